@@ -34,9 +34,14 @@ func init() {
 	RootCmd.AddCommand(
 		StartCommand,
 		PingCommand,
+		NetworkCommand,
 		EthereumCommand,
 		MaintainerCommand,
 		MaintainerCliCommand,
+		KeystoreCommand,
+		WalletsCommand,
+		DutiesCommand,
+		CoordinationJournalCommand,
 	)
 }
 
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+const (
+	// dutiesHostFlagName is the name of the flag setting the host of the
+	// running client's Client Info HTTP server.
+	dutiesHostFlagName = "host"
+	// dutiesJSONFlagName is the name of the flag switching the output to
+	// JSON.
+	dutiesJSONFlagName = "json"
+
+	// dutiesAverageBlockTime is the average Ethereum block time used to
+	// estimate when the upcoming duties start.
+	dutiesAverageBlockTime = 12 * time.Second
+	// dutiesRequestTimeout is the timeout of the diagnostics request.
+	dutiesRequestTimeout = 30 * time.Second
+)
+
+const dutiesDescription = `The duties command prints the upcoming duties of
+   the running client read from its diagnostics endpoint: the next
+   coordination windows of the wallets the client holds signers of, along
+   with the coordination leaders once they are known, the DKG state, the
+   wallets moving funds, and the work in progress. The command helps to
+   choose the time of a client restart. The Client Info HTTP server of the
+   client must be enabled.`
+
+// DutiesCommand contains the definition of the command printing the
+// upcoming duties of the running client.
+var DutiesCommand = &cobra.Command{
+	Use:   "duties",
+	Short: "Prints upcoming duties of the running client",
+	Long:  dutiesDescription,
+	Args:  cobra.NoArgs,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General, config.ClientInfo,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	RunE: duties,
+}
+
+// dutiesView is the printed representation of the tbtc_duties diagnostics.
+type dutiesView struct {
+	CurrentBlock        uint64                          `json:"current_block"`
+	MaintenanceMode     bool                            `json:"maintenance_mode"`
+	CoordinationWindows []tbtc.CoordinationWindowDuties `json:"coordination_windows"`
+	DkgState            string                          `json:"dkg_state"`
+	MovingFundsWallets  []string                        `json:"moving_funds_wallets"`
+	InFlight            []string                        `json:"in_flight"`
+}
+
+func duties(cmd *cobra.Command, args []string) error {
+	host, err := cmd.Flags().GetString(dutiesHostFlagName)
+	if err != nil {
+		return fmt.Errorf("failed to find host flag: %v", err)
+	}
+
+	view, err := fetchDuties(host, clientConfig.ClientInfo.Port)
+	if err != nil {
+		return err
+	}
+
+	if asJSON, _ := cmd.Flags().GetBool(dutiesJSONFlagName); asJSON {
+		return printJSON(os.Stdout, view)
+	}
+
+	return printDutiesView(os.Stdout, view)
+}
+
+// fetchDuties reads the tbtc_duties diagnostics of the client exposing the
+// Client Info HTTP server on the given host and port.
+func fetchDuties(host string, port int) (*dutiesView, error) {
+	view := &dutiesView{}
+	if err := fetchDiagnosticsSection(host, port, "tbtc_duties", view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// fetchDiagnosticsSection reads the given section of the diagnostics of the
+// client exposing the Client Info HTTP server on the given host and port,
+// and decodes it into the target.
+func fetchDiagnosticsSection(
+	host string,
+	port int,
+	section string,
+	target interface{},
+) error {
+	if port == 0 {
+		return fmt.Errorf("client info port is not configured")
+	}
+
+	client := &http.Client{Timeout: dutiesRequestTimeout}
+
+	response, err := client.Get(
+		fmt.Sprintf("http://%s:%d/diagnostics", host, port),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get client diagnostics: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"unexpected diagnostics response status: [%s]",
+			response.Status,
+		)
+	}
+
+	diagnostics := make(map[string]json.RawMessage)
+	if err := json.NewDecoder(response.Body).Decode(&diagnostics); err != nil {
+		return fmt.Errorf("cannot decode client diagnostics: [%v]", err)
+	}
+
+	content, ok := diagnostics[section]
+	if !ok || string(content) == "null" {
+		return fmt.Errorf(
+			"client diagnostics do not contain [%s]; "+
+				"is it a bootstrap node or an outdated client?",
+			section,
+		)
+	}
+
+	if err := json.Unmarshal(content, target); err != nil {
+		return fmt.Errorf("cannot decode client diagnostics [%s]: [%v]", section, err)
+	}
+
+	return nil
+}
+
+func printDutiesView(writer io.Writer, view *dutiesView) error {
+	w := tabwriter.NewWriter(writer, 2, 4, 1, ' ', 0)
+
+	fmt.Fprintf(w, "current block:\t%v\n", view.CurrentBlock)
+	fmt.Fprintf(w, "maintenance mode:\t%v\n", view.MaintenanceMode)
+	fmt.Fprintf(w, "DKG state:\t%s\n", view.DkgState)
+
+	for _, walletPublicKeyHash := range view.MovingFundsWallets {
+		fmt.Fprintf(w, "moving funds wallet:\t%s\n", walletPublicKeyHash)
+	}
+
+	for _, description := range view.InFlight {
+		fmt.Fprintf(w, "in progress:\t%s\n", description)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(writer)
+
+	w = tabwriter.NewWriter(writer, 2, 4, 1, ' ', 0)
+	fmt.Fprintf(w, "window\tend\tstarts in\twallet\tleader\t\n")
+
+	for _, window := range view.CoordinationWindows {
+		startsIn := "in progress"
+		if window.CoordinationBlock > view.CurrentBlock {
+			blocks := window.CoordinationBlock - view.CurrentBlock
+			startsIn = fmt.Sprintf(
+				"%v blocks (~%v)",
+				blocks,
+				time.Duration(blocks)*dutiesAverageBlockTime,
+			)
+		}
+
+		if len(window.Wallets) == 0 {
+			fmt.Fprintf(
+				w,
+				"%v\t%v\t%s\t-\t-\t\n",
+				window.CoordinationBlock,
+				window.EndBlock,
+				startsIn,
+			)
+			continue
+		}
+
+		for _, wallet := range window.Wallets {
+			leader := "unknown"
+			if wallet.IsLeader {
+				leader = "this operator"
+			} else if wallet.Leader != "" {
+				leader = wallet.Leader
+			}
+
+			fmt.Fprintf(
+				w,
+				"%v\t%v\t%s\t%s\t%s\t\n",
+				window.CoordinationBlock,
+				window.EndBlock,
+				startsIn,
+				wallet.WalletPublicKeyHash,
+				leader,
+			)
+		}
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	initFlags(
+		DutiesCommand,
+		&configFilePath,
+		clientConfig,
+		config.General, config.ClientInfo,
+	)
+
+	DutiesCommand.Flags().String(
+		dutiesHostFlagName,
+		"localhost",
+		"host of the running client's Client Info HTTP server",
+	)
+	DutiesCommand.Flags().Bool(
+		dutiesJSONFlagName,
+		false,
+		"print the output in JSON",
+	)
+}
@@ -15,9 +15,13 @@ import (
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	chainEthereum "github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/maintainer/depositrefund"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/secret"
+	"github.com/keep-network/keep-core/pkg/storage"
 	"github.com/keep-network/keep-core/pkg/tbtc"
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
 )
 
 func initGlobalFlags(
@@ -117,6 +121,83 @@ func initEthereumFlags(cmd *cobra.Command, cfg *config.Config) {
 		"The local filesystem path to Keep operator account keyfile.",
 	)
 
+	cmd.Flags().StringVar(
+		&cfg.Secrets.Provider,
+		"secrets.provider",
+		secret.EnvProvider,
+		"Source of the operator key file, its password and the storage password: env, file, agent or vault.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Secrets.Dir,
+		"secrets.dir",
+		"",
+		"Directory the file secret provider reads secret files from.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Secrets.AgentSocket,
+		"secrets.agentSocket",
+		"",
+		"Path to the Unix socket of the agent secret provider.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Secrets.Vault.URL,
+		"secrets.vault.url",
+		"",
+		"Address of the Vault server of the vault secret provider.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Secrets.Vault.Path,
+		"secrets.vault.path",
+		"",
+		"Path of the Vault key-value secret holding the client secrets, e.g. secret/data/keep.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Secrets.Vault.TokenFile,
+		"secrets.vault.tokenFile",
+		"",
+		"Path to the file holding the Vault token; if not set, VAULT_TOKEN environment variable is used.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Secrets.Timeout,
+		"secrets.timeout",
+		secret.DefaultTimeout,
+		"Timeout of a single request to the agent and vault secret providers.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.RemoteSigner.URL,
+		"remoteSigner.url",
+		"",
+		"JSON-RPC endpoint of the remote signer holding the operator key, e.g. Web3Signer or Clef.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.RemoteSigner.Address,
+		"remoteSigner.address",
+		"",
+		"Operator account address held by the remote signer.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.RemoteSigner.API,
+		"remoteSigner.api",
+		chainEthereum.EthRemoteSignerAPI,
+		"JSON-RPC API of the remote signer: eth for Web3Signer or account for Clef.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.RemoteSigner.Timeout,
+		"remoteSigner.timeout",
+		chainEthereum.DefaultRemoteSignerTimeout,
+		"Timeout of a single request to the remote signer.",
+	)
+
 	cmd.Flags().DurationVar(
 		&cfg.Ethereum.MiningCheckInterval,
 		"ethereum.miningCheckInterval",
@@ -247,6 +328,13 @@ func initStorageFlags(cmd *cobra.Command, cfg *config.Config) {
 		"",
 		"Location to store the Keep client key shares and other sensitive data.",
 	)
+
+	cmd.Flags().StringVar(
+		&cfg.Storage.Backend,
+		"storage.backend",
+		storage.DiskBackend,
+		"Storage backend keeping the key shares and work data: disk or bolt.",
+	)
 }
 
 // Initialize flags for ClientInfo configuration.
@@ -308,6 +396,70 @@ func initTbtcFlags(cmd *cobra.Command, cfg *config.Config) {
 		tbtc.DefaultKeyGenerationConcurrency,
 		"tECDSA key generation concurrency.",
 	)
+
+	cmd.Flags().StringVar(
+		&cfg.Tbtc.KeyStoreCheckFailOn,
+		"tbtc.keyStoreCheckFailOn",
+		tbtc.DefaultKeyStoreCheckFailOn,
+		"Minimum severity of key store issues found at startup that prevents "+
+			"the client from starting: warning, error or never.",
+	)
+
+	cmd.Flags().DurationVar(
+		&cfg.Tbtc.ShutdownTimeout,
+		"tbtc.shutdownTimeout",
+		tbtc.DefaultShutdownTimeout,
+		"Maximum time the client waits for in-flight signing and DKG on shutdown.",
+	)
+
+	cmd.Flags().BoolVar(
+		&cfg.Tbtc.MaintenanceMode,
+		"tbtc.maintenanceMode",
+		false,
+		"Decline new coordination windows, DKG executions and joining the "+
+			"sortition pool while completing the duties in progress.",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.Tbtc.CoordinationJournalRetention,
+		"tbtc.coordinationJournalRetention",
+		tbtc.DefaultCoordinationJournalRetention,
+		"Number of the most recent coordination windows of each wallet kept "+
+			"in the coordination journal.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Tbtc.DepositSweepSelection,
+		"tbtc.depositSweepSelection",
+		tbtcpg.OldestDepositSweepSelection,
+		"Strategy selecting deposits to sweep when proposing a deposit "+
+			"sweep: oldest or feeOptimized.",
+	)
+
+	cmd.Flags().StringVar(
+		&cfg.Tbtc.RedemptionSelection,
+		"tbtc.redemptionSelection",
+		tbtcpg.OldestRedemptionSelection,
+		"Strategy selecting pending redemption requests when proposing "+
+			"a redemption: oldest or feePriority.",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.Tbtc.RedemptionTimeoutWarningThreshold,
+		"tbtc.redemptionTimeoutWarningThreshold",
+		tbtc.DefaultRedemptionTimeoutWarningThreshold,
+		"Percentage of the redemption timeout elapsed since the creation of "+
+			"a pending redemption request at which a warning is raised. "+
+			"The value of 0 disables the redemption monitor.",
+	)
+
+	cmd.Flags().IntVar(
+		&cfg.Tbtc.RedemptionTimeoutCriticalThreshold,
+		"tbtc.redemptionTimeoutCriticalThreshold",
+		tbtc.DefaultRedemptionTimeoutCriticalThreshold,
+		"Percentage of the redemption timeout elapsed since the creation of "+
+			"a pending redemption request at which a critical alert is raised.",
+	)
 }
 
 // Initialize flags for Maintainer configuration.
@@ -363,6 +515,28 @@ func initMaintainerFlags(command *cobra.Command, cfg *config.Config) {
 		"The wait time which should be applied when there are no more "+
 			"transaction proofs to submit.",
 	)
+
+	command.Flags().BoolVar(
+		&cfg.Maintainer.DepositRefund.Enabled,
+		"depositRefund",
+		false,
+		"Start deposit refund watcher.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.DepositRefund.CheckInterval,
+		"depositRefund.checkInterval",
+		depositrefund.DefaultCheckInterval,
+		"The interval between deposit refund checks.",
+	)
+
+	command.Flags().DurationVar(
+		&cfg.Maintainer.DepositRefund.WarningPeriod,
+		"depositRefund.warningPeriod",
+		depositrefund.DefaultWarningPeriod,
+		"The time before the refund locktime of an unswept deposit at which "+
+			"the deposit is reported as nearing its refund.",
+	)
 }
 
 // Initialize flags for Developer configuration.
@@ -169,6 +169,50 @@ var cmdFlagsTests = map[string]struct {
 		flagValue:     "./flagged/location/dude",
 		defaultValue:  "",
 	},
+	"secrets.provider": {
+		readValueFunc: func(c *config.Config) interface{} { return c.Secrets.Provider },
+		flagName:      "--secrets.provider",
+		flagValue:     "file",
+		defaultValue:  "env",
+	},
+	"secrets.dir": {
+		readValueFunc: func(c *config.Config) interface{} { return c.Secrets.Dir },
+		flagName:      "--secrets.dir",
+		flagValue:     "/run/keep/secrets",
+		defaultValue:  "",
+	},
+	"secrets.vault.url": {
+		readValueFunc: func(c *config.Config) interface{} { return c.Secrets.Vault.URL },
+		flagName:      "--secrets.vault.url",
+		flagValue:     "http://127.0.0.1:8200",
+		defaultValue:  "",
+	},
+	"secrets.timeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Secrets.Timeout },
+		flagName:              "--secrets.timeout",
+		flagValue:             "30s",
+		expectedValueFromFlag: 30 * time.Second,
+		defaultValue:          10 * time.Second,
+	},
+	"remoteSigner.api": {
+		readValueFunc: func(c *config.Config) interface{} { return c.RemoteSigner.API },
+		flagName:      "--remoteSigner.api",
+		flagValue:     "account",
+		defaultValue:  "eth",
+	},
+	"remoteSigner.timeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.RemoteSigner.Timeout },
+		flagName:              "--remoteSigner.timeout",
+		flagValue:             "30s",
+		expectedValueFromFlag: 30 * time.Second,
+		defaultValue:          10 * time.Second,
+	},
+	"storage.backend": {
+		readValueFunc: func(c *config.Config) interface{} { return c.Storage.Backend },
+		flagName:      "--storage.backend",
+		flagValue:     "bolt",
+		defaultValue:  "disk",
+	},
 	"clientInfo.port": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.ClientInfo.Port },
 		flagName:              "--clientInfo.port",
@@ -225,6 +269,55 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 101,
 		defaultValue:          runtime.GOMAXPROCS(0),
 	},
+	"tbtc.shutdownTimeout": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.ShutdownTimeout },
+		flagName:              "--tbtc.shutdownTimeout",
+		flagValue:             "10m",
+		expectedValueFromFlag: 10 * time.Minute,
+		defaultValue:          5 * time.Minute,
+	},
+	"tbtc.maintenanceMode": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.MaintenanceMode },
+		flagName:              "--tbtc.maintenanceMode",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"tbtc.coordinationJournalRetention": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.CoordinationJournalRetention },
+		flagName:              "--tbtc.coordinationJournalRetention",
+		flagValue:             "56",
+		expectedValueFromFlag: 56,
+		defaultValue:          112,
+	},
+	"tbtc.depositSweepSelection": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.DepositSweepSelection },
+		flagName:              "--tbtc.depositSweepSelection",
+		flagValue:             "feeOptimized",
+		expectedValueFromFlag: "feeOptimized",
+		defaultValue:          "oldest",
+	},
+	"tbtc.redemptionSelection": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.RedemptionSelection },
+		flagName:              "--tbtc.redemptionSelection",
+		flagValue:             "feePriority",
+		expectedValueFromFlag: "feePriority",
+		defaultValue:          "oldest",
+	},
+	"tbtc.redemptionTimeoutWarningThreshold": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.RedemptionTimeoutWarningThreshold },
+		flagName:              "--tbtc.redemptionTimeoutWarningThreshold",
+		flagValue:             "40",
+		expectedValueFromFlag: 40,
+		defaultValue:          50,
+	},
+	"tbtc.redemptionTimeoutCriticalThreshold": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Tbtc.RedemptionTimeoutCriticalThreshold },
+		flagName:              "--tbtc.redemptionTimeoutCriticalThreshold",
+		flagValue:             "90",
+		expectedValueFromFlag: 90,
+		defaultValue:          75,
+	},
 	"maintainer.bitcoinDifficulty": {
 		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.BitcoinDifficulty.Enabled },
 		flagName:              "--bitcoinDifficulty",
@@ -274,6 +367,27 @@ var cmdFlagsTests = map[string]struct {
 		expectedValueFromFlag: 20 * time.Minute,
 		defaultValue:          10 * time.Minute,
 	},
+	"maintainer.depositRefund": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.DepositRefund.Enabled },
+		flagName:              "--depositRefund",
+		flagValue:             "", // don't provide any value
+		expectedValueFromFlag: true,
+		defaultValue:          false,
+	},
+	"maintainer.depositRefund.checkInterval": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.DepositRefund.CheckInterval },
+		flagName:              "--depositRefund.checkInterval",
+		flagValue:             "30m",
+		expectedValueFromFlag: 30 * time.Minute,
+		defaultValue:          time.Hour,
+	},
+	"maintainer.depositRefund.warningPeriod": {
+		readValueFunc:         func(c *config.Config) interface{} { return c.Maintainer.DepositRefund.WarningPeriod },
+		flagName:              "--depositRefund.warningPeriod",
+		flagValue:             "48h",
+		expectedValueFromFlag: 48 * time.Hour,
+		defaultValue:          7 * 24 * time.Hour,
+	},
 	"developer.randomBeaconAddress": {
 		readValueFunc: func(c *config.Config) interface{} {
 			address, _ := c.Ethereum.ContractAddress(chainEthereum.RandomBeaconContractName)
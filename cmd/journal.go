@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+const (
+	// journalWalletFlagName is the name of the flag narrowing the journal
+	// down to a single wallet.
+	journalWalletFlagName = "wallet"
+	// journalLimitFlagName is the name of the flag limiting the number of
+	// printed windows.
+	journalLimitFlagName = "limit"
+)
+
+const journalDescription = `The coordination-journal command prints the
+   coordination journal of the running client read from its diagnostics
+   endpoint. For each past coordination window of the wallets the client
+   holds signers of, the journal holds the coordination seed, the leader,
+   the actions checklist, the proposal, the observed faults and the outcome
+   of the proposed action. The most recent windows are printed last. The
+   Client Info HTTP server of the client must be enabled.`
+
+// CoordinationJournalCommand contains the definition of the command printing
+// the coordination journal of the running client.
+var CoordinationJournalCommand = &cobra.Command{
+	Use:   "coordination-journal",
+	Short: "Prints the coordination journal of the running client",
+	Long:  journalDescription,
+	Args:  cobra.NoArgs,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General, config.ClientInfo,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	RunE: coordinationJournal,
+}
+
+// journalView is the printed representation of the tbtc_coordination_journal
+// diagnostics.
+type journalView struct {
+	Retention int                              `json:"retention"`
+	Entries   []*tbtc.CoordinationJournalEntry `json:"entries"`
+}
+
+func coordinationJournal(cmd *cobra.Command, args []string) error {
+	host, err := cmd.Flags().GetString(dutiesHostFlagName)
+	if err != nil {
+		return fmt.Errorf("failed to find host flag: %v", err)
+	}
+
+	wallet, err := cmd.Flags().GetString(journalWalletFlagName)
+	if err != nil {
+		return fmt.Errorf("failed to find wallet flag: %v", err)
+	}
+
+	limit, err := cmd.Flags().GetInt(journalLimitFlagName)
+	if err != nil {
+		return fmt.Errorf("failed to find limit flag: %v", err)
+	}
+
+	view := &journalView{}
+	if err := fetchDiagnosticsSection(
+		host,
+		clientConfig.ClientInfo.Port,
+		"tbtc_coordination_journal",
+		view,
+	); err != nil {
+		return err
+	}
+
+	if wallet != "" {
+		walletPublicKeyHash, err := newWalletPublicKeyHash(wallet)
+		if err != nil {
+			return fmt.Errorf("invalid wallet public key hash: [%v]", err)
+		}
+
+		view.Entries = filterJournalEntries(
+			view.Entries,
+			hex.EncodeToString(walletPublicKeyHash[:]),
+		)
+	}
+
+	if limit > 0 && len(view.Entries) > limit {
+		view.Entries = view.Entries[len(view.Entries)-limit:]
+	}
+
+	if asJSON, _ := cmd.Flags().GetBool(dutiesJSONFlagName); asJSON {
+		return printJSON(os.Stdout, view)
+	}
+
+	return printJournalView(os.Stdout, view)
+}
+
+func filterJournalEntries(
+	entries []*tbtc.CoordinationJournalEntry,
+	walletPublicKeyHash string,
+) []*tbtc.CoordinationJournalEntry {
+	filtered := make([]*tbtc.CoordinationJournalEntry, 0)
+	for _, entry := range entries {
+		if entry.WalletPublicKeyHash == walletPublicKeyHash {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	return filtered
+}
+
+func printJournalView(writer io.Writer, view *journalView) error {
+	w := tabwriter.NewWriter(writer, 2, 4, 1, ' ', 0)
+	fmt.Fprintf(w, "window\twallet\tleader\tproposal\tfaults\toutcome\t\n")
+
+	for _, entry := range view.Entries {
+		outcome := "-"
+		if entry.Error != "" {
+			outcome = fmt.Sprintf("coordination failed: %s", entry.Error)
+		} else if entry.Outcome != nil {
+			outcome = entry.Outcome.Status
+			if entry.Outcome.Error != "" {
+				outcome = fmt.Sprintf("%s: %s", outcome, entry.Outcome.Error)
+			}
+		} else if entry.ProposedAction != tbtc.ActionNoop.String() {
+			outcome = "pending"
+		}
+
+		leader := entry.Leader
+		if leader == "" {
+			leader = "-"
+		} else if entry.Proposer != "" && entry.Proposer != entry.Leader {
+			leader = fmt.Sprintf("%s (backup: %s)", leader, entry.Proposer)
+		}
+
+		proposal := entry.ProposedAction
+		if proposal == "" {
+			proposal = "-"
+		}
+
+		fmt.Fprintf(
+			w,
+			"%v\t%s\t%s\t%s\t%v\t%s\t\n",
+			entry.CoordinationBlock,
+			entry.WalletPublicKeyHash,
+			leader,
+			proposal,
+			len(entry.Faults),
+			outcome,
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(
+		writer,
+		"Printed [%v] windows; the client keeps up to [%v] windows per wallet\n",
+		len(view.Entries),
+		view.Retention,
+	)
+
+	return nil
+}
+
+func init() {
+	initFlags(
+		CoordinationJournalCommand,
+		&configFilePath,
+		clientConfig,
+		config.General, config.ClientInfo,
+	)
+
+	CoordinationJournalCommand.Flags().String(
+		dutiesHostFlagName,
+		"localhost",
+		"host of the running client's Client Info HTTP server",
+	)
+	CoordinationJournalCommand.Flags().String(
+		journalWalletFlagName,
+		"",
+		"print only the windows of the wallet with the given public key hash",
+	)
+	CoordinationJournalCommand.Flags().Int(
+		journalLimitFlagName,
+		0,
+		"print only the given number of the most recent windows",
+	)
+	CoordinationJournalCommand.Flags().Bool(
+		dutiesJSONFlagName,
+		false,
+		"print the output in JSON",
+	)
+}
@@ -0,0 +1,564 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/secret"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+const (
+	// #nosec G101 (look for hardcoded credentials)
+	// This line doesn't contain any credentials.
+	// It's just the name of the environment variable.
+	backupPasswordEnvVariable = "KEEP_BACKUP_PASSWORD"
+
+	// #nosec G101 (look for hardcoded credentials)
+	// This line doesn't contain any credentials.
+	// It's just the name of the environment variable.
+	newPasswordEnvVariable = "KEEP_NEW_STORAGE_PASSWORD"
+
+	// keystoreOfflineFlagName is the name of the flag disabling on-chain
+	// verification of the backed up signers.
+	keystoreOfflineFlagName = "offline"
+)
+
+// KeystoreCommand contains the definition of tools associated with the
+// client's key store.
+var KeystoreCommand = &cobra.Command{
+	Use:   "keystore",
+	Short: "Key store maintenance tools",
+	Long: "The tool exposes commands for backing up, verifying and " +
+		"restoring the client's key store, and for changing the password " +
+		"the key store is encrypted with.",
+	TraverseChildren: true,
+}
+
+const keystoreBackupDescription = `The backup command writes an encrypted
+   archive of all the signers kept in the key store, including signers of
+   archived wallets, to the given file. The archive is encrypted with the
+   backup password read from the ` + backupPasswordEnvVariable + ` environment
+   variable or provided in the prompt. The key store files are archived as they
+   are stored on disk, so restoring them requires the same Ethereum key file
+   password the key store is encrypted with.`
+
+var keystoreBackupCommand = cobra.Command{
+	Use:              "backup <archive-file>",
+	Short:            "back up the key store",
+	Long:             keystoreBackupDescription,
+	Args:             cobra.ExactArgs(1),
+	TraverseChildren: true,
+	PreRun:           readKeystoreConfig,
+	RunE:             keystoreBackup,
+}
+
+const keystoreVerifyDescription = `The verify command decrypts the given
+   backup archive, checks its integrity, and verifies all the backed up tBTC
+   signers. For each signer, the command checks whether its private key share
+   corresponds to the wallet public key and whether the wallet with that public
+   key exists on-chain. The on-chain check can be skipped with the --offline
+   flag.`
+
+var keystoreVerifyCommand = cobra.Command{
+	Use:              "verify <archive-file>",
+	Short:            "verify a key store backup",
+	Long:             keystoreVerifyDescription,
+	Args:             cobra.ExactArgs(1),
+	TraverseChildren: true,
+	PreRun:           readKeystoreConfig,
+	RunE:             keystoreVerify,
+}
+
+const keystoreRestoreDescription = `The restore command decrypts the given
+   backup archive, checks its integrity, and restores the key store into the
+   configured storage directory. To not overwrite or mix key shares, the key
+   store in the storage directory must be empty.`
+
+var keystoreRestoreCommand = cobra.Command{
+	Use:              "restore <archive-file>",
+	Short:            "restore the key store from a backup",
+	Long:             keystoreRestoreDescription,
+	Args:             cobra.ExactArgs(1),
+	TraverseChildren: true,
+	PreRun:           readKeystoreConfig,
+	RunE:             keystoreRestore,
+}
+
+const keystoreRotatePasswordDescription = `The rotate-password command
+   re-encrypts all the key store and work data kept in the configured storage
+   directory with a new password. The data are currently encrypted with the
+   storage-password secret if the secret provider holds it, or with the
+   Ethereum key file password otherwise. The new password is read from the
+   ` + newPasswordEnvVariable + ` environment variable or provided in the
+   prompt. The re-encrypted data are staged and verified before they replace
+   the current data. If the command is interrupted, the rotation is either
+   rolled back or completed the next time the storage is opened, so the data
+   are never encrypted with mixed passwords. The command refuses to run while
+   the client uses the storage. If the storage is encrypted with the Ethereum
+   key file password, the key file is re-encrypted with the new password as
+   well, so the client can be started with the new password right away.
+   Otherwise, the new password must be stored as the storage-password secret
+   before the client is started.`
+
+var keystoreRotatePasswordCommand = cobra.Command{
+	Use:              "rotate-password",
+	Short:            "change the storage encryption password",
+	Long:             keystoreRotatePasswordDescription,
+	Args:             cobra.NoArgs,
+	TraverseChildren: true,
+	PreRun:           readKeystoreConfig,
+	RunE:             keystoreRotatePassword,
+}
+
+func readKeystoreConfig(cmd *cobra.Command, args []string) {
+	if err := clientConfig.ReadConfig(
+		configFilePath,
+		cmd.Flags(),
+		config.General, config.Ethereum, config.Storage,
+	); err != nil {
+		logger.Fatalf("error reading config: %v", err)
+	}
+}
+
+func keystoreBackup(cmd *cobra.Command, args []string) error {
+	archivePath := args[0]
+
+	if _, err := os.Stat(archivePath); err == nil {
+		return fmt.Errorf("archive file [%s] already exists", archivePath)
+	}
+
+	backupPassword, err := readBackupPassword(true)
+	if err != nil {
+		return err
+	}
+
+	archiveFile, err := os.OpenFile(
+		filepath.Clean(archivePath),
+		os.O_WRONLY|os.O_CREATE|os.O_EXCL,
+		0600,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot create archive file: [%v]", err)
+	}
+	defer archiveFile.Close()
+
+	manifest, err := storage.BackupKeyStore(
+		clientConfig.Storage,
+		archiveFile,
+		backupPassword,
+	)
+	if err != nil {
+		_ = os.Remove(archivePath)
+		return fmt.Errorf("cannot back up key store: [%v]", err)
+	}
+
+	if err := archiveFile.Sync(); err != nil {
+		return fmt.Errorf("cannot write archive file: [%v]", err)
+	}
+
+	fmt.Printf(
+		"Backed up [%v] key store files to [%s]\n",
+		len(manifest.Files),
+		archivePath,
+	)
+
+	return nil
+}
+
+func keystoreVerify(cmd *cobra.Command, args []string) error {
+	offline, err := cmd.Flags().GetBool(keystoreOfflineFlagName)
+	if err != nil {
+		return fmt.Errorf("failed to find offline flag: %v", err)
+	}
+
+	backup, err := readKeystoreBackup(args[0])
+	if err != nil {
+		return err
+	}
+
+	var keyStoreChain tbtc.KeyStoreChain
+	if !offline {
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			cmd.Context(),
+			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not connect to Ethereum chain: [%v]",
+				err,
+			)
+		}
+
+		keyStoreChain = tbtcChain
+	}
+
+	storagePassword, err := clientConfig.StoragePassword(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("cannot read storage password: [%v]", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', 0)
+	fmt.Fprintf(w, "file\twallet\tmember\tstate\tresult\t\n")
+
+	failures := 0
+	for _, fileInfo := range backup.Manifest.Files {
+		wallet, member, state := "-", "-", "-"
+
+		verifyErr := func() error {
+			content, err := backup.Decrypt(fileInfo.Path, storagePassword)
+			if err != nil {
+				return fmt.Errorf("cannot decrypt: %v", err)
+			}
+
+			// Only tBTC signers can be verified further.
+			if !strings.HasPrefix(fileInfo.Path, "tbtc/") {
+				return nil
+			}
+
+			storedSigner, err := tbtc.VerifyStoredSigner(content, keyStoreChain)
+			if storedSigner != nil {
+				wallet = fmt.Sprintf("0x%x", storedSigner.WalletPublicKeyHash)
+				member = fmt.Sprintf(
+					"%v/%v",
+					storedSigner.MemberIndex,
+					storedSigner.GroupSize,
+				)
+				if keyStoreChain != nil {
+					state = storedSigner.WalletState.String()
+				}
+
+				if err == nil && !strings.Contains(
+					fileInfo.Path,
+					"/"+storedSigner.WalletStorageKey+"/",
+				) {
+					err = fmt.Errorf("stored under wrong wallet directory")
+				}
+			}
+
+			return err
+		}()
+
+		result := "ok"
+		if verifyErr != nil {
+			failures++
+			result = fmt.Sprintf("failed: %v", verifyErr)
+		}
+
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t\n",
+			fileInfo.Path,
+			wallet,
+			member,
+			state,
+			result,
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if failures > 0 {
+		return fmt.Errorf(
+			"verification of [%v] out of [%v] key store files failed",
+			failures,
+			len(backup.Manifest.Files),
+		)
+	}
+
+	fmt.Printf(
+		"Verified [%v] key store files backed up at [%v]\n",
+		len(backup.Manifest.Files),
+		backup.Manifest.CreatedAt,
+	)
+
+	return nil
+}
+
+func keystoreRestore(cmd *cobra.Command, args []string) error {
+	backup, err := readKeystoreBackup(args[0])
+	if err != nil {
+		return err
+	}
+
+	storagePassword, err := clientConfig.StoragePassword(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("cannot read storage password: [%v]", err)
+	}
+
+	// Make sure the restored key store is usable with the configured
+	// storage password before touching the storage directory.
+	for _, fileInfo := range backup.Manifest.Files {
+		if _, err := backup.Decrypt(fileInfo.Path, storagePassword); err != nil {
+			return fmt.Errorf(
+				"cannot decrypt [%s] with the configured storage "+
+					"password: [%v]",
+				fileInfo.Path,
+				err,
+			)
+		}
+	}
+
+	if err := storage.RestoreKeyStore(clientConfig.Storage, backup); err != nil {
+		return fmt.Errorf("cannot restore key store: [%v]", err)
+	}
+
+	fmt.Printf(
+		"Restored [%v] key store files to [%s]\n",
+		len(backup.Manifest.Files),
+		clientConfig.Storage.Dir,
+	)
+
+	return nil
+}
+
+func keystoreRotatePassword(cmd *cobra.Command, args []string) error {
+	currentPassword, err := clientConfig.StoragePassword(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("cannot read storage password: [%v]", err)
+	}
+
+	_, storagePasswordSecret, err := secret.Lookup(
+		cmd.Context(),
+		clientConfig.SecretProvider(),
+		secret.StoragePassword,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot read storage password: [%v]", err)
+	}
+
+	_, keySecret, err := secret.Lookup(
+		cmd.Context(),
+		clientConfig.SecretProvider(),
+		secret.EthereumKey,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot read Ethereum key: [%v]", err)
+	}
+
+	// The storage is encrypted with the Ethereum key file password so the key
+	// file must be re-encrypted along with the storage for the client to
+	// start. Make sure the key file can be re-encrypted before the storage
+	// is touched.
+	keyFile := ""
+	if !storagePasswordSecret && !keySecret {
+		keyFile = clientConfig.Ethereum.Account.KeyFile
+
+		if _, err := ethutil.DecryptKeyFile(keyFile, currentPassword); err != nil {
+			return fmt.Errorf(
+				"cannot decrypt Ethereum key file [%s]: [%v]",
+				keyFile,
+				err,
+			)
+		}
+	}
+
+	newPassword, err := readPassword(
+		newPasswordEnvVariable,
+		"New Storage Password",
+		true,
+	)
+	if err != nil {
+		return err
+	}
+
+	if newPassword == currentPassword {
+		return fmt.Errorf("new password must differ from the current one")
+	}
+
+	count, err := storage.RotatePassword(
+		clientConfig.Storage,
+		currentPassword,
+		newPassword,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot rotate storage password: [%v]", err)
+	}
+
+	fmt.Printf(
+		"Re-encrypted [%v] storage entries in [%s] with the new password\n",
+		count,
+		clientConfig.Storage.Dir,
+	)
+
+	if keyFile == "" {
+		fmt.Printf(
+			"Store the new password as the [%s] secret before starting "+
+				"the client\n",
+			secret.StoragePassword,
+		)
+
+		return nil
+	}
+
+	if err := reencryptKeyFile(keyFile, currentPassword, newPassword); err != nil {
+		return fmt.Errorf(
+			"storage is encrypted with the new password but Ethereum key "+
+				"file [%s] could not be re-encrypted; re-encrypt the key "+
+				"file with the new password or store the new password as "+
+				"the [%s] secret before starting the client: [%v]",
+			keyFile,
+			secret.StoragePassword,
+			err,
+		)
+	}
+
+	fmt.Printf(
+		"Re-encrypted Ethereum key file [%s] with the new password; "+
+			"provide the new password as the Ethereum key file password "+
+			"when starting the client\n",
+		keyFile,
+	)
+
+	return nil
+}
+
+// reencryptKeyFile re-encrypts the Ethereum key file with the new password.
+// The re-encrypted key is written to a temporary file first, which then
+// atomically replaces the key file.
+func reencryptKeyFile(
+	keyFile string,
+	currentPassword string,
+	newPassword string,
+) error {
+	key, err := ethutil.DecryptKeyFile(keyFile, currentPassword)
+	if err != nil {
+		return fmt.Errorf("cannot decrypt key file: [%w]", err)
+	}
+
+	keyJSON, err := keystore.EncryptKey(
+		key,
+		newPassword,
+		keystore.StandardScryptN,
+		keystore.StandardScryptP,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot encrypt key: [%w]", err)
+	}
+
+	tempFile, err := os.CreateTemp(
+		filepath.Dir(keyFile),
+		"."+filepath.Base(keyFile)+".*",
+	)
+	if err != nil {
+		return fmt.Errorf("cannot create temporary key file: [%w]", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	if _, err := tempFile.Write(keyJSON); err != nil {
+		return fmt.Errorf("cannot write temporary key file: [%w]", err)
+	}
+
+	if err := tempFile.Sync(); err != nil {
+		return fmt.Errorf("cannot write temporary key file: [%w]", err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("cannot write temporary key file: [%w]", err)
+	}
+
+	if err := os.Rename(tempFile.Name(), keyFile); err != nil {
+		return fmt.Errorf("cannot replace key file: [%w]", err)
+	}
+
+	return nil
+}
+
+func readKeystoreBackup(archivePath string) (*storage.KeyStoreBackup, error) {
+	backupPassword, err := readBackupPassword(false)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveFile, err := os.Open(filepath.Clean(archivePath))
+	if err != nil {
+		return nil, fmt.Errorf("cannot open archive file: [%v]", err)
+	}
+	defer archiveFile.Close()
+
+	backup, err := storage.ReadKeyStoreBackup(archiveFile, backupPassword)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read key store backup: [%v]", err)
+	}
+
+	return backup, nil
+}
+
+// readBackupPassword reads the backup password from the environment variable
+// or prompts the user for it. If confirm is set, the prompted password has
+// to be entered twice.
+func readBackupPassword(confirm bool) (string, error) {
+	return readPassword(backupPasswordEnvVariable, "Backup Password", confirm)
+}
+
+// readPassword reads the password with the given name from the given
+// environment variable or prompts the user for it. If confirm is set, the
+// prompted password has to be entered twice.
+func readPassword(envVariable string, name string, confirm bool) (string, error) {
+	if password := os.Getenv(envVariable); password != "" {
+		return password, nil
+	}
+
+	password, err := config.ReadPassword(fmt.Sprintf("Enter %s: ", name))
+	if err != nil {
+		return "", err
+	}
+
+	if password == "" {
+		return "", fmt.Errorf("%s must not be empty", strings.ToLower(name))
+	}
+
+	if confirm {
+		confirmation, err := config.ReadPassword(
+			fmt.Sprintf("Confirm %s: ", name),
+		)
+		if err != nil {
+			return "", err
+		}
+
+		if confirmation != password {
+			return "", fmt.Errorf("%ss do not match", strings.ToLower(name))
+		}
+	}
+
+	return password, nil
+}
+
+func init() {
+	for _, command := range []*cobra.Command{
+		&keystoreBackupCommand,
+		&keystoreVerifyCommand,
+		&keystoreRestoreCommand,
+		&keystoreRotatePasswordCommand,
+	} {
+		initFlags(
+			command,
+			&configFilePath,
+			clientConfig,
+			config.General, config.Ethereum, config.Storage,
+		)
+
+		KeystoreCommand.AddCommand(command)
+	}
+
+	keystoreVerifyCommand.Flags().Bool(
+		keystoreOfflineFlagName,
+		false,
+		"skip the on-chain verification of wallets",
+	)
+}
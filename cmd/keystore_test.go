@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+func TestReencryptKeyFile(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	keyJSON, err := keystore.EncryptKey(
+		key,
+		"current-password",
+		keystore.LightScryptN,
+		keystore.LightScryptP,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key.json")
+	if err := os.WriteFile(keyFile, keyJSON, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reencryptKeyFile(
+		keyFile,
+		"wrong-password",
+		"new-password",
+	); err == nil {
+		t.Fatal("expected error for wrong current password")
+	}
+
+	if err := reencryptKeyFile(
+		keyFile,
+		"current-password",
+		"new-password",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ethutil.DecryptKeyFile(keyFile, "current-password"); err == nil {
+		t.Error("expected key file not to decrypt with the current password")
+	}
+
+	reencryptedKey, err := ethutil.DecryptKeyFile(keyFile, "new-password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reencryptedKey.Address != key.Address {
+		t.Errorf(
+			"unexpected address\nexpected: %s\nactual:   %s",
+			key.Address.Hex(),
+			reencryptedKey.Address.Hex(),
+		)
+	}
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("unexpected number of files: [%v]", len(entries))
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/keep-network/keep-core/config"
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
-	"github.com/keep-network/keep-core/pkg/maintainer"
 )
 
 // MaintainerCommand contains the definition of the maintainer command-line
@@ -53,6 +52,8 @@ func maintainers(cmd *cobra.Command, args []string) error {
 		ctx,
 		clientConfig.Ethereum,
 		clientConfig.Maintainer,
+		ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+		ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
 	)
 	if err != nil {
 		return fmt.Errorf(
@@ -64,6 +65,8 @@ func maintainers(cmd *cobra.Command, args []string) error {
 	_, tbtcChain, _, _, _, err := ethereum.Connect(
 		ctx,
 		clientConfig.Ethereum,
+		ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+		ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
 	)
 	if err != nil {
 		return fmt.Errorf(
@@ -72,13 +75,28 @@ func maintainers(cmd *cobra.Command, args []string) error {
 		)
 	}
 
-	maintainer.Initialize(
-		ctx,
-		clientConfig.Maintainer,
-		btcChain,
-		btcDiffChain,
-		tbtcChain,
-	)
+	runner := &maintainerRunner{
+		ctx:                ctx,
+		btcChain:           btcChain,
+		btcDiffChain:       btcDiffChain,
+		spvChain:           tbtcChain,
+		depositRefundChain: tbtcChain,
+	}
+	runner.run(clientConfig.Maintainer)
+
+	if configFilePath != "" {
+		configWatcher := config.NewWatcher(
+			configFilePath,
+			clientConfig,
+			config.MaintainerCategories...,
+		)
+		configWatcher.RegisterUpdateHook(
+			"maintainer",
+			maintainerUpdateHook(runner),
+		)
+
+		go configWatcher.Watch(ctx)
+	}
 
 	<-ctx.Done()
 	return fmt.Errorf("unexpected context cancellation")
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
@@ -14,6 +16,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
 	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
+	"github.com/keep-network/keep-core/pkg/tbtc"
 	"github.com/keep-network/keep-core/pkg/tbtcpg"
 )
 
@@ -32,6 +35,9 @@ var (
 	// submitRedemptionProofCommand:
 	transactionHashFlagName = "transaction-hash"
 	confirmationsFlagName   = "confirmations"
+
+	// simulateProposalCommand:
+	actionFlagName = "action"
 )
 
 // MaintainerCliCommand contains the definition of tools associated with maintainers
@@ -78,6 +84,8 @@ var listDepositsCommand = cobra.Command{
 		_, tbtcChain, _, _, _, err := ethereum.Connect(
 			ctx,
 			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
 		)
 		if err != nil {
 			return fmt.Errorf(
@@ -132,10 +140,10 @@ var listDepositsCommand = cobra.Command{
 
 func printDepositsTable(deposits []*tbtcpg.Deposit) error {
 	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', tabwriter.AlignRight)
-	fmt.Fprintf(w, "index\twallet\tvalue (BTC)\tdeposit key\trevealed deposit data\tconfirmations\tswept\t\n")
+	fmt.Fprintf(w, "index\twallet\tvalue (BTC)\tdeposit key\trevealed deposit data\tconfirmations\tswept\trefunded\t\n")
 
 	for i, deposit := range deposits {
-		fmt.Fprintf(w, "%d\t%s\t%.5f\t%s\t%s\t%d\t%t\t\n",
+		fmt.Fprintf(w, "%d\t%s\t%.5f\t%s\t%s\t%d\t%t\t%t\t\n",
 			i,
 			hexutils.Encode(deposit.WalletPublicKeyHash[:]),
 			deposit.AmountBtc,
@@ -148,6 +156,7 @@ func printDepositsTable(deposits []*tbtcpg.Deposit) error {
 			),
 			deposit.Confirmations,
 			deposit.IsSwept,
+			deposit.IsRefunded,
 		)
 	}
 
@@ -171,7 +180,12 @@ var estimateDepositsSweepFeeCommand = cobra.Command{
 			return fmt.Errorf("failed to find deposits count flag: %v", err)
 		}
 
-		_, tbtcChain, _, _, _, err := ethereum.Connect(ctx, clientConfig.Ethereum)
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
+		)
 		if err != nil {
 			return fmt.Errorf(
 				"could not connect to Ethereum chain: [%v]",
@@ -288,6 +302,8 @@ var submitDepositSweepProofCommand = cobra.Command{
 		_, tbtcChain, _, _, _, err := ethereum.Connect(
 			ctx,
 			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
 		)
 		if err != nil {
 			return fmt.Errorf(
@@ -378,6 +394,8 @@ var submitRedemptionProofCommand = cobra.Command{
 		_, tbtcChain, _, _, _, err := ethereum.Connect(
 			ctx,
 			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
 		)
 		if err != nil {
 			return fmt.Errorf(
@@ -457,6 +475,142 @@ var submitRedemptionProofCommand = cobra.Command{
 	},
 }
 
+var simulateProposalCommand = cobra.Command{
+	Use:              "simulate-proposal",
+	Short:            "simulates wallet action proposal",
+	Long:             simulateProposalCommandDescription,
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		wallet, err := cmd.Flags().GetString(walletFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find wallet flag: %v", err)
+		}
+
+		walletPublicKeyHash, err := newWalletPublicKeyHash(wallet)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to extract wallet public key hash: %v",
+				err,
+			)
+		}
+
+		actionFlag, err := cmd.Flags().GetString(actionFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find action flag: %v", err)
+		}
+
+		action, err := parseWalletActionType(actionFlag)
+		if err != nil {
+			return fmt.Errorf("failed to parse action flag: %v", err)
+		}
+
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"could not connect to Ethereum chain: [%v]",
+				err,
+			)
+		}
+
+		btcChain, err := electrum.Connect(ctx, clientConfig.Bitcoin.Electrum)
+		if err != nil {
+			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
+		}
+
+		simulation, err := tbtcpg.SimulateProposal(
+			tbtcChain,
+			btcChain,
+			walletPublicKeyHash,
+			action,
+		)
+		if err != nil {
+			return fmt.Errorf("cannot simulate proposal: [%v]", err)
+		}
+
+		return printProposalSimulation(os.Stdout, simulation)
+	},
+}
+
+var simulateProposalCommandDescription = "Runs the proposal task of the " +
+	"given wallet action for the given wallet against the current state of " +
+	"the chains, the same way the coordination leader does in a " +
+	"coordination window, and prints the generated proposal, the fee of " +
+	"the proposed Bitcoin transaction and the result of the on-chain " +
+	"validation of the proposal. The proposal is not broadcast and " +
+	"transactions the task would submit to the chain, like the moving " +
+	"funds commitment, are not submitted. Supported actions are: " +
+	"DepositSweep, Redemption, Heartbeat, MovingFunds and MovedFundsSweep."
+
+// parseWalletActionType parses the case-insensitive name of the wallet
+// action type.
+func parseWalletActionType(name string) (tbtc.WalletActionType, error) {
+	for _, action := range []tbtc.WalletActionType{
+		tbtc.ActionHeartbeat,
+		tbtc.ActionDepositSweep,
+		tbtc.ActionRedemption,
+		tbtc.ActionMovingFunds,
+		tbtc.ActionMovedFundsSweep,
+	} {
+		if strings.EqualFold(action.String(), name) {
+			return action, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown wallet action [%s]", name)
+}
+
+func printProposalSimulation(
+	writer io.Writer,
+	simulation *tbtcpg.ProposalSimulation,
+) error {
+	fmt.Fprintf(writer, "action: %s\n", simulation.Action)
+
+	for _, submission := range simulation.SkippedSubmissions {
+		fmt.Fprintf(
+			writer,
+			"skipped submission: %s; the task proposes once it is "+
+				"submitted by a wallet operator\n",
+			submission,
+		)
+	}
+
+	if simulation.Proposal == nil {
+		fmt.Fprintf(writer, "proposal: none\n")
+		return nil
+	}
+
+	fmt.Fprintf(writer, "proposal:\n")
+	if err := printJSON(writer, simulation.Proposal); err != nil {
+		return fmt.Errorf("failed to print proposal: [%v]", err)
+	}
+
+	if simulation.Fee != nil {
+		fmt.Fprintf(writer, "fee (satoshis): %v\n", simulation.Fee)
+	}
+
+	switch {
+	case !simulation.Validated:
+		fmt.Fprintf(writer, "validation: not performed\n")
+	case simulation.ValidationError != nil:
+		fmt.Fprintf(
+			writer,
+			"validation: invalid: %v\n",
+			simulation.ValidationError,
+		)
+	default:
+		fmt.Fprintf(writer, "validation: valid\n")
+	}
+
+	return nil
+}
+
 func init() {
 	initFlags(
 		MaintainerCliCommand,
@@ -548,6 +702,35 @@ func init() {
 	)
 
 	MaintainerCliCommand.AddCommand(&submitRedemptionProofCommand)
+
+	// Simulate Proposal Subcommand.
+
+	simulateProposalCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash",
+	)
+
+	if err := simulateProposalCommand.MarkFlagRequired(
+		walletFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	simulateProposalCommand.Flags().String(
+		actionFlagName,
+		"",
+		"wallet action whose proposal is simulated: DepositSweep, "+
+			"Redemption, Heartbeat, MovingFunds or MovedFundsSweep",
+	)
+
+	if err := simulateProposalCommand.MarkFlagRequired(
+		actionFlagName,
+	); err != nil {
+		logger.Fatalf("failed to mark flag required: [%v]", err)
+	}
+
+	MaintainerCliCommand.AddCommand(&simulateProposalCommand)
 }
 
 func newWalletPublicKeyHash(str string) ([20]byte, error) {
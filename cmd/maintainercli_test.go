@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/tbtc"
 )
 
 var walletPublicKeyHashTests = []struct {
@@ -40,3 +41,45 @@ func TestNewWalletPublicKeyHash(t *testing.T) {
 		})
 	}
 }
+
+func TestParseWalletActionType(t *testing.T) {
+	tests := map[string]struct {
+		input          string
+		expectedResult tbtc.WalletActionType
+		wantErr        error
+	}{
+		"exact name": {
+			input:          "DepositSweep",
+			expectedResult: tbtc.ActionDepositSweep,
+		},
+		"lowercase name": {
+			input:          "movedfundssweep",
+			expectedResult: tbtc.ActionMovedFundsSweep,
+		},
+		"noop": {
+			input:   "Noop",
+			wantErr: fmt.Errorf("unknown wallet action [Noop]"),
+		},
+		"unknown name": {
+			input:   "sweep",
+			wantErr: fmt.Errorf("unknown wallet action [sweep]"),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			actualResult, err := parseWalletActionType(test.input)
+			if !reflect.DeepEqual(err, test.wantErr) {
+				t.Fatalf("unexpected error\nexpected: %v\nactual:   %v", test.wantErr, err)
+			}
+
+			if test.wantErr == nil && actualResult != test.expectedResult {
+				t.Errorf(
+					"unexpected action\nexpected: %s\nactual:   %s",
+					test.expectedResult,
+					actualResult,
+				)
+			}
+		})
+	}
+}
@@ -20,6 +20,15 @@ import (
 	"github.com/keep-network/keep-core/pkg/operator"
 )
 
+// NetworkCommand contains the definition of tools associated with the
+// network layer of the client.
+var NetworkCommand = &cobra.Command{
+	Use:              "network",
+	Short:            "Network diagnostic tools",
+	Long:             "The tool exposes commands for diagnosing client network connectivity.",
+	TraverseChildren: true,
+}
+
 // PingCommand contains the definition of the ping command-line subcommand.
 var PingCommand = &cobra.Command{
 	Use:                   "ping [multiaddr]...",
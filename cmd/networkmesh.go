@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+var (
+	// meshCommand:
+	diagnosticsURLFlagName = "diagnostics-url"
+)
+
+// diagnosticsRequestTimeout is the maximum time the mesh command waits for
+// the diagnostics endpoint of the running client.
+const diagnosticsRequestTimeout = 10 * time.Second
+
+var meshCommand = cobra.Command{
+	Use:   "mesh",
+	Short: "show wallet broadcast channels peers",
+	Long: "Fetches diagnostics of the running client and prints, for each " +
+		"broadcast channel of each wallet controlled by the client, peers " +
+		"subscribed to the channel and signing group operators that are " +
+		"not reachable through it.",
+	TraverseChildren: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		diagnosticsURL, err := cmd.Flags().GetString(diagnosticsURLFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find diagnostics URL flag: %v", err)
+		}
+
+		wallet, err := cmd.Flags().GetString(walletFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find wallet flag: %v", err)
+		}
+
+		var walletPublicKeyHash string
+		if len(wallet) > 0 {
+			pkh, err := newWalletPublicKeyHash(wallet)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to extract wallet public key hash: %v",
+					err,
+				)
+			}
+
+			walletPublicKeyHash = hex.EncodeToString(pkh[:])
+		}
+
+		wallets, err := fetchWalletsNetworkDiagnostics(diagnosticsURL)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to fetch network diagnostics: [%v]",
+				err,
+			)
+		}
+
+		return printWalletsNetworkDiagnosticsTable(wallets, walletPublicKeyHash)
+	},
+}
+
+// fetchWalletsNetworkDiagnostics gets the wallets network diagnostics from
+// the diagnostics endpoint of the running client.
+func fetchWalletsNetworkDiagnostics(
+	diagnosticsURL string,
+) ([]*tbtc.WalletNetworkDiagnostics, error) {
+	diagnostics := struct {
+		TbtcNetwork *struct {
+			Wallets []*tbtc.WalletNetworkDiagnostics `json:"wallets"`
+		} `json:"tbtc_network"`
+	}{}
+
+	if err := fetchDiagnostics(diagnosticsURL, &diagnostics); err != nil {
+		return nil, err
+	}
+
+	if diagnostics.TbtcNetwork == nil {
+		return nil, fmt.Errorf(
+			"client does not expose network diagnostics; " +
+				"is it a non-bootstrap node?",
+		)
+	}
+
+	return diagnostics.TbtcNetwork.Wallets, nil
+}
+
+// fetchDiagnostics gets the diagnostics document from the diagnostics
+// endpoint of the running client and unmarshals it into the given value.
+func fetchDiagnostics(diagnosticsURL string, diagnostics interface{}) error {
+	client := &http.Client{Timeout: diagnosticsRequestTimeout}
+
+	response, err := client.Get(diagnosticsURL)
+	if err != nil {
+		return fmt.Errorf("cannot get diagnostics: [%v]", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"unexpected diagnostics response status: [%v]",
+			response.Status,
+		)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("cannot read diagnostics: [%v]", err)
+	}
+
+	if err := json.Unmarshal(body, diagnostics); err != nil {
+		return fmt.Errorf("cannot unmarshal diagnostics: [%v]", err)
+	}
+
+	return nil
+}
+
+func printWalletsNetworkDiagnosticsTable(
+	wallets []*tbtc.WalletNetworkDiagnostics,
+	walletPublicKeyHash string,
+) error {
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', 0)
+	fmt.Fprintf(w, "wallet\tchannel\tpeers\tunreachable operators\t\n")
+
+	for _, wallet := range wallets {
+		if len(walletPublicKeyHash) > 0 &&
+			wallet.WalletPublicKeyHash != walletPublicKeyHash {
+			continue
+		}
+
+		for _, channel := range wallet.BroadcastChannels {
+			unreachable := "-"
+			if len(channel.UnreachableOperators) > 0 {
+				unreachable = strings.Join(channel.UnreachableOperators, ",")
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t\n",
+				wallet.WalletPublicKeyHash,
+				channel.Name,
+				len(channel.Peers),
+				unreachable,
+			)
+		}
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	meshCommand.Flags().String(
+		diagnosticsURLFlagName,
+		"http://localhost:9601/diagnostics",
+		"URL of the diagnostics endpoint of the running client",
+	)
+
+	meshCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash",
+	)
+
+	NetworkCommand.AddCommand(&meshCommand)
+}
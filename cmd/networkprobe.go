@@ -0,0 +1,398 @@
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/net/retransmission"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+var (
+	// probeCommand:
+	probeTimeoutFlagName = "timeout"
+)
+
+const defaultProbeTimeout = 30 * time.Second
+
+const probeDescription = `The probe command checks the connectivity of this
+   operator with the given peers. Peers can be passed as a list of multiaddresses
+   in the format <endpoint>/ipfs/<cid> or determined from wallet broadcast
+   channel peers reported by the diagnostics endpoint of the running client,
+   using the --wallet flag.
+
+   For each peer, the command reports the peer's operator address, whether the
+   peer is accepted by the firewall (i.e. is a recognized staking operator),
+   the latency of establishing an authenticated connection, and the ping
+   round-trip time.
+
+   The probe uses the operator key from the configuration so that remote peers
+   accept the connection. It listens on a random port so it can be run on the
+   same machine as the working client.`
+
+var probeCommand = cobra.Command{
+	Use:              "probe [multiaddr]...",
+	Short:            "probe connectivity with peers",
+	Long:             probeDescription,
+	TraverseChildren: true,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := clientConfig.ReadConfig(
+			configFilePath,
+			cmd.Flags(),
+			config.General, config.Ethereum,
+		); err != nil {
+			logger.Fatalf("error reading config: %v", err)
+		}
+	},
+	RunE: networkProbe,
+}
+
+// probeResult holds the outcome of probing a single peer.
+type probeResult struct {
+	address      string
+	networkID    string
+	chainAddress chain.Address
+
+	firewallErr error
+
+	handshakeLatency time.Duration
+	handshakeErr     error
+
+	pingRTT time.Duration
+	pingErr error
+}
+
+func networkProbe(cmd *cobra.Command, args []string) error {
+	ctx, cancelCtx := context.WithCancel(cmd.Context())
+	defer cancelCtx()
+
+	timeout, err := cmd.Flags().GetDuration(probeTimeoutFlagName)
+	if err != nil {
+		return fmt.Errorf("failed to find timeout flag: %v", err)
+	}
+
+	wallet, err := cmd.Flags().GetString(walletFlagName)
+	if err != nil {
+		return fmt.Errorf("failed to find wallet flag: %v", err)
+	}
+
+	addresses := args
+	var unreachableOperators []string
+
+	if len(wallet) > 0 {
+		diagnosticsURL, err := cmd.Flags().GetString(diagnosticsURLFlagName)
+		if err != nil {
+			return fmt.Errorf("failed to find diagnostics URL flag: %v", err)
+		}
+
+		walletPublicKeyHash, err := newWalletPublicKeyHash(wallet)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to extract wallet public key hash: %v",
+				err,
+			)
+		}
+
+		var diagnostics walletProbeDiagnostics
+		if err := fetchDiagnostics(diagnosticsURL, &diagnostics); err != nil {
+			return fmt.Errorf("failed to fetch diagnostics: [%v]", err)
+		}
+
+		walletAddresses, walletUnreachable, err := walletProbeTargets(
+			&diagnostics,
+			hex.EncodeToString(walletPublicKeyHash[:]),
+		)
+		if err != nil {
+			return err
+		}
+
+		addresses = append(addresses, walletAddresses...)
+		unreachableOperators = walletUnreachable
+	}
+
+	if len(addresses) == 0 && len(unreachableOperators) == 0 {
+		return fmt.Errorf("no peers to probe")
+	}
+
+	beaconChain, tbtcChain, _, signing, operatorPrivateKey, err :=
+		ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
+		)
+	if err != nil {
+		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
+	}
+
+	if operatorPrivateKey == nil {
+		return errNetworkKeyNotSet
+	}
+
+	probeFirewall := firewall.AnyApplicationPolicy(
+		[]firewall.Application{beaconChain, tbtcChain},
+		firewall.EmptyAllowList,
+	)
+
+	netProvider, err := libp2p.Connect(
+		ctx,
+		libp2p.Config{Port: 0},
+		operatorPrivateKey,
+		probeFirewall,
+		retransmission.NewTimeTicker(ctx, 50*time.Millisecond),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot initialize network: [%v]", err)
+	}
+
+	results := make([]*probeResult, 0, len(addresses))
+	for _, address := range addresses {
+		results = append(
+			results,
+			probePeer(
+				ctx,
+				netProvider,
+				signing,
+				probeFirewall,
+				address,
+				timeout,
+			),
+		)
+	}
+
+	return printProbeResultsTable(results, unreachableOperators)
+}
+
+// probePeer runs connectivity checks against the peer with the given address.
+func probePeer(
+	ctx context.Context,
+	netProvider net.Provider,
+	signing chain.Signing,
+	probeFirewall net.Firewall,
+	address string,
+	timeout time.Duration,
+) *probeResult {
+	result := &probeResult{address: address}
+
+	publicKeys, err := libp2p.ExtractPeersPublicKeys([]string{address})
+	if err != nil {
+		result.handshakeErr = err
+		return result
+	}
+
+	transportID, err := netProvider.CreateTransportIdentifier(publicKeys[0])
+	if err != nil {
+		result.handshakeErr = err
+		return result
+	}
+	result.networkID = transportID.String()
+
+	chainAddress, err := signing.PublicKeyToAddress(publicKeys[0])
+	if err != nil {
+		result.handshakeErr = err
+		return result
+	}
+	result.chainAddress = chainAddress
+
+	result.firewallErr = probeFirewall.Validate(publicKeys[0])
+
+	connectCtx, cancelConnectCtx := context.WithTimeout(ctx, timeout)
+	defer cancelConnectCtx()
+
+	result.handshakeLatency, result.handshakeErr =
+		netProvider.ConnectionManager().ConnectToPeer(connectCtx, address)
+	if result.handshakeErr != nil {
+		return result
+	}
+
+	pingCtx, cancelPingCtx := context.WithTimeout(ctx, timeout)
+	defer cancelPingCtx()
+
+	result.pingRTT, result.pingErr =
+		netProvider.ConnectionManager().PingPeer(pingCtx, result.networkID)
+
+	return result
+}
+
+func printProbeResultsTable(
+	results []*probeResult,
+	unreachableOperators []string,
+) error {
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', 0)
+	fmt.Fprintf(w, "peer\toperator\tfirewall\thandshake\tping\t\n")
+
+	for _, result := range results {
+		firewallStatus := "accepted"
+		if result.firewallErr != nil {
+			firewallStatus = fmt.Sprintf("rejected: %v", result.firewallErr)
+		}
+
+		handshake := result.handshakeLatency.String()
+		if result.handshakeErr != nil {
+			handshake = fmt.Sprintf("failed: %v", result.handshakeErr)
+		}
+
+		ping := "-"
+		if result.handshakeErr == nil {
+			ping = result.pingRTT.String()
+			if result.pingErr != nil {
+				ping = fmt.Sprintf("failed: %v", result.pingErr)
+			}
+		}
+
+		peer := result.networkID
+		if len(peer) == 0 {
+			peer = result.address
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n",
+			peer,
+			result.chainAddress,
+			firewallStatus,
+			handshake,
+			ping,
+		)
+	}
+
+	for _, operator := range unreachableOperators {
+		fmt.Fprintf(
+			w,
+			"-\t%s\t-\tnot seen by the client\t-\t\n",
+			operator,
+		)
+	}
+
+	return w.Flush()
+}
+
+// walletProbeDiagnostics is the subset of the running client's diagnostics
+// needed to determine probe targets for a wallet.
+type walletProbeDiagnostics struct {
+	ConnectedPeers []clientinfo.Peer `json:"connected_peers"`
+	TbtcNetwork    *struct {
+		Wallets []*tbtc.WalletNetworkDiagnostics `json:"wallets"`
+	} `json:"tbtc_network"`
+}
+
+// walletProbeTargets determines addresses of the given wallet's broadcast
+// channels peers and the list of wallet operators the client does not see
+// at all, based on the diagnostics of the running client.
+func walletProbeTargets(
+	diagnostics *walletProbeDiagnostics,
+	walletPublicKeyHash string,
+) ([]string, []string, error) {
+	if diagnostics.TbtcNetwork == nil {
+		return nil, nil, fmt.Errorf(
+			"client does not expose network diagnostics; " +
+				"is it a non-bootstrap node?",
+		)
+	}
+
+	var walletDiagnostics *tbtc.WalletNetworkDiagnostics
+	for _, wallet := range diagnostics.TbtcNetwork.Wallets {
+		if wallet.WalletPublicKeyHash == walletPublicKeyHash {
+			walletDiagnostics = wallet
+			break
+		}
+	}
+
+	if walletDiagnostics == nil {
+		return nil, nil, fmt.Errorf(
+			"wallet [%s] is not controlled by the client",
+			walletPublicKeyHash,
+		)
+	}
+
+	peersMultiaddrs := make(map[string][]string)
+	for _, peer := range diagnostics.ConnectedPeers {
+		peersMultiaddrs[peer.NetworkID] = peer.NetworkMultiAddresses
+	}
+
+	addresses := make([]string, 0)
+	seenPeers := make(map[string]bool)
+	unreachable := make(map[string]bool)
+
+	for _, channel := range walletDiagnostics.BroadcastChannels {
+		for _, peer := range channel.Peers {
+			if seenPeers[peer.NetworkID] {
+				continue
+			}
+			seenPeers[peer.NetworkID] = true
+
+			multiaddrs := peersMultiaddrs[peer.NetworkID]
+			if len(multiaddrs) == 0 {
+				continue
+			}
+
+			addresses = append(
+				addresses,
+				fmt.Sprintf("%s/ipfs/%s", multiaddrs[0], peer.NetworkID),
+			)
+		}
+
+		for _, operator := range channel.UnreachableOperators {
+			unreachable[operator] = true
+		}
+	}
+
+	// Operators unreachable through one channel but seen on another are
+	// probed anyway so report only operators not seen on any channel.
+	for _, channel := range walletDiagnostics.BroadcastChannels {
+		for _, peer := range channel.Peers {
+			delete(unreachable, peer.ChainAddress)
+		}
+	}
+
+	unreachableOperators := make([]string, 0, len(unreachable))
+	for operator := range unreachable {
+		unreachableOperators = append(unreachableOperators, operator)
+	}
+	sort.Strings(unreachableOperators)
+
+	return addresses, unreachableOperators, nil
+}
+
+func init() {
+	initFlags(
+		&probeCommand,
+		&configFilePath,
+		clientConfig,
+		config.General, config.Ethereum,
+	)
+
+	probeCommand.Flags().String(
+		walletFlagName,
+		"",
+		"wallet public key hash; probe the wallet's broadcast channels peers "+
+			"seen by the running client",
+	)
+
+	probeCommand.Flags().String(
+		diagnosticsURLFlagName,
+		"http://localhost:9601/diagnostics",
+		"URL of the diagnostics endpoint of the running client; used "+
+			"along with the wallet flag",
+	)
+
+	probeCommand.Flags().Duration(
+		probeTimeoutFlagName,
+		defaultProbeTimeout,
+		"timeout of each probe step",
+	)
+
+	NetworkCommand.AddCommand(&probeCommand)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestWalletProbeTargets(t *testing.T) {
+	diagnostics := &walletProbeDiagnostics{
+		ConnectedPeers: []clientinfo.Peer{
+			{
+				NetworkID:             "peer-1",
+				NetworkMultiAddresses: []string{"/ip4/10.0.0.1/tcp/3919"},
+			},
+			{
+				NetworkID:             "peer-2",
+				NetworkMultiAddresses: []string{"/ip4/10.0.0.2/tcp/3919"},
+			},
+		},
+		TbtcNetwork: &struct {
+			Wallets []*tbtc.WalletNetworkDiagnostics `json:"wallets"`
+		}{
+			Wallets: []*tbtc.WalletNetworkDiagnostics{
+				{
+					WalletPublicKeyHash: "aa",
+					BroadcastChannels: []tbtc.BroadcastChannelDiagnostics{
+						{
+							Name: "tbtc-04aa",
+							Peers: []tbtc.BroadcastChannelPeer{
+								{NetworkID: "peer-1", ChainAddress: "0x1"},
+							},
+							UnreachableOperators: []string{"0x2", "0x3"},
+						},
+						{
+							Name: "tbtc-04aa-coordination",
+							Peers: []tbtc.BroadcastChannelPeer{
+								{NetworkID: "peer-1", ChainAddress: "0x1"},
+								{NetworkID: "peer-2", ChainAddress: "0x2"},
+							},
+							UnreachableOperators: []string{"0x3"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	addresses, unreachable, err := walletProbeTargets(diagnostics, "aa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedAddresses := []string{
+		"/ip4/10.0.0.1/tcp/3919/ipfs/peer-1",
+		"/ip4/10.0.0.2/tcp/3919/ipfs/peer-2",
+	}
+	if !reflect.DeepEqual(expectedAddresses, addresses) {
+		t.Errorf(
+			"unexpected addresses\nexpected: %v\nactual:   %v",
+			expectedAddresses,
+			addresses,
+		)
+	}
+
+	expectedUnreachable := []string{"0x3"}
+	if !reflect.DeepEqual(expectedUnreachable, unreachable) {
+		t.Errorf(
+			"unexpected unreachable operators\nexpected: %v\nactual:   %v",
+			expectedUnreachable,
+			unreachable,
+		)
+	}
+
+	_, _, err = walletProbeTargets(diagnostics, "bb")
+	if err == nil {
+		t.Errorf("expected error for unknown wallet")
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/maintainer"
+	"github.com/keep-network/keep-core/pkg/maintainer/btcdiff"
+	"github.com/keep-network/keep-core/pkg/maintainer/depositrefund"
+	"github.com/keep-network/keep-core/pkg/maintainer/spv"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// firewallUpdateHook returns a config update hook reloading the firewall
+// policies. Once the new policies are applied, already connected peers no
+// longer satisfying them are disconnected.
+func firewallUpdateHook(
+	policy *firewall.ConfigurablePolicy,
+	netProvider net.Provider,
+) config.UpdateHook {
+	return func(current *config.Config, reloaded *config.Config) ([]string, error) {
+		changed := config.ChangedProperties(
+			current.Firewall,
+			reloaded.Firewall,
+			"Firewall",
+		)
+		if len(changed) == 0 {
+			return nil, nil
+		}
+
+		if err := policy.Reload(reloaded.Firewall); err != nil {
+			return nil, err
+		}
+
+		disconnectDeniedPeers(policy, netProvider.ConnectionManager())
+
+		return changed, nil
+	}
+}
+
+// disconnectDeniedPeers disconnects all connected peers that do not satisfy
+// the given firewall policy. Network addresses of already connected peers
+// are not checked; address deny lists apply to new connections only.
+func disconnectDeniedPeers(
+	policy net.Firewall,
+	connectionManager net.ConnectionManager,
+) {
+	for _, connectedPeer := range connectionManager.ConnectedPeers() {
+		peerPublicKey, err := connectionManager.GetPeerPublicKey(connectedPeer)
+		if err != nil {
+			logger.Errorf(
+				"cannot get public key of peer [%v]: [%v]",
+				connectedPeer,
+				err,
+			)
+			continue
+		}
+
+		if err := policy.Validate(peerPublicKey); err != nil {
+			logger.Warnf(
+				"disconnecting peer [%v] not satisfying firewall rules: [%v]",
+				connectedPeer,
+				err,
+			)
+			connectionManager.DisconnectPeer(connectedPeer)
+		}
+	}
+}
+
+// clientInfoUpdateHook returns a config update hook applying changes of
+// the metrics observation ticks.
+func clientInfoUpdateHook(registry *clientinfo.Registry) config.UpdateHook {
+	return func(current *config.Config, reloaded *config.Config) ([]string, error) {
+		changed := make([]string, 0)
+		for _, property := range config.ChangedProperties(
+			current.ClientInfo,
+			reloaded.ClientInfo,
+		) {
+			// The port of the client info server cannot be changed at runtime.
+			if property == "Port" {
+				continue
+			}
+
+			changed = append(changed, fmt.Sprintf("ClientInfo.%s", property))
+		}
+
+		if len(changed) == 0 {
+			return nil, nil
+		}
+
+		registry.UpdateTicks(reloaded.ClientInfo)
+
+		return changed, nil
+	}
+}
+
+// tbtcUpdateHook returns a config update hook applying changes of the TBTC
+// configuration supported by the given updater.
+func tbtcUpdateHook(updater tbtc.ConfigUpdater) config.UpdateHook {
+	return func(current *config.Config, reloaded *config.Config) ([]string, error) {
+		applied := updater(reloaded.Tbtc)
+
+		changed := make([]string, len(applied))
+		for i, property := range applied {
+			changed[i] = fmt.Sprintf("Tbtc.%s", property)
+		}
+
+		return changed, nil
+	}
+}
+
+// maintainerRunner runs the maintainers and restarts them with a new
+// configuration on demand.
+type maintainerRunner struct {
+	ctx                context.Context
+	btcChain           bitcoin.Chain
+	btcDiffChain       btcdiff.Chain
+	spvChain           spv.Chain
+	depositRefundChain depositrefund.Chain
+
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+}
+
+// run stops the currently running maintainers, if any, and starts the
+// maintainers enabled in the given configuration.
+func (mr *maintainerRunner) run(maintainerConfig maintainer.Config) {
+	mr.mutex.Lock()
+	defer mr.mutex.Unlock()
+
+	if mr.cancel != nil {
+		mr.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(mr.ctx)
+	mr.cancel = cancel
+
+	maintainer.Initialize(
+		ctx,
+		maintainerConfig,
+		mr.btcChain,
+		mr.btcDiffChain,
+		mr.spvChain,
+		mr.depositRefundChain,
+	)
+}
+
+// maintainerUpdateHook returns a config update hook restarting the
+// maintainers if their configuration changed.
+func maintainerUpdateHook(runner *maintainerRunner) config.UpdateHook {
+	return func(current *config.Config, reloaded *config.Config) ([]string, error) {
+		changed := config.ChangedProperties(
+			current.Maintainer,
+			reloaded.Maintainer,
+			"Maintainer",
+		)
+		if len(changed) == 0 {
+			return nil, nil
+		}
+
+		runner.run(reloaded.Maintainer)
+
+		return changed, nil
+	}
+}
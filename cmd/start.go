@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/keep-network/keep-core/pkg/tbtcpg"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/keep-network/keep-common/pkg/persistence"
 	"github.com/keep-network/keep-core/build"
@@ -59,19 +62,45 @@ Environment variables:
 	)
 }
 
+// errNetworkKeyNotSet is returned if the operator key is held only by the
+// remote signer. The network identity of the client is derived from the
+// operator key, so the key must also be available locally to connect to the
+// network.
+var errNetworkKeyNotSet = fmt.Errorf(
+	"operator key file is required to connect to the network; " +
+		"the remote signer cannot secure network connections",
+)
+
 // start starts a node
 func start(cmd *cobra.Command) error {
 	ctx := context.Background()
 
 	beaconChain, tbtcChain, blockCounter, signing, operatorPrivateKey, err :=
-		ethereum.Connect(ctx, clientConfig.Ethereum)
+		ethereum.Connect(
+			ctx,
+			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
+		)
 	if err != nil {
 		return fmt.Errorf("error connecting to Ethereum node: [%v]", err)
 	}
 
-	netProvider, err := initializeNetwork(
+	if operatorPrivateKey == nil {
+		return errNetworkKeyNotSet
+	}
+
+	configWatcher := config.NewWatcher(
+		configFilePath,
+		clientConfig,
+		config.StartCmdCategories...,
+	)
+
+	netProvider, firewallPolicy, err := initializeNetwork(
 		ctx,
 		[]firewall.Application{beaconChain, tbtcChain},
+		tbtcChain,
+		signing,
 		operatorPrivateKey,
 		blockCounter,
 	)
@@ -79,6 +108,11 @@ func start(cmd *cobra.Command) error {
 		return fmt.Errorf("cannot initialize network: [%v]", err)
 	}
 
+	configWatcher.RegisterUpdateHook(
+		"firewall",
+		firewallUpdateHook(firewallPolicy, netProvider),
+	)
+
 	clientInfoRegistry := initializeClientInfo(
 		ctx,
 		clientConfig,
@@ -86,6 +120,15 @@ func start(cmd *cobra.Command) error {
 		signing,
 		blockCounter,
 	)
+	if clientInfoRegistry != nil {
+		configWatcher.RegisterUpdateHook(
+			"clientinfo",
+			clientInfoUpdateHook(clientInfoRegistry),
+		)
+	}
+
+	// Bootstrap nodes have no in-flight work to wait for on shutdown.
+	shutdownFn := func() {}
 
 	// Initialize beacon and tbtc only for non-bootstrap nodes.
 	// Skip initialization for bootstrap nodes as they are only used for network
@@ -96,7 +139,8 @@ func start(cmd *cobra.Command) error {
 			return fmt.Errorf("could not connect to Electrum chain: [%v]", err)
 		}
 
-		beaconKeyStorePersistence,
+		keepStorage,
+			beaconKeyStorePersistence,
 			tbtcKeyStorePersistence,
 			tbtcDataPersistence,
 			err := initializePersistence()
@@ -124,12 +168,22 @@ func start(cmd *cobra.Command) error {
 			return fmt.Errorf("error initializing beacon: [%v]", err)
 		}
 
-		proposalGenerator := tbtcpg.NewProposalGenerator(
+		proposalGenerator, err := tbtcpg.NewProposalGenerator(
 			tbtcChain,
 			btcChain,
+			&tbtcpg.ProposalGeneratorConfig{
+				DepositSweepSelection: clientConfig.Tbtc.DepositSweepSelection,
+				RedemptionSelection:   clientConfig.Tbtc.RedemptionSelection,
+			},
 		)
+		if err != nil {
+			return fmt.Errorf(
+				"error creating tbtc proposal generator: [%v]",
+				err,
+			)
+		}
 
-		err = tbtc.Initialize(
+		tbtcConfigUpdater, tbtcShutdown, err := tbtc.Initialize(
 			ctx,
 			tbtcChain,
 			btcChain,
@@ -144,6 +198,19 @@ func start(cmd *cobra.Command) error {
 		if err != nil {
 			return fmt.Errorf("error initializing TBTC: [%v]", err)
 		}
+
+		configWatcher.RegisterUpdateHook(
+			"tbtc",
+			tbtcUpdateHook(tbtcConfigUpdater),
+		)
+
+		shutdownFn = func() {
+			shutdownTbtc(tbtcShutdown, keepStorage)
+		}
+	}
+
+	if configFilePath != "" {
+		go configWatcher.Watch(ctx)
 	}
 
 	nodeHeader(
@@ -153,8 +220,56 @@ func start(cmd *cobra.Command) error {
 		clientConfig.Ethereum,
 	)
 
-	<-ctx.Done()
-	return fmt.Errorf("shutting down the node because its context has ended")
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("shutting down the node because its context has ended")
+	case sig := <-signals:
+		logger.Infof("received [%v] signal; shutting down the node", sig)
+	}
+
+	// The second signal aborts the graceful shutdown.
+	go func() {
+		sig := <-signals
+		logger.Fatalf("received [%v] signal; aborting graceful shutdown", sig)
+	}()
+
+	shutdownFn()
+
+	return nil
+}
+
+// shutdownTbtc stops the TBTC node gracefully, waiting for the in-flight
+// signing and DKG up to the configured timeout, and closes the storage.
+func shutdownTbtc(tbtcShutdown tbtc.ShutdownFunc, keepStorage storage.Storage) {
+	logger.Infof(
+		"waiting up to [%v] for in-flight signing and DKG to complete",
+		clientConfig.Tbtc.ShutdownTimeout,
+	)
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		clientConfig.Tbtc.ShutdownTimeout,
+	)
+	defer cancelCtx()
+
+	interrupted := tbtcShutdown(ctx)
+	if len(interrupted) > 0 {
+		logger.Warnf(
+			"shutdown timeout exceeded; interrupting [%v] in-flight "+
+				"operations: %v",
+			len(interrupted),
+			interrupted,
+		)
+	} else {
+		logger.Infof("all in-flight signing and DKG completed")
+	}
+
+	if err := keepStorage.Close(); err != nil {
+		logger.Errorf("cannot close storage: [%v]", err)
+	}
 }
 
 func isBootstrap() bool {
@@ -164,36 +279,46 @@ func isBootstrap() bool {
 func initializeNetwork(
 	ctx context.Context,
 	applications []firewall.Application,
+	stakeApplication firewall.StakeApplication,
+	signing chain.Signing,
 	operatorPrivateKey *operator.PrivateKey,
 	blockCounter chain.BlockCounter,
-) (net.Provider, error) {
+) (net.Provider, *firewall.ConfigurablePolicy, error) {
 	bootstrapPeersPublicKeys, err := libp2p.ExtractPeersPublicKeys(
 		clientConfig.LibP2P.Peers,
 	)
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, nil, fmt.Errorf(
 			"error extracting bootstrap peers public keys: [%v]",
 			err,
 		)
 	}
 
-	firewall := firewall.AnyApplicationPolicy(
-		applications,
-		firewall.NewAllowList(bootstrapPeersPublicKeys),
+	allowList := firewall.NewAllowList(bootstrapPeersPublicKeys)
+
+	firewallPolicy, err := firewall.NewConfigurablePolicy(
+		firewall.AnyApplicationPolicy(applications, allowList),
+		clientConfig.Firewall,
+		signing,
+		stakeApplication,
+		allowList,
 	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot initialize firewall: [%v]", err)
+	}
 
 	netProvider, err := libp2p.Connect(
 		ctx,
 		clientConfig.LibP2P,
 		operatorPrivateKey,
-		firewall,
+		firewallPolicy,
 		retransmission.NewTicker(blockCounter.WatchBlocks(ctx)),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed while creating the network provider: [%v]", err)
+		return nil, nil, fmt.Errorf("failed while creating the network provider: [%v]", err)
 	}
 
-	return netProvider, nil
+	return netProvider, firewallPolicy, nil
 }
 
 func initializeClientInfo(
@@ -247,42 +372,51 @@ func initializeClientInfo(
 }
 
 func initializePersistence() (
+	keepStorage storage.Storage,
 	beaconKeyStorePersistence persistence.ProtectedHandle,
 	tbtcKeyStorePersistence persistence.ProtectedHandle,
 	tbtcDataPersistence persistence.BasicHandle,
 	err error,
 ) {
-	storage, err := storage.Initialize(
+	storagePassword, err := clientConfig.StoragePassword(context.Background())
+	if err != nil {
+		return storage.Storage{}, nil, nil, nil, fmt.Errorf(
+			"cannot read storage password: [%w]",
+			err,
+		)
+	}
+
+	keepStorage, err = storage.Initialize(
 		clientConfig.Storage,
-		clientConfig.Ethereum.KeyFilePassword,
+		storagePassword,
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("cannot initialize storage: [%w]", err)
+		return storage.Storage{}, nil, nil, nil, fmt.Errorf("cannot initialize storage: [%w]", err)
 	}
 
-	beaconKeyStorePersistence, err = storage.InitializeKeyStorePersistence(
+	beaconKeyStorePersistence, err = keepStorage.InitializeKeyStorePersistence(
 		"beacon",
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return storage.Storage{}, nil, nil, nil, fmt.Errorf(
 			"cannot initialize beacon keystore persistence: [%w]",
 			err,
 		)
 	}
 
-	tbtcKeyStorePersistence, err = storage.InitializeKeyStorePersistence(
+	tbtcKeyStorePersistence, err = keepStorage.InitializeKeyStorePersistence(
 		"tbtc",
 	)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return storage.Storage{}, nil, nil, nil, fmt.Errorf(
 			"cannot initialize tbtc keystore persistence: [%w]",
 			err,
 		)
 	}
 
-	tbtcDataPersistence, err = storage.InitializeWorkPersistence("tbtc")
+	tbtcDataPersistence, err = keepStorage.InitializeWorkPersistence("tbtc")
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf(
+		return storage.Storage{}, nil, nil, nil, fmt.Errorf(
 			"cannot initialize tbtc data persistence: [%w]",
 			err,
 		)
@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keep-network/keep-core/config"
+	"github.com/keep-network/keep-core/internal/hexutils"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+	"github.com/keep-network/keep-core/pkg/storage"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+const (
+	// walletsOfflineFlagName is the name of the flag disabling reading of
+	// the on-chain wallet data.
+	walletsOfflineFlagName = "offline"
+	// walletsJSONFlagName is the name of the flag switching the output to
+	// JSON.
+	walletsJSONFlagName = "json"
+)
+
+// WalletsCommand contains the definition of tools for inspecting the tBTC
+// wallets the client holds signers of.
+var WalletsCommand = &cobra.Command{
+	Use:   "wallets",
+	Short: "Wallet inspection tools",
+	Long: "The tool exposes commands for inspecting the tBTC wallets the " +
+		"client's key store holds signers of. The key store is opened " +
+		"read-only so the commands can be used while the client is stopped.",
+	TraverseChildren: true,
+}
+
+const walletsListDescription = `The list command prints all the tBTC wallets
+   the key store holds signers of, including archived ones. For each wallet,
+   the command prints the wallet public key hash, the ECDSA wallet ID, the
+   signing group member indexes held by the client, the group size and
+   threshold, the on-chain wallet state, and whether the wallet is archived.
+   Reading the on-chain data can be skipped with the --offline flag.`
+
+var walletsListCommand = cobra.Command{
+	Use:              "list",
+	Short:            "list wallets held in the key store",
+	Long:             walletsListDescription,
+	Args:             cobra.NoArgs,
+	TraverseChildren: true,
+	PreRun:           readKeystoreConfig,
+	RunE:             walletsList,
+}
+
+const walletsShowDescription = `The show command prints details of the tBTC
+   wallet with the given public key hash the key store holds signers of,
+   including the on-chain wallet data and problems found with the signers.
+   Reading the on-chain data can be skipped with the --offline flag.`
+
+var walletsShowCommand = cobra.Command{
+	Use:              "show <wallet-public-key-hash>",
+	Short:            "show details of a wallet held in the key store",
+	Long:             walletsShowDescription,
+	Args:             cobra.ExactArgs(1),
+	TraverseChildren: true,
+	PreRun:           readKeystoreConfig,
+	RunE:             walletsShow,
+}
+
+// walletView is the printed representation of the stored wallet.
+type walletView struct {
+	WalletPublicKeyHash string              `json:"walletPublicKeyHash"`
+	WalletID            string              `json:"walletId,omitempty"`
+	MemberIndexes       []group.MemberIndex `json:"memberIndexes"`
+	GroupSize           int                 `json:"groupSize"`
+	HonestThreshold     int                 `json:"honestThreshold"`
+	State               string              `json:"state,omitempty"`
+	Archived            bool                `json:"archived"`
+	ChainData           *walletChainView    `json:"chainData,omitempty"`
+	Issues              []string            `json:"issues,omitempty"`
+}
+
+// walletChainView is the printed representation of the on-chain wallet
+// data.
+type walletChainView struct {
+	MainUtxoHash                        string     `json:"mainUtxoHash"`
+	PendingRedemptionsValue             uint64     `json:"pendingRedemptionsValue"`
+	CreatedAt                           time.Time  `json:"createdAt"`
+	MovingFundsRequestedAt              *time.Time `json:"movingFundsRequestedAt,omitempty"`
+	ClosingStartedAt                    *time.Time `json:"closingStartedAt,omitempty"`
+	PendingMovedFundsSweepRequestsCount uint32     `json:"pendingMovedFundsSweepRequestsCount"`
+}
+
+func newWalletView(wallet *tbtc.StoredWallet, online bool) *walletView {
+	view := &walletView{
+		WalletPublicKeyHash: hexutils.Encode(wallet.WalletPublicKeyHash[:]),
+		MemberIndexes:       wallet.MemberIndexes,
+		GroupSize:           wallet.GroupSize,
+		HonestThreshold:     wallet.HonestThreshold,
+		Archived:            wallet.Archived,
+		Issues:              wallet.Issues,
+	}
+
+	if !online {
+		return view
+	}
+
+	view.WalletID = hexutils.Encode(wallet.WalletID[:])
+	view.State = "unknown"
+
+	if chainData := wallet.ChainData; chainData != nil {
+		view.State = chainData.State.String()
+
+		// Zero timestamps mean the wallet never entered the given state.
+		optionalTime := func(value time.Time) *time.Time {
+			if value.Unix() <= 0 {
+				return nil
+			}
+			return &value
+		}
+
+		view.ChainData = &walletChainView{
+			MainUtxoHash:                        hexutils.Encode(chainData.MainUtxoHash[:]),
+			PendingRedemptionsValue:             chainData.PendingRedemptionsValue,
+			CreatedAt:                           chainData.CreatedAt,
+			MovingFundsRequestedAt:              optionalTime(chainData.MovingFundsRequestedAt),
+			ClosingStartedAt:                    optionalTime(chainData.ClosingStartedAt),
+			PendingMovedFundsSweepRequestsCount: chainData.PendingMovedFundsSweepRequestsCount,
+		}
+	}
+
+	return view
+}
+
+func walletsList(cmd *cobra.Command, args []string) error {
+	views, err := readWalletViews(cmd)
+	if err != nil {
+		return err
+	}
+
+	if asJSON, _ := cmd.Flags().GetBool(walletsJSONFlagName); asJSON {
+		return printJSON(os.Stdout, views)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 1, ' ', 0)
+	fmt.Fprintf(w, "wallet\tmembers\tthreshold\tstate\tarchived\tissues\t\n")
+
+	for _, view := range views {
+		state := view.State
+		if state == "" {
+			state = "-"
+		}
+
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%v/%v\t%s\t%v\t%v\t\n",
+			view.WalletPublicKeyHash,
+			formatMemberIndexes(view.MemberIndexes),
+			view.HonestThreshold,
+			view.GroupSize,
+			state,
+			view.Archived,
+			len(view.Issues),
+		)
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Found [%v] wallets in the key store\n", len(views))
+
+	return nil
+}
+
+func walletsShow(cmd *cobra.Command, args []string) error {
+	walletPublicKeyHash, err := newWalletPublicKeyHash(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid wallet public key hash: [%v]", err)
+	}
+
+	views, err := readWalletViews(cmd)
+	if err != nil {
+		return err
+	}
+
+	// The key store may hold both current and archived signers of the
+	// same wallet.
+	matching := make([]*walletView, 0)
+	for _, view := range views {
+		if view.WalletPublicKeyHash == hexutils.Encode(walletPublicKeyHash[:]) {
+			matching = append(matching, view)
+		}
+	}
+
+	if len(matching) == 0 {
+		return fmt.Errorf(
+			"key store does not hold signers of wallet [%s]",
+			hexutils.Encode(walletPublicKeyHash[:]),
+		)
+	}
+
+	if asJSON, _ := cmd.Flags().GetBool(walletsJSONFlagName); asJSON {
+		return printJSON(os.Stdout, matching)
+	}
+
+	for i, view := range matching {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		if err := printWalletView(os.Stdout, view); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readWalletViews reads the wallets held in the key store and, unless the
+// offline flag is set, their on-chain data.
+func readWalletViews(cmd *cobra.Command) ([]*walletView, error) {
+	offline, err := cmd.Flags().GetBool(walletsOfflineFlagName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find offline flag: %v", err)
+	}
+
+	storagePassword, err := clientConfig.StoragePassword(cmd.Context())
+	if err != nil {
+		return nil, fmt.Errorf("cannot read storage password: [%v]", err)
+	}
+
+	entries, err := storage.ReadKeyStore(clientConfig.Storage, storagePassword)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read key store: [%v]", err)
+	}
+
+	files := make([]*tbtc.KeyStoreSignerFile, 0, len(entries))
+	for _, entry := range entries {
+		// Snapshots are not signers the client uses.
+		if entry.Handle != tbtc.ProtocolName ||
+			entry.Kind == storage.KeyStoreSnapshot {
+			continue
+		}
+
+		files = append(files, &tbtc.KeyStoreSignerFile{
+			Path:     entry.Path,
+			Archived: entry.Kind == storage.KeyStoreArchive,
+			Content:  entry.Content,
+		})
+	}
+
+	var keyStoreChain tbtc.KeyStoreChain
+	if !offline {
+		_, tbtcChain, _, _, _, err := ethereum.Connect(
+			cmd.Context(),
+			clientConfig.Ethereum,
+			ethereum.WithSecretProvider(clientConfig.SecretProvider()),
+			ethereum.WithRemoteSigner(clientConfig.RemoteSigner),
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"could not connect to Ethereum chain: [%v]",
+				err,
+			)
+		}
+
+		keyStoreChain = tbtcChain
+	}
+
+	wallets, err := tbtc.ReadStoredWallets(files, keyStoreChain)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*walletView, 0, len(wallets))
+	for _, wallet := range wallets {
+		views = append(views, newWalletView(wallet, !offline))
+	}
+
+	return views, nil
+}
+
+func printWalletView(writer io.Writer, view *walletView) error {
+	w := tabwriter.NewWriter(writer, 2, 4, 1, ' ', 0)
+
+	fmt.Fprintf(w, "wallet public key hash:\t%s\n", view.WalletPublicKeyHash)
+	if view.WalletID != "" {
+		fmt.Fprintf(w, "wallet ID:\t%s\n", view.WalletID)
+	}
+	fmt.Fprintf(w, "member indexes:\t%s\n", formatMemberIndexes(view.MemberIndexes))
+	fmt.Fprintf(w, "group size:\t%v\n", view.GroupSize)
+	fmt.Fprintf(w, "honest threshold:\t%v\n", view.HonestThreshold)
+	fmt.Fprintf(w, "archived:\t%v\n", view.Archived)
+
+	if view.State != "" {
+		fmt.Fprintf(w, "state:\t%s\n", view.State)
+	}
+
+	if chainData := view.ChainData; chainData != nil {
+		fmt.Fprintf(w, "created at:\t%v\n", chainData.CreatedAt)
+		fmt.Fprintf(w, "main UTXO hash:\t%s\n", chainData.MainUtxoHash)
+		fmt.Fprintf(
+			w,
+			"pending redemptions value:\t%v\n",
+			chainData.PendingRedemptionsValue,
+		)
+		if chainData.MovingFundsRequestedAt != nil {
+			fmt.Fprintf(
+				w,
+				"moving funds requested at:\t%v\n",
+				chainData.MovingFundsRequestedAt,
+			)
+		}
+		if chainData.ClosingStartedAt != nil {
+			fmt.Fprintf(
+				w,
+				"closing started at:\t%v\n",
+				chainData.ClosingStartedAt,
+			)
+		}
+		fmt.Fprintf(
+			w,
+			"pending moved funds sweep requests:\t%v\n",
+			chainData.PendingMovedFundsSweepRequestsCount,
+		)
+	}
+
+	for _, issue := range view.Issues {
+		fmt.Fprintf(w, "issue:\t%s\n", issue)
+	}
+
+	return w.Flush()
+}
+
+func formatMemberIndexes(memberIndexes []group.MemberIndex) string {
+	formatted := make([]string, len(memberIndexes))
+	for i, memberIndex := range memberIndexes {
+		formatted[i] = fmt.Sprintf("%v", memberIndex)
+	}
+
+	return strings.Join(formatted, ",")
+}
+
+func printJSON(writer io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+func init() {
+	for _, command := range []*cobra.Command{
+		&walletsListCommand,
+		&walletsShowCommand,
+	} {
+		initFlags(
+			command,
+			&configFilePath,
+			clientConfig,
+			config.General, config.Ethereum, config.Storage,
+		)
+
+		command.Flags().Bool(
+			walletsOfflineFlagName,
+			false,
+			"skip reading the on-chain wallet data",
+		)
+		command.Flags().Bool(
+			walletsJSONFlagName,
+			false,
+			"print the output in JSON",
+		)
+
+		WalletsCommand.AddCommand(command)
+	}
+}
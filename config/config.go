@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
@@ -20,9 +21,12 @@ import (
 
 	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/bitcoin/electrum"
+	"github.com/keep-network/keep-core/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/firewall"
 	"github.com/keep-network/keep-core/pkg/maintainer"
 	"github.com/keep-network/keep-core/pkg/net/libp2p"
+	"github.com/keep-network/keep-core/pkg/secret"
 	"github.com/keep-network/keep-core/pkg/storage"
 	"github.com/keep-network/keep-core/pkg/tbtc"
 )
@@ -50,8 +54,16 @@ type Config struct {
 	LibP2P     libp2p.Config `mapstructure:"network"`
 	Storage    storage.Config
 	ClientInfo clientinfo.Config
+	Firewall   firewall.Config
 	Maintainer maintainer.Config
 	Tbtc       tbtc.Config
+	Secrets    secret.Config
+
+	RemoteSigner ethereum.RemoteSignerConfig
+
+	// secretProvider is the provider of secrets created from the Secrets
+	// configuration while reading the config.
+	secretProvider secret.Provider
 }
 
 // BitcoinConfig defines the configuration for Bitcoin.
@@ -163,13 +175,37 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	secretProvider, err := secret.NewProvider(c.Secrets)
+	if err != nil {
+		return fmt.Errorf("cannot create secret provider: [%w]", err)
+	}
+	c.secretProvider = secretProvider
+
 	// Don't use viper.BindEnv for password reading as it's too sensitive value
 	// to read it with an external library.
 	if c.Ethereum.Account.KeyFilePassword == "" {
-		c.Ethereum.Account.KeyFilePassword = os.Getenv(EthereumPasswordEnvVariable)
+		password, ok, err := secret.Lookup(
+			context.Background(),
+			secretProvider,
+			secret.EthereumPassword,
+		)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			c.Ethereum.Account.KeyFilePassword = string(password)
+		} else {
+			c.Ethereum.Account.KeyFilePassword = os.Getenv(EthereumPasswordEnvVariable)
+		}
 	}
 
-	if strings.TrimSpace(c.Ethereum.Account.KeyFilePassword) == "" {
+	localKey, err := c.hasLocalKey(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if localKey && strings.TrimSpace(c.Ethereum.Account.KeyFilePassword) == "" {
 		var (
 			password string
 			err      error
@@ -181,7 +217,7 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 		)
 
 		for strings.TrimSpace(password) == "" {
-			if password, err = readPassword("Enter Ethereum Account Password: "); err != nil {
+			if password, err = ReadPassword("Enter Ethereum Account Password: "); err != nil {
 				return err
 			}
 		}
@@ -192,6 +228,63 @@ func (c *Config) ReadConfig(configFilePath string, flagSet *pflag.FlagSet, categ
 	return nil
 }
 
+// hasLocalKey tells whether the operator key is expected to be available
+// locally, either in the key file or in the secret provider. If the remote
+// signer is configured, the local key is optional.
+func (c *Config) hasLocalKey(ctx context.Context) (bool, error) {
+	if c.Ethereum.Account.KeyFile != "" || !c.RemoteSigner.Enabled() {
+		return true, nil
+	}
+
+	_, ok, err := secret.Lookup(ctx, c.SecretProvider(), secret.EthereumKey)
+	if err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+// SecretProvider returns the provider of secrets created while reading the
+// config. If the config was not read, the provider reading secrets from
+// environment variables is returned.
+func (c *Config) SecretProvider() secret.Provider {
+	if c.secretProvider == nil {
+		provider, _ := secret.NewProvider(secret.Config{})
+		return provider
+	}
+
+	return c.secretProvider
+}
+
+// StoragePassword returns the password the storage is encrypted with. The
+// password is read from the secret provider. If the provider does not hold
+// the storage password, the Ethereum key file password is used. If neither
+// is set, which is the case when the remote signer holds the operator key,
+// an error is returned.
+func (c *Config) StoragePassword(ctx context.Context) (string, error) {
+	password, ok, err := secret.Lookup(
+		ctx,
+		c.SecretProvider(),
+		secret.StoragePassword,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		if c.Ethereum.Account.KeyFilePassword == "" {
+			return "", fmt.Errorf(
+				"storage password is not set; set the [%s] secret",
+				secret.StoragePassword,
+			)
+		}
+
+		return c.Ethereum.Account.KeyFilePassword, nil
+	}
+
+	return string(password), nil
+}
+
 func validateConfig(config *Config, categories ...Category) error {
 	var result *multierror.Error
 
@@ -204,11 +297,30 @@ func validateConfig(config *Config, categories ...Category) error {
 				))
 			}
 
-			if config.Ethereum.Account.KeyFile == "" {
+			// An external secret provider may hold the key file content and
+			// the remote signer may hold the key itself.
+			if config.Ethereum.Account.KeyFile == "" &&
+				!config.RemoteSigner.Enabled() &&
+				(config.Secrets.Provider == "" ||
+					config.Secrets.Provider == secret.EnvProvider) {
 				result = multierror.Append(result, fmt.Errorf(
 					"missing value for ethereum.keyFile; see ethereum section in configuration",
 				))
 			}
+
+			if err := config.Secrets.Validate(); err != nil {
+				result = multierror.Append(result, fmt.Errorf(
+					"%w; see secrets section in configuration",
+					err,
+				))
+			}
+
+			if err := config.RemoteSigner.Validate(); err != nil {
+				result = multierror.Append(result, fmt.Errorf(
+					"%w; see remote signer section in configuration",
+					err,
+				))
+			}
 		case BitcoinElectrum:
 			if config.Bitcoin.Electrum.URL == "" {
 				result = multierror.Append(result, fmt.Errorf(
@@ -221,12 +333,26 @@ func validateConfig(config *Config, categories ...Category) error {
 					"missing value for network.port; see network section in configuration",
 				))
 			}
+
+			if err := config.Firewall.Validate(); err != nil {
+				result = multierror.Append(result, fmt.Errorf(
+					"%w; see firewall section in configuration",
+					err,
+				))
+			}
 		case Storage:
 			if config.Storage.Dir == "" {
 				result = multierror.Append(result, fmt.Errorf(
 					"missing value for storage.dir; see storage section in configuration",
 				))
 			}
+
+			if err := config.Storage.ValidateBackend(); err != nil {
+				result = multierror.Append(result, fmt.Errorf(
+					"%w; see storage section in configuration",
+					err,
+				))
+			}
 		}
 	}
 
@@ -254,26 +380,29 @@ func readConfigFile(configFilePath string) error {
 // unmarshalConfig unmarshals config with viper from config file and command-line
 // flags into a struct.
 func unmarshalConfig(config *Config) error {
-	if err := viper.Unmarshal(
-		config,
-		viper.DecodeHook(
-			mapstructure.ComposeDecodeHookFunc(
-				mapstructure.StringToTimeDurationHookFunc(),
-				mapstructure.StringToSliceHookFunc(","),
-				mapstructure.TextUnmarshallerHookFunc(),
-			),
-		),
-	); err != nil {
+	if err := viper.Unmarshal(config, configDecodeHook()); err != nil {
 		return fmt.Errorf("failed to unmarshal configuration: %w", err)
 	}
 
 	return nil
 }
 
-// readPassword prompts a user to enter a password. The read password uses
+// configDecodeHook returns the decode hook used to unmarshal configuration
+// values into their target types.
+func configDecodeHook() viper.DecoderConfigOption {
+	return viper.DecodeHook(
+		mapstructure.ComposeDecodeHookFunc(
+			mapstructure.StringToTimeDurationHookFunc(),
+			mapstructure.StringToSliceHookFunc(","),
+			mapstructure.TextUnmarshallerHookFunc(),
+		),
+	)
+}
+
+// ReadPassword prompts a user to enter a password. The read password uses
 // the system password reading call that helps to prevent key loggers from
 // capturing the password.
-func readPassword(prompt string) (string, error) {
+func ReadPassword(prompt string) (string, error) {
 	fmt.Print(prompt)
 	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
 	fmt.Print("\n")
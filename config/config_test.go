@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"os"
@@ -199,6 +200,32 @@ func TestReadConfigFromFile(t *testing.T) {
 			readValueFunc: func(c *Config) interface{} { return c.ClientInfo.EthereumMetricsTick },
 			expectedValue: 87 * time.Second,
 		},
+		"Firewall.DeniedOperators": {
+			readValueFunc: func(c *Config) interface{} { return c.Firewall.DeniedOperators },
+			expectedValue: []string{
+				"03f4d3e5d7dfa8b3cd98a2cd3a3c8b6f4fcd8ec7b1a4c4c8e3a4a6dcc0d4b1e2f5",
+				"0x1f9f1ec07d0e3e1b0bd9e7b2d3a7a8e7c0b9a3f4",
+			},
+		},
+		"Firewall.DeniedAddresses": {
+			readValueFunc: func(c *Config) interface{} { return c.Firewall.DeniedAddresses },
+			expectedValue: []string{"192.168.10.7", "10.20.0.0/16"},
+		},
+		"Firewall.ConnectionRateLimit": {
+			readValueFunc: func(c *Config) interface{} { return c.Firewall.ConnectionRateLimit },
+			expectedValue: 7,
+		},
+		"Firewall.ConnectionRateLimitPeriod": {
+			readValueFunc: func(c *Config) interface{} { return c.Firewall.ConnectionRateLimitPeriod },
+			expectedValue: 90 * time.Second,
+		},
+		"Firewall.MinimumStake": {
+			readValueFunc: func(c *Config) interface{} { return c.Firewall.MinimumStake },
+			expectedValue: func() *big.Int {
+				v, _ := new(big.Int).SetString("40000000000000000000000", 10)
+				return v
+			}(),
+		},
 		"Maintainer.BitcoinDifficulty.Enabled": {
 			readValueFunc: func(c *Config) interface{} { return c.Maintainer.BitcoinDifficulty.Enabled },
 			expectedValue: true,
@@ -404,3 +431,42 @@ func TestReadConfig_ReadContracts(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_StoragePassword(t *testing.T) {
+	cfg := &Config{}
+	cfg.Ethereum.Account.KeyFilePassword = "ethereum-password"
+
+	t.Setenv("KEEP_STORAGE_PASSWORD", "")
+
+	password, err := cfg.StoragePassword(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password != "ethereum-password" {
+		t.Errorf(
+			"unexpected storage password\nexpected: %s\nactual:   %s",
+			"ethereum-password",
+			password,
+		)
+	}
+
+	cfg.Ethereum.Account.KeyFilePassword = ""
+
+	if _, err := cfg.StoragePassword(context.Background()); err == nil {
+		t.Errorf("expected error when no password is set")
+	}
+
+	t.Setenv("KEEP_STORAGE_PASSWORD", "storage-password")
+
+	password, err = cfg.StoragePassword(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password != "storage-password" {
+		t.Errorf(
+			"unexpected storage password\nexpected: %s\nactual:   %s",
+			"storage-password",
+			password,
+		)
+	}
+}
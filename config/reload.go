@@ -0,0 +1,277 @@
+package config
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// UpdateHook applies changes of the reloaded configuration to a running
+// subsystem. It receives the currently applied configuration and the reloaded
+// one and returns names of the configuration properties, as returned by
+// ChangedProperties, whose changes were applied live. An error means none
+// of the subsystem's changes were applied.
+type UpdateHook func(current *Config, reloaded *Config) ([]string, error)
+
+// Watcher reloads the configuration file each time the process receives
+// SIGHUP and applies the changes through the registered update hooks.
+// Changes not applied by any of the hooks require a client restart and
+// are reported as such.
+type Watcher struct {
+	configFilePath string
+
+	mutex    sync.Mutex
+	startup  *Config
+	current  *Config
+	hooks    []namedUpdateHook
+	liveSet  map[string]bool
+	reloadFn func() (*Config, error)
+}
+
+type namedUpdateHook struct {
+	name string
+	hook UpdateHook
+}
+
+// NewWatcher creates a new configuration watcher for the configuration read
+// from the given config file path. The given categories are used to validate
+// the reloaded configuration.
+func NewWatcher(
+	configFilePath string,
+	config *Config,
+	categories ...Category,
+) *Watcher {
+	w := &Watcher{
+		configFilePath: configFilePath,
+		startup:        config,
+		current:        config,
+		liveSet:        make(map[string]bool),
+	}
+
+	w.reloadFn = func() (*Config, error) {
+		return config.reread(configFilePath, categories...)
+	}
+
+	return w
+}
+
+// RegisterUpdateHook registers the update hook of the subsystem with the
+// given name. Hooks are executed in the registration order.
+func (w *Watcher) RegisterUpdateHook(name string, hook UpdateHook) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.hooks = append(w.hooks, namedUpdateHook{name, hook})
+}
+
+// Watch reloads the configuration on each SIGHUP until the context is done.
+func (w *Watcher) Watch(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-signals:
+			if err := w.Reload(); err != nil {
+				logger.Errorf(
+					"cannot reload configuration; keeping the current one: [%v]",
+					err,
+				)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Reload reads and validates the configuration file and applies the changes
+// through the registered update hooks. If the configuration cannot be read
+// or is invalid, an error is returned and no changes are applied.
+func (w *Watcher) Reload() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	logger.Infof("reloading configuration from [%s]", w.configFilePath)
+
+	reloaded, err := w.reloadFn()
+	if err != nil {
+		return err
+	}
+
+	changed := ChangedProperties(w.current, reloaded)
+	if len(changed) == 0 {
+		logger.Infof("configuration has not changed")
+	}
+
+	for _, namedHook := range w.hooks {
+		applied, err := namedHook.hook(w.current, reloaded)
+		if err != nil {
+			logger.Errorf(
+				"cannot apply configuration changes to [%s]: [%v]",
+				namedHook.name,
+				err,
+			)
+			continue
+		}
+
+		if len(applied) > 0 {
+			logger.Infof(
+				"applied configuration changes to [%s]: %v",
+				namedHook.name,
+				applied,
+			)
+		}
+
+		for _, property := range applied {
+			w.liveSet[property] = true
+		}
+	}
+
+	// Properties changed since the startup but not applied live by any hook
+	// remain pending until the client is restarted.
+	pendingRestart := make([]string, 0)
+	for _, property := range ChangedProperties(w.startup, reloaded) {
+		if !w.liveSet[property] {
+			pendingRestart = append(pendingRestart, property)
+		}
+	}
+
+	if len(pendingRestart) > 0 {
+		logger.Warnf(
+			"configuration changes requiring restart: %v",
+			pendingRestart,
+		)
+	}
+
+	w.current = reloaded
+
+	return nil
+}
+
+// reread reads the configuration file again and returns the reloaded
+// configuration. Values resolved at the startup, like networks, default
+// peers, Electrum server, the Ethereum account password and the secret
+// provider, are carried over from the current configuration if they are not
+// set explicitly.
+func (c *Config) reread(
+	configFilePath string,
+	categories ...Category,
+) (*Config, error) {
+	if err := readConfigFile(configFilePath); err != nil {
+		return nil, fmt.Errorf(
+			"unable to load config (file: [%s]): [%w]",
+			configFilePath,
+			err,
+		)
+	}
+
+	reloaded := &Config{}
+	if err := unmarshalConfig(reloaded); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal config: %w", err)
+	}
+
+	reloaded.Ethereum.Network = c.Ethereum.Network
+	reloaded.Bitcoin.Network = c.Bitcoin.Network
+
+	reloaded.resolveContractsAddresses()
+
+	if len(reloaded.LibP2P.Peers) == 0 {
+		reloaded.LibP2P.Peers = c.LibP2P.Peers
+	}
+
+	if reloaded.Bitcoin.Electrum.URL == "" {
+		reloaded.Bitcoin.Electrum.URL = c.Bitcoin.Electrum.URL
+	}
+
+	if err := validateConfig(reloaded, categories...); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if reloaded.Ethereum.Account.KeyFilePassword == "" {
+		reloaded.Ethereum.Account.KeyFilePassword =
+			c.Ethereum.Account.KeyFilePassword
+	}
+
+	// Secrets are read only at the startup.
+	reloaded.secretProvider = c.secretProvider
+
+	return reloaded, nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// ChangedProperties returns sorted names of the configuration properties
+// whose values differ between the two given values of the same type.
+// Properties are named with field names of nested structures joined with
+// dots, e.g. `ClientInfo.NetworkMetricsTick`. Optional prefix is prepended
+// to all the names.
+func ChangedProperties(previous, current interface{}, prefix ...string) []string {
+	changed := make([]string, 0)
+
+	collectChangedProperties(
+		reflect.ValueOf(previous),
+		reflect.ValueOf(current),
+		strings.Join(prefix, "."),
+		&changed,
+	)
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+func collectChangedProperties(
+	previous reflect.Value,
+	current reflect.Value,
+	name string,
+	changed *[]string,
+) {
+	if previous.Kind() == reflect.Pointer {
+		if previous.IsNil() || current.IsNil() {
+			if previous.IsNil() != current.IsNil() {
+				*changed = append(*changed, name)
+			}
+			return
+		}
+
+		previous = previous.Elem()
+		current = current.Elem()
+	}
+
+	// Structures that know how to unmarshal themselves from text, like
+	// big.Int, are configuration leaves just like non-structure values.
+	if previous.Kind() != reflect.Struct ||
+		reflect.PointerTo(previous.Type()).Implements(textUnmarshalerType) {
+		if !reflect.DeepEqual(previous.Interface(), current.Interface()) {
+			*changed = append(*changed, name)
+		}
+		return
+	}
+
+	for i := 0; i < previous.NumField(); i++ {
+		field := previous.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldName := field.Name
+		if name != "" {
+			fieldName = name + "." + field.Name
+		}
+
+		collectChangedProperties(
+			previous.Field(i),
+			current.Field(i),
+			fieldName,
+			changed,
+		)
+	}
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/firewall"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestChangedProperties(t *testing.T) {
+	previous := &Config{
+		ClientInfo: clientinfo.Config{
+			Port:               3498,
+			NetworkMetricsTick: 43 * time.Second,
+		},
+		Firewall: firewall.Config{
+			DeniedOperators: []string{"0x1f9f1ec07d0e3e1b0bd9e7b2d3a7a8e7c0b9a3f4"},
+			MinimumStake:    big.NewInt(100),
+		},
+		Tbtc: tbtc.Config{PreParamsPoolSize: 3000},
+	}
+
+	var tests = map[string]struct {
+		modifyFn func(*Config)
+		expected []string
+	}{
+		"no changes": {
+			modifyFn: func(c *Config) {},
+			expected: []string{},
+		},
+		"nested duration changed": {
+			modifyFn: func(c *Config) {
+				c.ClientInfo.NetworkMetricsTick = time.Minute
+			},
+			expected: []string{"ClientInfo.NetworkMetricsTick"},
+		},
+		"slice and big integer changed": {
+			modifyFn: func(c *Config) {
+				c.Firewall.DeniedOperators = []string{}
+				c.Firewall.MinimumStake = big.NewInt(200)
+			},
+			expected: []string{
+				"Firewall.DeniedOperators",
+				"Firewall.MinimumStake",
+			},
+		},
+		"big integer unset": {
+			modifyFn: func(c *Config) {
+				c.Firewall.MinimumStake = nil
+			},
+			expected: []string{"Firewall.MinimumStake"},
+		},
+		"multiple sections changed": {
+			modifyFn: func(c *Config) {
+				c.Tbtc.PreParamsPoolSize = 10
+				c.ClientInfo.Port = 3499
+			},
+			expected: []string{"ClientInfo.Port", "Tbtc.PreParamsPoolSize"},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			current := copyConfig(previous)
+			test.modifyFn(current)
+
+			actual := ChangedProperties(previous, current)
+			if !reflect.DeepEqual(test.expected, actual) {
+				t.Errorf(
+					"unexpected changed properties\nexpected: %v\nactual:   %v",
+					test.expected,
+					actual,
+				)
+			}
+		})
+	}
+}
+
+func TestChangedProperties_Prefix(t *testing.T) {
+	actual := ChangedProperties(
+		tbtc.Config{KeyGenerationConcurrency: 1},
+		tbtc.Config{KeyGenerationConcurrency: 2},
+		"Tbtc",
+	)
+
+	expected := []string{"Tbtc.KeyGenerationConcurrency"}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf(
+			"unexpected changed properties\nexpected: %v\nactual:   %v",
+			expected,
+			actual,
+		)
+	}
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	startup := &Config{
+		ClientInfo: clientinfo.Config{
+			Port:               3498,
+			NetworkMetricsTick: 43 * time.Second,
+		},
+	}
+
+	watcher := NewWatcher("config.toml", startup)
+
+	reloaded := copyConfig(startup)
+	reloaded.ClientInfo.Port = 3499
+	reloaded.ClientInfo.NetworkMetricsTick = time.Minute
+	watcher.reloadFn = func() (*Config, error) { return reloaded, nil }
+
+	var hookCalls []string
+	watcher.RegisterUpdateHook(
+		"clientinfo",
+		func(current *Config, next *Config) ([]string, error) {
+			hookCalls = append(hookCalls, "clientinfo")
+
+			if current != startup {
+				t.Errorf("hook received unexpected current config")
+			}
+			if next != reloaded {
+				t.Errorf("hook received unexpected reloaded config")
+			}
+
+			return []string{"ClientInfo.NetworkMetricsTick"}, nil
+		},
+	)
+	watcher.RegisterUpdateHook(
+		"failing",
+		func(current *Config, next *Config) ([]string, error) {
+			hookCalls = append(hookCalls, "failing")
+			return nil, fmt.Errorf("unexpected failure")
+		},
+	)
+
+	if err := watcher.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedHookCalls := []string{"clientinfo", "failing"}
+	if !reflect.DeepEqual(expectedHookCalls, hookCalls) {
+		t.Errorf(
+			"unexpected hook calls\nexpected: %v\nactual:   %v",
+			expectedHookCalls,
+			hookCalls,
+		)
+	}
+
+	if watcher.current != reloaded {
+		t.Errorf("reloaded config should become the current one")
+	}
+
+	if !watcher.liveSet["ClientInfo.NetworkMetricsTick"] {
+		t.Errorf("applied property should be recorded as live")
+	}
+
+	if watcher.liveSet["ClientInfo.Port"] {
+		t.Errorf("not applied property should not be recorded as live")
+	}
+}
+
+func TestWatcher_Reload_Error(t *testing.T) {
+	startup := &Config{}
+
+	watcher := NewWatcher("config.toml", startup)
+	watcher.reloadFn = func() (*Config, error) {
+		return nil, fmt.Errorf("invalid config")
+	}
+
+	hookCalled := false
+	watcher.RegisterUpdateHook(
+		"test",
+		func(current *Config, next *Config) ([]string, error) {
+			hookCalled = true
+			return nil, nil
+		},
+	)
+
+	if err := watcher.Reload(); err == nil {
+		t.Fatal("expected reload error")
+	}
+
+	if hookCalled {
+		t.Errorf("hooks should not be called for invalid config")
+	}
+
+	if watcher.current != startup {
+		t.Errorf("current config should be kept")
+	}
+}
+
+func copyConfig(config *Config) *Config {
+	copied := *config
+	copied.Firewall.DeniedOperators = append(
+		[]string{},
+		config.Firewall.DeniedOperators...,
+	)
+	return &copied
+}
@@ -44,7 +44,7 @@ func Initialize(
 		scheduler,
 	)
 
-	err := sortition.MonitorPool(
+	_, err := sortition.MonitorPool(
 		ctx,
 		logger,
 		beaconChain,
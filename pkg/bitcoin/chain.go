@@ -62,6 +62,14 @@ type Chain interface {
 		publicKeyHash [20]byte,
 	) ([]Hash, error)
 
+	// GetTxHashesForScript gets hashes of confirmed transactions that pay or
+	// spend outputs locked with the given script (P2PKH, P2WPKH, P2SH, P2WSH,
+	// etc.). The returned transactions hashes are ordered by block height in
+	// the ascending order, i.e. the latest transaction hash is at the end of
+	// the list. The returned list does not contain unconfirmed transactions
+	// hashes living in the mempool at the moment of request.
+	GetTxHashesForScript(script Script) ([]Hash, error)
+
 	// GetMempoolForPublicKeyHash gets the unconfirmed mempool transactions
 	// that pays the given public key hash using either a P2PKH or P2WPKH script.
 	// The returned transactions are in an indefinite order.
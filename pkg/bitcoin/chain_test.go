@@ -126,6 +126,12 @@ func (lc *localChain) GetTxHashesForPublicKeyHash(
 	panic("unsupported")
 }
 
+func (lc *localChain) GetTxHashesForScript(
+	script Script,
+) ([]Hash, error) {
+	panic("unsupported")
+}
+
 func (lc *localChain) GetMempoolForPublicKeyHash(
 	publicKeyHash [20]byte,
 ) ([]*Transaction, error) {
@@ -496,6 +496,32 @@ func (c *Connection) GetTxHashesForPublicKeyHash(
 	return txHashes, nil
 }
 
+// GetTxHashesForScript gets hashes of confirmed transactions that pay or
+// spend outputs locked with the given script (P2PKH, P2WPKH, P2SH, P2WSH,
+// etc.). The returned transactions hashes are ordered by block height in
+// the ascending order, i.e. the latest transaction hash is at the end of
+// the list. The returned list does not contain unconfirmed transactions
+// hashes living in the mempool at the moment of request.
+func (c *Connection) GetTxHashesForScript(
+	script bitcoin.Script,
+) ([]bitcoin.Hash, error) {
+	items, err := c.getConfirmedScriptHistory(script)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get history for script [0x%x]: [%v]",
+			script,
+			err,
+		)
+	}
+
+	txHashes := make([]bitcoin.Hash, len(items))
+	for i, item := range items {
+		txHashes[i] = item.txHash
+	}
+
+	return txHashes, nil
+}
+
 type scriptHistoryItem struct {
 	txHash      bitcoin.Hash
 	blockHeight int32
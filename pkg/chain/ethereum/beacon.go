@@ -90,6 +90,8 @@ func newBeaconChain(
 		)
 	}
 
+	baseChain.useRemoteSigner(randomBeacon, sortitionPool)
+
 	return &BeaconChain{
 		baseChain:     baseChain,
 		randomBeacon:  randomBeacon,
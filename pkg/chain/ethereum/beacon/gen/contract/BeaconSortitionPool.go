@@ -4071,3 +4071,11 @@ func (bsp *BeaconSortitionPool) PastRewardEligibilityRestoredEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (bsp *BeaconSortitionPool) SetTransactorSigner(signer bind.SignerFn) {
+	bsp.transactorOptions.Signer = signer
+}
@@ -12789,3 +12789,11 @@ func (rb *RandomBeacon) PastUnauthorizedSigningSlashingFailedEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (rb *RandomBeacon) SetTransactorSigner(signer bind.SignerFn) {
+	rb.transactorOptions.Signer = signer
+}
@@ -63,6 +63,8 @@ func NewBitcoinDifficultyChain(
 		)
 	}
 
+	baseChain.useRemoteSigner(lightRelay)
+
 	// If the Bitcoin difficulty should be updated directly via LightRelay,
 	// quit early without creating a handle to LightRelayMaintainerProxy.
 	if maintainerConfig.BitcoinDifficulty.DisableProxy {
@@ -102,6 +104,8 @@ func NewBitcoinDifficultyChain(
 		)
 	}
 
+	baseChain.useRemoteSigner(lightRelayMaintainerProxy)
+
 	retrievedLightRelayAddress, err := lightRelayMaintainerProxy.LightRelay()
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -11231,3 +11231,11 @@ func (wr *WalletRegistry) PastWalletOwnerUpdatedEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (wr *WalletRegistry) SetTransactorSigner(signer bind.SignerFn) {
+	wr.transactorOptions.Signer = signer
+}
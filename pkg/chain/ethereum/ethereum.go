@@ -2,6 +2,7 @@ package ethereum
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ipfs/go-log"
@@ -21,6 +23,7 @@ import (
 	"github.com/keep-network/keep-core/pkg/chain/ethereum/threshold/gen/contract"
 	"github.com/keep-network/keep-core/pkg/maintainer"
 	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/secret"
 )
 
 // Definitions of contract names.
@@ -57,12 +60,54 @@ type baseChain struct {
 	transactionMutex *sync.Mutex
 
 	tokenStaking *contract.TokenStaking
+
+	// remoteSigner signs messages and transactions with the operator key held
+	// by the remote signer. If nil, messages and transactions are signed with
+	// the local key.
+	remoteSigner *remoteSigner
+}
+
+// ConnectOption is an option of the Ethereum chain connection.
+type ConnectOption func(*connectOptions)
+
+type connectOptions struct {
+	secretProvider     secret.Provider
+	remoteSignerConfig RemoteSignerConfig
+}
+
+// WithSecretProvider makes the connection read the operator key from the
+// given secret provider. If the provider does not hold the key, the key file
+// pointed by the config is used.
+func WithSecretProvider(secretProvider secret.Provider) ConnectOption {
+	return func(options *connectOptions) {
+		options.secretProvider = secretProvider
+	}
 }
 
-// Connect creates Random Beacon and TBTC Ethereum chain handles.
+// WithRemoteSigner makes the connection sign messages and transactions with
+// the operator key held by the remote signer. The local key is optional in
+// that case. The option has no effect if the remote signer URL is not set.
+func WithRemoteSigner(config RemoteSignerConfig) ConnectOption {
+	return func(options *connectOptions) {
+		options.remoteSignerConfig = config
+	}
+}
+
+func newConnectOptions(options []ConnectOption) *connectOptions {
+	result := &connectOptions{}
+	for _, option := range options {
+		option(result)
+	}
+	return result
+}
+
+// Connect creates Random Beacon and TBTC Ethereum chain handles. The returned
+// operator private key is nil if the operator key is held only by the remote
+// signer.
 func Connect(
 	ctx context.Context,
 	config ethereum.Config,
+	options ...ConnectOption,
 ) (
 	*BeaconChain,
 	*TbtcChain,
@@ -80,7 +125,12 @@ func Connect(
 		)
 	}
 
-	baseChain, err := newBaseChain(ctx, config, client)
+	baseChain, err := newBaseChain(
+		ctx,
+		config,
+		client,
+		newConnectOptions(options),
+	)
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf(
 			"could not create base chain handle: [%v]",
@@ -131,6 +181,7 @@ func ConnectBitcoinDifficulty(
 	ctx context.Context,
 	ethereumConfig ethereum.Config,
 	maintainerConfig maintainer.Config,
+	options ...ConnectOption,
 ) (
 	*BitcoinDifficultyChain,
 	error,
@@ -144,7 +195,12 @@ func ConnectBitcoinDifficulty(
 		)
 	}
 
-	baseChain, err := newBaseChain(ctx, ethereumConfig, client)
+	baseChain, err := newBaseChain(
+		ctx,
+		ethereumConfig,
+		client,
+		newConnectOptions(options),
+	)
 	if err != nil {
 		return nil, fmt.Errorf(
 			"could not create base chain handle: [%v]",
@@ -217,6 +273,7 @@ func newBaseChain(
 	ctx context.Context,
 	config ethereum.Config,
 	client *ethclient.Client,
+	options *connectOptions,
 ) (*baseChain, error) {
 	chainID, err := client.ChainID(ctx)
 	if err != nil {
@@ -238,12 +295,28 @@ func newBaseChain(
 		)
 	}
 
-	key, err := decryptKey(config)
+	key, err := decryptKey(ctx, config, options.secretProvider)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to decrypt Ethereum key: [%v]",
-			err,
+		// The local key is optional if the remote signer holds it.
+		if !errors.Is(err, errKeyNotSet) ||
+			!options.remoteSignerConfig.Enabled() {
+			return nil, fmt.Errorf(
+				"failed to decrypt Ethereum key: [%v]",
+				err,
+			)
+		}
+	}
+
+	var remoteSigner *remoteSigner
+	if options.remoteSignerConfig.Enabled() {
+		remoteSigner, key, err = connectRemoteSigner(
+			ctx,
+			options.remoteSignerConfig,
+			key,
 		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	clientWithAddons := wrapClientAddons(config, client)
@@ -294,6 +367,10 @@ func newBaseChain(
 		)
 	}
 
+	if remoteSigner != nil {
+		tokenStaking.SetTransactorSigner(remoteSigner.transactorSigner(chainID))
+	}
+
 	return &baseChain{
 		key:              key,
 		client:           clientWithAddons,
@@ -303,11 +380,13 @@ func newBaseChain(
 		miningWaiter:     miningWaiter,
 		transactionMutex: transactionMutex,
 		tokenStaking:     tokenStaking,
+		remoteSigner:     remoteSigner,
 	}, nil
 }
 
 // OperatorKeyPair returns the key pair of the operator assigned to this
-// chain handle.
+// chain handle. The private key is nil if the operator key is held only by
+// the remote signer.
 func (bc *baseChain) OperatorKeyPair() (
 	*operator.PrivateKey,
 	*operator.PublicKey,
@@ -323,9 +402,31 @@ func (bc *baseChain) OperatorKeyPair() (
 		)
 	}
 
+	if privateKey.D == nil {
+		return nil, publicKey, nil
+	}
+
 	return privateKey, publicKey, nil
 }
 
+// transactorSignerSetter is a generated contract binding whose transaction
+// signer can be replaced.
+type transactorSignerSetter interface {
+	SetTransactorSigner(signer bind.SignerFn)
+}
+
+// useRemoteSigner makes the given contract bindings sign transactions with
+// the remote signer, if the remote signer is configured.
+func (bc *baseChain) useRemoteSigner(contracts ...transactorSignerSetter) {
+	if bc.remoteSigner == nil {
+		return
+	}
+
+	for _, contract := range contracts {
+		contract.SetTransactorSigner(bc.remoteSigner.transactorSigner(bc.chainID))
+	}
+}
+
 // GetBlockNumberByTimestamp gets the block number for the given timestamp.
 // In the best case, the block with the exact same timestamp is returned.
 // If the aforementioned is not possible, it tries to return the closest
@@ -427,6 +528,16 @@ func (bc *baseChain) GetBlockHashByNumber(blockNumber uint64) (
 	return header.Hash(), nil
 }
 
+// GetBlockTimestamp gets the timestamp of the block with the given number.
+func (bc *baseChain) GetBlockTimestamp(blockNumber uint64) (uint64, error) {
+	header, err := bc.headerByNumber(blockNumber)
+	if err != nil {
+		return 0, fmt.Errorf("cannot get block header: [%v]", err)
+	}
+
+	return header.Time, nil
+}
+
 // currentBlock fetches the current block.
 func (bc *baseChain) currentBlock() (*types.Block, error) {
 	currentBlockNumber, err := bc.blockCounter.CurrentBlock()
@@ -522,8 +633,46 @@ func wrapClientAddons(
 	return loggingClient
 }
 
-// decryptKey decrypts the chain key pointed by the config.
-func decryptKey(config ethereum.Config) (*keystore.Key, error) {
+// errKeyNotSet is returned by decryptKey if neither the key file nor the
+// secret provider holds the chain key.
+var errKeyNotSet = fmt.Errorf(
+	"key file is not set and secret provider does not hold the key",
+)
+
+// decryptKey decrypts the chain key. The key is read from the secret
+// provider if it holds the key, otherwise from the key file pointed by the
+// config.
+func decryptKey(
+	ctx context.Context,
+	config ethereum.Config,
+	secretProvider secret.Provider,
+) (*keystore.Key, error) {
+	if secretProvider != nil {
+		keyJSON, ok, err := secret.Lookup(ctx, secretProvider, secret.EthereumKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			key, err := keystore.DecryptKey(
+				keyJSON,
+				config.Account.KeyFilePassword,
+			)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"unable to decrypt key provided by secret provider [%v]",
+					err,
+				)
+			}
+
+			return key, nil
+		}
+	}
+
+	if config.Account.KeyFile == "" {
+		return nil, errKeyNotSet
+	}
+
 	return ethutil.DecryptKeyFile(
 		config.Account.KeyFile,
 		config.Account.KeyFilePassword,
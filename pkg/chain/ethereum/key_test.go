@@ -1,11 +1,15 @@
 package ethereum
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
+	commonEthereum "github.com/keep-network/keep-common/pkg/chain/ethereum"
 	"github.com/keep-network/keep-core/pkg/operator"
+	"github.com/keep-network/keep-core/pkg/secret"
 	"math/big"
 	"reflect"
 	"testing"
@@ -184,3 +188,49 @@ func TestOperatorPublicKeyToChainAddress_NotSecp256k1(t *testing.T) {
 		)
 	}
 }
+
+func TestDecryptKey_SecretProvider(t *testing.T) {
+	chainPrivateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyJSON, err := keystore.EncryptKey(
+		&keystore.Key{
+			Address:    crypto.PubkeyToAddress(chainPrivateKey.PublicKey),
+			PrivateKey: chainPrivateKey,
+		},
+		"password",
+		keystore.LightScryptN,
+		keystore.LightScryptP,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := commonEthereum.Config{}
+	config.Account.KeyFilePassword = "password"
+
+	t.Setenv(secret.EnvVariable(secret.EthereumKey), string(keyJSON))
+
+	provider, err := secret.NewProvider(secret.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := decryptKey(context.Background(), config, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key.PrivateKey.D.Cmp(chainPrivateKey.D) != 0 {
+		t.Errorf("unexpected decrypted key")
+	}
+
+	// Without the key provided, the key file must be set.
+	t.Setenv(secret.EnvVariable(secret.EthereumKey), "")
+
+	if _, err := decryptKey(context.Background(), config, provider); err == nil {
+		t.Errorf("expected error for missing key file")
+	}
+}
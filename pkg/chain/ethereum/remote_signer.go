@@ -0,0 +1,495 @@
+package ethereum
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/keep-network/keep-common/pkg/chain/ethereum/ethutil"
+)
+
+const (
+	// EthRemoteSignerAPI is the `eth` namespace JSON-RPC API exposed, among
+	// others, by Web3Signer.
+	EthRemoteSignerAPI = "eth"
+	// AccountRemoteSignerAPI is the `account` namespace JSON-RPC API exposed
+	// by Clef.
+	AccountRemoteSignerAPI = "account"
+
+	// DefaultRemoteSignerTimeout is the default timeout of a single request
+	// to the remote signer.
+	DefaultRemoteSignerTimeout = 10 * time.Second
+)
+
+// remoteSignerProbeMessage is the message signed by the remote signer when
+// connecting to it. The operator public key is recovered from the signature.
+const remoteSignerProbeMessage = "keep-client remote signer public key probe"
+
+// RemoteSignerConfig is the configuration of the remote signer holding the
+// operator key.
+type RemoteSignerConfig struct {
+	// URL is the address of the remote signer JSON-RPC endpoint. The remote
+	// signer is not used if the URL is not set.
+	URL string
+	// Address is the address of the operator account held by the remote
+	// signer.
+	Address string
+	// API is the JSON-RPC API of the remote signer, `eth` or `account`.
+	API string
+	// Timeout is the timeout of a single request to the remote signer.
+	Timeout time.Duration
+}
+
+// Enabled tells whether the remote signer is configured.
+func (rsc RemoteSignerConfig) Enabled() bool {
+	return rsc.URL != ""
+}
+
+// Validate checks the remote signer configuration.
+func (rsc RemoteSignerConfig) Validate() error {
+	if !rsc.Enabled() {
+		return nil
+	}
+
+	if !common.IsHexAddress(rsc.Address) {
+		return fmt.Errorf(
+			"invalid remote signer address [%s]",
+			rsc.Address,
+		)
+	}
+
+	switch rsc.API {
+	case "", EthRemoteSignerAPI, AccountRemoteSignerAPI:
+	default:
+		return fmt.Errorf(
+			"unsupported remote signer api [%s]; expected %s or %s",
+			rsc.API,
+			EthRemoteSignerAPI,
+			AccountRemoteSignerAPI,
+		)
+	}
+
+	return nil
+}
+
+// remoteSignerMethods are the names of the JSON-RPC methods of the remote
+// signer API.
+type remoteSignerMethods struct {
+	accounts        string
+	signData        string
+	signTransaction string
+}
+
+var remoteSignerAPIs = map[string]remoteSignerMethods{
+	EthRemoteSignerAPI: {
+		accounts:        "eth_accounts",
+		signData:        "eth_sign",
+		signTransaction: "eth_signTransaction",
+	},
+	AccountRemoteSignerAPI: {
+		accounts:        "account_list",
+		signData:        "account_signData",
+		signTransaction: "account_signTransaction",
+	},
+}
+
+// remoteSigner is the chain signing implementation delegating signing of
+// messages and transactions with the operator key to the remote signer. The
+// operator key never leaves the remote signer; only its public key, recovered
+// from a signature when connecting, is known locally. Signature verification
+// and address conversions do not need the private key and are done locally.
+type remoteSigner struct {
+	// signer is created with the public key only and must not be used for
+	// signing.
+	*signer
+
+	client    *rpc.Client
+	address   common.Address
+	publicKey *ecdsa.PublicKey
+	api       string
+	methods   remoteSignerMethods
+	timeout   time.Duration
+}
+
+func newRemoteSigner(
+	ctx context.Context,
+	config RemoteSignerConfig,
+) (*remoteSigner, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	api := config.API
+	if api == "" {
+		api = EthRemoteSignerAPI
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = DefaultRemoteSignerTimeout
+	}
+
+	client, err := rpc.DialContext(ctx, config.URL)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot connect to remote signer [%s]: [%v]",
+			config.URL,
+			err,
+		)
+	}
+
+	rs := &remoteSigner{
+		client:  client,
+		address: common.HexToAddress(config.Address),
+		api:     api,
+		methods: remoteSignerAPIs[api],
+		timeout: timeout,
+	}
+
+	if err := rs.checkAccount(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	publicKey, err := rs.recoverPublicKey(ctx)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	rs.publicKey = publicKey
+	rs.signer = &signer{
+		ethutil.NewSigner(&ecdsa.PrivateKey{PublicKey: *publicKey}),
+	}
+
+	return rs, nil
+}
+
+// connectRemoteSigner connects to the remote signer and resolves the operator
+// key. If the local key is set, it must belong to the account held by the
+// remote signer; it is used only to secure the network connections. If the
+// local key is not set, the returned key holds only the public key of the
+// operator and must not be used for signing.
+func connectRemoteSigner(
+	ctx context.Context,
+	config RemoteSignerConfig,
+	localKey *keystore.Key,
+) (*remoteSigner, *keystore.Key, error) {
+	rs, err := newRemoteSigner(ctx, config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot connect remote signer: [%v]", err)
+	}
+
+	key := localKey
+	if key == nil {
+		key = rs.accountKey()
+	}
+
+	if rs.address != key.Address {
+		rs.Close()
+		return nil, nil, fmt.Errorf(
+			"remote signer account [%s] does not match operator account [%s]",
+			rs.address.Hex(),
+			key.Address.Hex(),
+		)
+	}
+
+	logger.Infof(
+		"signing messages and transactions with remote signer account [%s]",
+		rs.address.Hex(),
+	)
+
+	return rs, key, nil
+}
+
+// accountKey returns the operator key holding only the public key recovered
+// from the remote signer. The key can be used to build contract bindings as
+// long as their transaction signer is replaced with transactorSigner.
+func (rs *remoteSigner) accountKey() *keystore.Key {
+	return &keystore.Key{
+		Address:    rs.address,
+		PrivateKey: &ecdsa.PrivateKey{PublicKey: *rs.publicKey},
+	}
+}
+
+// checkAccount ensures the remote signer holds the operator account.
+func (rs *remoteSigner) checkAccount(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, rs.timeout)
+	defer cancel()
+
+	var remoteAccounts []common.Address
+	if err := rs.client.CallContext(
+		ctx,
+		&remoteAccounts,
+		rs.methods.accounts,
+	); err != nil {
+		return fmt.Errorf("cannot list remote signer accounts: [%v]", err)
+	}
+
+	for _, account := range remoteAccounts {
+		if account == rs.address {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"remote signer does not hold the account [%s]",
+		rs.address.Hex(),
+	)
+}
+
+// recoverPublicKey recovers the operator public key from the signature of
+// the probe message made by the remote signer.
+func (rs *remoteSigner) recoverPublicKey(
+	ctx context.Context,
+) (*ecdsa.PublicKey, error) {
+	message := []byte(remoteSignerProbeMessage)
+
+	signature, err := rs.signData(ctx, message)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot sign the probe message: [%v]",
+			err,
+		)
+	}
+
+	recoverable := make([]byte, len(signature))
+	copy(recoverable, signature)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+
+	publicKey, err := crypto.SigToPub(
+		accounts.TextHash(message),
+		recoverable,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot recover public key from the probe signature: [%v]",
+			err,
+		)
+	}
+
+	if recovered := crypto.PubkeyToAddress(*publicKey); recovered != rs.address {
+		return nil, fmt.Errorf(
+			"probe message signed by [%s] instead of [%s]",
+			recovered.Hex(),
+			rs.address.Hex(),
+		)
+	}
+
+	return publicKey, nil
+}
+
+// Sign signs the provided message using Ethereum-specific format with the
+// operator key held by the remote signer.
+func (rs *remoteSigner) Sign(message []byte) ([]byte, error) {
+	signature, err := rs.signData(context.Background(), message)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never pass on a signature that would not pass the verification
+	// done by other operators.
+	ok, err := rs.Verify(message, signature)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot verify remote signer signature: [%v]",
+			err,
+		)
+	}
+	if !ok {
+		return nil, fmt.Errorf("remote signer returned invalid signature")
+	}
+
+	return signature, nil
+}
+
+// signData requests the remote signer to sign the message using
+// Ethereum-specific format. The returned signature has v={27, 28}, just like
+// the signature produced by the local signer.
+func (rs *remoteSigner) signData(
+	ctx context.Context,
+	message []byte,
+) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, rs.timeout)
+	defer cancel()
+
+	var args []interface{}
+	if rs.api == AccountRemoteSignerAPI {
+		args = append(args, accounts.MimetypeTextPlain)
+	}
+	args = append(args, rs.address, hexutil.Bytes(message))
+
+	var signature hexutil.Bytes
+	if err := rs.client.CallContext(
+		ctx,
+		&signature,
+		rs.methods.signData,
+		args...,
+	); err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign data: [%v]", err)
+	}
+
+	if len(signature) != ethutil.SignatureSize {
+		return nil, fmt.Errorf(
+			"remote signer returned signature of [%d] bytes; expected [%d]",
+			len(signature),
+			ethutil.SignatureSize,
+		)
+	}
+
+	// Some signers return v={0, 1}.
+	if v := signature[crypto.RecoveryIDOffset]; v < 27 {
+		signature[crypto.RecoveryIDOffset] = v + 27
+	}
+
+	return signature, nil
+}
+
+// remoteTransactionArgs are the arguments of the transaction signing request
+// understood by both Web3Signer and Clef.
+type remoteTransactionArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// signTransaction requests the remote signer to sign the transaction with
+// the operator key. The signed transaction is checked to be the requested
+// one, signed by the operator.
+func (rs *remoteSigner) signTransaction(
+	ctx context.Context,
+	tx *types.Transaction,
+	chainID *big.Int,
+) (*types.Transaction, error) {
+	args := remoteTransactionArgs{
+		From:    rs.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rs.timeout)
+	defer cancel()
+
+	var result json.RawMessage
+	if err := rs.client.CallContext(
+		ctx,
+		&result,
+		rs.methods.signTransaction,
+		args,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"remote signer failed to sign transaction: [%v]",
+			err,
+		)
+	}
+
+	raw, err := parseSignedTransaction(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf(
+			"cannot decode transaction signed by remote signer: [%v]",
+			err,
+		)
+	}
+
+	txSigner := types.LatestSignerForChainID(chainID)
+
+	if txSigner.Hash(signedTx) != txSigner.Hash(tx) {
+		return nil, fmt.Errorf(
+			"remote signer signed a different transaction than requested",
+		)
+	}
+
+	sender, err := types.Sender(txSigner, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot resolve sender of transaction signed by remote signer: [%v]",
+			err,
+		)
+	}
+	if sender != rs.address {
+		return nil, fmt.Errorf(
+			"transaction signed by [%s] instead of [%s]",
+			sender.Hex(),
+			rs.address.Hex(),
+		)
+	}
+
+	return signedTx, nil
+}
+
+// parseSignedTransaction parses the raw signed transaction from the result
+// of the transaction signing request. Web3Signer returns the raw transaction
+// while Clef returns an object holding the raw transaction along with its
+// decoded form.
+func parseSignedTransaction(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+
+	var response struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf(
+			"cannot parse transaction signed by remote signer: [%v]",
+			err,
+		)
+	}
+
+	return response.Raw, nil
+}
+
+// transactorSigner returns the function signing transactions submitted by
+// the contract bindings with the remote signer.
+func (rs *remoteSigner) transactorSigner(chainID *big.Int) bind.SignerFn {
+	return func(
+		address common.Address,
+		tx *types.Transaction,
+	) (*types.Transaction, error) {
+		if address != rs.address {
+			return nil, bind.ErrNotAuthorized
+		}
+
+		return rs.signTransaction(context.Background(), tx, chainID)
+	}
+}
+
+// Close closes the connection with the remote signer.
+func (rs *remoteSigner) Close() {
+	rs.client.Close()
+}
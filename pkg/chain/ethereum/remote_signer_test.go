@@ -0,0 +1,420 @@
+package ethereum
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+// testRemoteSigner is the key-holding part of the stub remote signer.
+type testRemoteSigner struct {
+	key     *ecdsa.PrivateKey
+	chainID *big.Int
+
+	// tamper makes the stub sign a different transaction than requested.
+	tamper bool
+}
+
+func (trs *testRemoteSigner) signData(data hexutil.Bytes) (hexutil.Bytes, error) {
+	signature, err := crypto.Sign(accounts.TextHash(data), trs.key)
+	if err != nil {
+		return nil, err
+	}
+
+	signature[crypto.RecoveryIDOffset] += 27
+
+	return signature, nil
+}
+
+func (trs *testRemoteSigner) signTransaction(
+	args remoteTransactionArgs,
+) (hexutil.Bytes, error) {
+	nonce := uint64(args.Nonce)
+	if trs.tamper {
+		nonce++
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   (*big.Int)(args.ChainID),
+		Nonce:     nonce,
+		GasTipCap: (*big.Int)(args.MaxPriorityFeePerGas),
+		GasFeeCap: (*big.Int)(args.MaxFeePerGas),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     (*big.Int)(args.Value),
+		Data:      args.Data,
+	})
+
+	signedTx, err := types.SignTx(
+		tx,
+		types.LatestSignerForChainID(trs.chainID),
+		trs.key,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return signedTx.MarshalBinary()
+}
+
+// testEthAPI is the Web3Signer-like `eth` API of the stub remote signer.
+type testEthAPI struct {
+	*testRemoteSigner
+}
+
+func (api *testEthAPI) Accounts() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(api.key.PublicKey)}
+}
+
+func (api *testEthAPI) Sign(
+	address common.Address,
+	data hexutil.Bytes,
+) (hexutil.Bytes, error) {
+	return api.signData(data)
+}
+
+func (api *testEthAPI) SignTransaction(
+	args remoteTransactionArgs,
+) (hexutil.Bytes, error) {
+	return api.signTransaction(args)
+}
+
+// testAccountAPI is the Clef-like `account` API of the stub remote signer.
+type testAccountAPI struct {
+	*testRemoteSigner
+}
+
+func (api *testAccountAPI) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(api.key.PublicKey)}
+}
+
+func (api *testAccountAPI) SignData(
+	contentType string,
+	address common.Address,
+	data hexutil.Bytes,
+) (hexutil.Bytes, error) {
+	return api.signData(data)
+}
+
+func (api *testAccountAPI) SignTransaction(
+	args remoteTransactionArgs,
+) (map[string]interface{}, error) {
+	raw, err := api.signTransaction(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"raw": raw}, nil
+}
+
+func TestRemoteSigner(t *testing.T) {
+	for _, api := range []string{EthRemoteSignerAPI, AccountRemoteSignerAPI} {
+		t.Run(api, func(t *testing.T) {
+			key, url := startTestRemoteSigner(t, api, &testRemoteSigner{})
+
+			remoteSigner, err := newRemoteSigner(
+				context.Background(),
+				RemoteSignerConfig{
+					URL:     url,
+					Address: crypto.PubkeyToAddress(key.PublicKey).Hex(),
+					API:     api,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer remoteSigner.Close()
+
+			localSigner := newSigner(newTestKey(key))
+
+			testutils.AssertStringsEqual(
+				t,
+				"address",
+				localSigner.Address().String(),
+				remoteSigner.Address().String(),
+			)
+
+			if !bytes.Equal(localSigner.PublicKey(), remoteSigner.PublicKey()) {
+				t.Errorf("unexpected public key")
+			}
+
+			message := []byte("remote signer message")
+
+			signature, err := remoteSigner.Sign(message)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ok, err := localSigner.Verify(message, signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Errorf("remote signature not verified with the local key")
+			}
+		})
+	}
+}
+
+func TestRemoteSigner_UnknownAccount(t *testing.T) {
+	_, url := startTestRemoteSigner(t, EthRemoteSignerAPI, &testRemoteSigner{})
+
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = newRemoteSigner(
+		context.Background(),
+		RemoteSignerConfig{
+			URL:     url,
+			Address: crypto.PubkeyToAddress(otherKey.PublicKey).Hex(),
+		},
+	)
+	if err == nil {
+		t.Fatal("expected error for account not held by remote signer")
+	}
+}
+
+func TestConnectRemoteSigner_AccountMismatch(t *testing.T) {
+	key, url := startTestRemoteSigner(t, EthRemoteSignerAPI, &testRemoteSigner{})
+
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = connectRemoteSigner(
+		context.Background(),
+		RemoteSignerConfig{
+			URL:     url,
+			Address: crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		},
+		newTestKey(otherKey),
+	)
+	if err == nil {
+		t.Fatal("expected error for remote and local account mismatch")
+	}
+}
+
+func TestConnectRemoteSigner_WithoutLocalKey(t *testing.T) {
+	key, url := startTestRemoteSigner(t, EthRemoteSignerAPI, &testRemoteSigner{})
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	remoteSigner, accountKey, err := connectRemoteSigner(
+		context.Background(),
+		RemoteSignerConfig{
+			URL:     url,
+			Address: address.Hex(),
+		},
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer remoteSigner.Close()
+
+	testutils.AssertStringsEqual(
+		t,
+		"account address",
+		address.Hex(),
+		accountKey.Address.Hex(),
+	)
+
+	if !accountKey.PrivateKey.PublicKey.Equal(&key.PublicKey) {
+		t.Errorf("unexpected account public key")
+	}
+
+	if accountKey.PrivateKey.D != nil {
+		t.Errorf("account key must not hold the private key")
+	}
+}
+
+func TestRemoteSigner_SignTransaction(t *testing.T) {
+	chainID := big.NewInt(1101)
+
+	var tests = map[string]struct {
+		api         string
+		tamper      bool
+		expectError bool
+	}{
+		"eth api": {
+			api: EthRemoteSignerAPI,
+		},
+		"account api": {
+			api: AccountRemoteSignerAPI,
+		},
+		"different transaction signed": {
+			api:         EthRemoteSignerAPI,
+			tamper:      true,
+			expectError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			key, url := startTestRemoteSigner(
+				t,
+				test.api,
+				&testRemoteSigner{chainID: chainID, tamper: test.tamper},
+			)
+			address := crypto.PubkeyToAddress(key.PublicKey)
+
+			remoteSigner, err := newRemoteSigner(
+				context.Background(),
+				RemoteSignerConfig{
+					URL:     url,
+					Address: address.Hex(),
+					API:     test.api,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer remoteSigner.Close()
+
+			to := common.HexToAddress("0x09e303E34F5aC4350caF327aD92d752602f3B061")
+			tx := types.NewTx(&types.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     7,
+				GasTipCap: big.NewInt(2000000000),
+				GasFeeCap: big.NewInt(30000000000),
+				Gas:       100000,
+				To:        &to,
+				Value:     big.NewInt(0),
+				Data:      []byte{0xca, 0xfe},
+			})
+
+			transactorSigner := remoteSigner.transactorSigner(chainID)
+
+			signedTx, err := transactorSigner(address, tx)
+			if test.expectError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sender, err := types.Sender(
+				types.LatestSignerForChainID(chainID),
+				signedTx,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertStringsEqual(
+				t,
+				"sender",
+				address.Hex(),
+				sender.Hex(),
+			)
+
+			if _, err := transactorSigner(to, tx); err == nil {
+				t.Errorf("expected error for other account")
+			}
+		})
+	}
+}
+
+func TestRemoteSignerConfig_Validate(t *testing.T) {
+	var tests = map[string]struct {
+		config      RemoteSignerConfig
+		expectError bool
+	}{
+		"disabled": {
+			config: RemoteSignerConfig{},
+		},
+		"enabled": {
+			config: RemoteSignerConfig{
+				URL:     "http://127.0.0.1:9000",
+				Address: "0x09e303E34F5aC4350caF327aD92d752602f3B061",
+				API:     AccountRemoteSignerAPI,
+			},
+		},
+		"missing address": {
+			config: RemoteSignerConfig{
+				URL: "http://127.0.0.1:9000",
+			},
+			expectError: true,
+		},
+		"unsupported api": {
+			config: RemoteSignerConfig{
+				URL:     "http://127.0.0.1:9000",
+				Address: "0x09e303E34F5aC4350caF327aD92d752602f3B061",
+				API:     "personal",
+			},
+			expectError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.config.Validate()
+			if test.expectError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: %v\nactual:         %v",
+					test.expectError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+// startTestRemoteSigner starts the stub remote signer exposing the given API
+// and holding a newly generated key.
+func startTestRemoteSigner(
+	t *testing.T,
+	api string,
+	remoteSigner *testRemoteSigner,
+) (*ecdsa.PrivateKey, string) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteSigner.key = key
+
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+
+	var service interface{}
+	switch api {
+	case EthRemoteSignerAPI:
+		service = &testEthAPI{remoteSigner}
+	case AccountRemoteSignerAPI:
+		service = &testAccountAPI{remoteSigner}
+	}
+
+	if err := server.RegisterName(api, service); err != nil {
+		t.Fatal(err)
+	}
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	return key, httpServer.URL
+}
+
+func newTestKey(privateKey *ecdsa.PrivateKey) *keystore.Key {
+	return &keystore.Key{
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+}
@@ -50,5 +50,9 @@ func (s *signer) PublicKeyBytesToAddress(publicKey []byte) chain.Address {
 }
 
 func (bc *baseChain) Signing() chain.Signing {
+	if bc.remoteSigner != nil {
+		return bc.remoteSigner
+	}
+
 	return newSigner(bc.key)
 }
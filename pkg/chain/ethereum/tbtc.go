@@ -204,6 +204,14 @@ func newTbtcChain(
 		)
 	}
 
+	baseChain.useRemoteSigner(
+		bridge,
+		maintainerProxy,
+		walletRegistry,
+		sortitionPool,
+		walletProposalValidator,
+	)
+
 	redemptionWatchtowerAddress, err := bridge.GetRedemptionWatchtower()
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -236,6 +244,8 @@ func newTbtcChain(
 				err,
 			)
 		}
+
+		baseChain.useRemoteSigner(redemptionWatchtower)
 	}
 
 	return &TbtcChain{
@@ -354,6 +364,38 @@ func (tc *TbtcChain) EligibleStake(stakingProvider chain.Address) (*big.Int, err
 	return eligibleStake, nil
 }
 
+// OperatorEligibleStake returns the current eligible stake of the staking
+// provider associated with the given operator. If the operator is not
+// associated with any staking provider, zero is returned.
+func (tc *TbtcChain) OperatorEligibleStake(
+	operatorPublicKey *operator.PublicKey,
+) (*big.Int, error) {
+	operatorAddress, err := operatorPublicKeyToChainAddress(operatorPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot convert from operator key to chain address: [%v]",
+			err,
+		)
+	}
+
+	stakingProvider, err := tc.walletRegistry.OperatorToStakingProvider(
+		operatorAddress,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to map operator [%v] to a staking provider: [%v]",
+			operatorAddress,
+			err,
+		)
+	}
+
+	if (stakingProvider == common.Address{}) {
+		return big.NewInt(0), nil
+	}
+
+	return tc.EligibleStake(chain.Address(stakingProvider.Hex()))
+}
+
 // IsPoolLocked returns true if the sortition pool is locked and no state
 // changes are allowed.
 func (tc *TbtcChain) IsPoolLocked() (bool, error) {
@@ -1338,6 +1380,53 @@ func (tc *TbtcChain) GetDepositRequest(
 		return nil, false, nil
 	}
 
+	request := convertDepositRequest(chainRequest)
+
+	// If the request was swept on-chain, there is a guarantee that no
+	// further changes will occur regarding its parameters.
+	// Such a request can be cached.
+	if isSwept := request.SweptAt.Unix() != 0; isSwept {
+		tc.sweptDepositsCache.Add(depositCacheKey, request)
+	}
+
+	return request, true, nil
+}
+
+func (tc *TbtcChain) GetDepositRequestAtBlock(
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+	blockNumber uint64,
+) (*tbtc.DepositChainRequest, bool, error) {
+	depositKey := buildDepositKey(fundingTxHash, fundingOutputIndex)
+
+	// The swept deposits cache is not used as the deposit may have been
+	// swept after the given block.
+	chainRequest, err := tc.bridge.DepositsAtBlock(
+		depositKey,
+		new(big.Int).SetUint64(blockNumber),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot get deposit request for key [0x%x] at block [%v]: [%v]",
+			depositKey.Text(16),
+			blockNumber,
+			err,
+		)
+	}
+
+	// Deposit not found.
+	if chainRequest.RevealedAt == 0 {
+		return nil, false, nil
+	}
+
+	return convertDepositRequest(chainRequest), true, nil
+}
+
+// convertDepositRequest converts the deposit request returned by the Bridge
+// contract.
+func convertDepositRequest(
+	chainRequest tbtcabi.DepositDepositRequest,
+) *tbtc.DepositChainRequest {
 	var vault *chain.Address
 	if chainRequest.Vault != [20]byte{} {
 		v := chain.Address(chainRequest.Vault.Hex())
@@ -1349,7 +1438,7 @@ func (tc *TbtcChain) GetDepositRequest(
 		extraData = &chainRequest.ExtraData
 	}
 
-	request := &tbtc.DepositChainRequest{
+	return &tbtc.DepositChainRequest{
 		Depositor:   chain.Address(chainRequest.Depositor.Hex()),
 		Amount:      chainRequest.Amount,
 		RevealedAt:  time.Unix(int64(chainRequest.RevealedAt), 0),
@@ -1358,15 +1447,6 @@ func (tc *TbtcChain) GetDepositRequest(
 		SweptAt:     time.Unix(int64(chainRequest.SweptAt), 0),
 		ExtraData:   extraData,
 	}
-
-	// If the request was swept on-chain, there is a guarantee that no
-	// further changes will occur regarding its parameters.
-	// Such a request can be cached.
-	if isSwept := request.SweptAt.Unix() != 0; isSwept {
-		tc.sweptDepositsCache.Add(depositCacheKey, request)
-	}
-
-	return request, true, nil
 }
 
 func (tc *TbtcChain) PastNewWalletRegisteredEvents(
@@ -1448,6 +1528,46 @@ func (tc *TbtcChain) IsWalletRegistered(EcdsaWalletID [32]byte) (bool, error) {
 	return isWalletRegistered, nil
 }
 
+func (tc *TbtcChain) IsWalletSigningGroup(
+	EcdsaWalletID [32]byte,
+	signingGroupOperators chain.Addresses,
+) (bool, error) {
+	wallet, err := tc.walletRegistry.GetWallet(EcdsaWalletID)
+	if err != nil {
+		return false, fmt.Errorf(
+			"cannot get wallet with ECDSA ID [0x%x]: [%v]",
+			EcdsaWalletID,
+			err,
+		)
+	}
+
+	operatorsIDs := make(chain.OperatorIDs, len(signingGroupOperators))
+	operatorsIDsCache := make(map[chain.Address]chain.OperatorID)
+	for i, operator := range signingGroupOperators {
+		operatorID, ok := operatorsIDsCache[operator]
+		if !ok {
+			operatorID, err = tc.GetOperatorID(operator)
+			if err != nil {
+				return false, fmt.Errorf(
+					"cannot get operator ID of [%v]: [%v]",
+					operator,
+					err,
+				)
+			}
+			operatorsIDsCache[operator] = operatorID
+		}
+
+		operatorsIDs[i] = operatorID
+	}
+
+	membersIDsHash, err := computeOperatorsIDsHash(operatorsIDs)
+	if err != nil {
+		return false, fmt.Errorf("cannot compute members hash: [%v]", err)
+	}
+
+	return wallet.MembersIdsHash == membersIDsHash, nil
+}
+
 func (tc *TbtcChain) GetWallet(
 	walletPublicKeyHash [20]byte,
 ) (*tbtc.WalletChainData, error) {
@@ -1460,11 +1580,39 @@ func (tc *TbtcChain) GetWallet(
 		)
 	}
 
+	return convertWallet(walletPublicKeyHash, wallet)
+}
+
+func (tc *TbtcChain) GetWalletAtBlock(
+	walletPublicKeyHash [20]byte,
+	blockNumber uint64,
+) (*tbtc.WalletChainData, error) {
+	wallet, err := tc.bridge.WalletsAtBlock(
+		walletPublicKeyHash,
+		new(big.Int).SetUint64(blockNumber),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get wallet for public key hash [0x%x] at block [%v]: [%v]",
+			walletPublicKeyHash,
+			blockNumber,
+			err,
+		)
+	}
+
+	return convertWallet(walletPublicKeyHash, wallet)
+}
+
+// convertWallet converts the wallet returned by the Bridge contract.
+func convertWallet(
+	walletPublicKeyHash [20]byte,
+	wallet tbtcabi.WalletsWallet,
+) (*tbtc.WalletChainData, error) {
 	// Wallet not found.
 	if wallet.CreatedAt == 0 {
 		return nil, fmt.Errorf(
 			"no wallet for public key hash [0x%x]",
-			wallet,
+			walletPublicKeyHash,
 		)
 	}
 
@@ -2411,3 +2559,7 @@ func (tc *TbtcChain) GetRedemptionDelay(
 func (tc *TbtcChain) GetDepositMinAge() (uint32, error) {
 	return tc.walletProposalValidator.DEPOSITMINAGE()
 }
+
+func (tc *TbtcChain) GetDepositRefundSafetyMargin() (uint32, error) {
+	return tc.walletProposalValidator.DEPOSITREFUNDSAFETYMARGIN()
+}
@@ -12656,3 +12656,11 @@ func (b *Bridge) PastWalletTerminatedEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (b *Bridge) SetTransactorSigner(signer bind.SignerFn) {
+	b.transactorOptions.Signer = signer
+}
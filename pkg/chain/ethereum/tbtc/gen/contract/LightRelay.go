@@ -3049,3 +3049,11 @@ func (lr *LightRelay) PastSubmitterDeauthorizedEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (lr *LightRelay) SetTransactorSigner(signer bind.SignerFn) {
+	lr.transactorOptions.Signer = signer
+}
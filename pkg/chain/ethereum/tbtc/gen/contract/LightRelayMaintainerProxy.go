@@ -2500,3 +2500,11 @@ func (lrmp *LightRelayMaintainerProxy) PastRetargetGasOffsetUpdatedEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (lrmp *LightRelayMaintainerProxy) SetTransactorSigner(signer bind.SignerFn) {
+	lrmp.transactorOptions.Signer = signer
+}
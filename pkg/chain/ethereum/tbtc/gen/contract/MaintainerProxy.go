@@ -5385,3 +5385,11 @@ func (mp *MaintainerProxy) PastWalletMaintainerUnauthorizedEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (mp *MaintainerProxy) SetTransactorSigner(signer bind.SignerFn) {
+	mp.transactorOptions.Signer = signer
+}
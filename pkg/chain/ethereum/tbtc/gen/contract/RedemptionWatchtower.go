@@ -4897,3 +4897,11 @@ func (rw *RedemptionWatchtower) PastWatchtowerParametersUpdatedEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (rw *RedemptionWatchtower) SetTransactorSigner(signer bind.SignerFn) {
+	rw.transactorOptions.Signer = signer
+}
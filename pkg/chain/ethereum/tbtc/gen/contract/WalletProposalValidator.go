@@ -586,3 +586,11 @@ func (wpv *WalletProposalValidator) ValidateRedemptionProposalAtBlock(
 }
 
 // ------ Events -------
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (wpv *WalletProposalValidator) SetTransactorSigner(signer bind.SignerFn) {
+	wpv.transactorOptions.Signer = signer
+}
@@ -10371,3 +10371,11 @@ func (ts *TokenStaking) PastUnstakedEvents(
 
 	return events, nil
 }
+
+// SetTransactorSigner sets the function signing transactions submitted with
+// the contract handle, replacing the function signing them with the account
+// key passed to the constructor. It must be called before any transaction is
+// submitted.
+func (ts *TokenStaking) SetTransactorSigner(signer bind.SignerFn) {
+	ts.transactorOptions.Signer = signer
+}
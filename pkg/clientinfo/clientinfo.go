@@ -2,6 +2,7 @@ package clientinfo
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-log"
@@ -25,6 +26,9 @@ type Registry struct {
 	*clientinfo.Registry
 
 	ctx context.Context
+
+	observationsMutex sync.Mutex
+	observations      map[string]*observation
 }
 
 // Initialize set up the client info registry and enables metrics and
@@ -37,7 +41,11 @@ func Initialize(
 		return nil, false
 	}
 
-	registry := &Registry{clientinfo.NewRegistry(), ctx}
+	registry := &Registry{
+		Registry:     clientinfo.NewRegistry(),
+		ctx:          ctx,
+		observations: make(map[string]*observation),
+	}
 
 	registry.EnableServer(port)
 
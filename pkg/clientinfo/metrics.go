@@ -1,6 +1,7 @@
 package clientinfo
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -51,6 +52,7 @@ func (r *Registry) ObserveConnectedPeersCount(
 		ConnectedPeersCountMetricName,
 		input,
 		validateTick(tick, DefaultNetworkMetricsTick),
+		networkMetricsTickGroup,
 	)
 }
 
@@ -77,6 +79,7 @@ func (r *Registry) ObserveConnectedBootstrapCount(
 		ConnectedBootstrapCountMetricName,
 		input,
 		validateTick(tick, DefaultNetworkMetricsTick),
+		networkMetricsTickGroup,
 	)
 }
 
@@ -99,6 +102,7 @@ func (r *Registry) ObserveEthConnectivity(
 		EthConnectivityMetricName,
 		input,
 		validateTick(tick, DefaultEthereumMetricsTick),
+		ethereumMetricsTickGroup,
 	)
 }
 
@@ -121,6 +125,7 @@ func (r *Registry) ObserveBtcConnectivity(
 		BtcConnectivityMetricName,
 		input,
 		validateTick(tick, DefaultBitcoinMetricsTick),
+		bitcoinMetricsTickGroup,
 	)
 }
 
@@ -135,6 +140,7 @@ func (r *Registry) ObserveApplicationSource(
 			fmt.Sprintf("%s_%s", application, k),
 			v,
 			ApplicationMetricsTick,
+			applicationMetricsTickGroup,
 		)
 	}
 }
@@ -152,10 +158,30 @@ func (r *Registry) RegisterMetricClientInfo(version string) {
 	}
 }
 
+// tickGroup determines which configuration property controls the observation
+// tick of the given metric.
+type tickGroup int
+
+const (
+	applicationMetricsTickGroup tickGroup = iota
+	networkMetricsTickGroup
+	ethereumMetricsTickGroup
+	bitcoinMetricsTickGroup
+)
+
+// observation is a running metric observation process.
+type observation struct {
+	observer *clientinfo.MetricObserver
+	group    tickGroup
+	tick     time.Duration
+	cancel   context.CancelFunc
+}
+
 func (r *Registry) observe(
 	name string,
 	input Source,
 	tick time.Duration,
+	group tickGroup,
 ) {
 	observer, err := r.NewMetricGaugeObserver(name, clientinfo.MetricObserverInput(input))
 	if err != nil {
@@ -163,11 +189,60 @@ func (r *Registry) observe(
 		return
 	}
 
-	observer.Observe(r.ctx, tick)
+	ctx, cancel := context.WithCancel(r.ctx)
+	observer.Observe(ctx, tick)
+
+	r.observationsMutex.Lock()
+	r.observations[name] = &observation{
+		observer: observer,
+		group:    group,
+		tick:     tick,
+		cancel:   cancel,
+	}
+	r.observationsMutex.Unlock()
 
 	logger.Infof("observing %s with [%s] tick", name, tick)
 }
 
+// UpdateTicks restarts the running metric observation processes whose
+// observation tick changed in the given config. Metrics that are not observed
+// yet will use the ticks passed when their observation is triggered.
+func (r *Registry) UpdateTicks(config Config) {
+	ticks := map[tickGroup]time.Duration{
+		networkMetricsTickGroup: validateTick(
+			config.NetworkMetricsTick,
+			DefaultNetworkMetricsTick,
+		),
+		ethereumMetricsTickGroup: validateTick(
+			config.EthereumMetricsTick,
+			DefaultEthereumMetricsTick,
+		),
+		bitcoinMetricsTickGroup: validateTick(
+			config.BitcoinMetricsTick,
+			DefaultBitcoinMetricsTick,
+		),
+	}
+
+	r.observationsMutex.Lock()
+	defer r.observationsMutex.Unlock()
+
+	for name, observation := range r.observations {
+		tick, ok := ticks[observation.group]
+		if !ok || tick == observation.tick {
+			continue
+		}
+
+		observation.cancel()
+
+		ctx, cancel := context.WithCancel(r.ctx)
+		observation.observer.Observe(ctx, tick)
+		observation.tick = tick
+		observation.cancel = cancel
+
+		logger.Infof("observing %s with [%s] tick", name, tick)
+	}
+}
+
 func validateTick(tick time.Duration, defaultTick time.Duration) time.Duration {
 	if tick > 0 {
 		return tick
@@ -0,0 +1,195 @@
+package firewall
+
+import (
+	"fmt"
+	"math/big"
+	gonet "net"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// DefaultConnectionRateLimitPeriod is the default period the connection
+// rate limit is applied over.
+const DefaultConnectionRateLimitPeriod = time.Minute
+
+// Config stores configuration of the firewall policies applied on top of
+// the default policy.
+type Config struct {
+	// DeniedOperators are operators that are not allowed to connect. Each
+	// entry is either a compressed operator public key in hex or an operator
+	// chain address.
+	DeniedOperators []string
+
+	// DeniedAddresses are IP addresses or CIDR ranges connections are not
+	// allowed from.
+	DeniedAddresses []string
+
+	// ConnectionRateLimit is the maximum number of new connections a single
+	// remote peer can establish within ConnectionRateLimitPeriod. Zero
+	// disables the limit.
+	ConnectionRateLimit int
+
+	// ConnectionRateLimitPeriod is the period the connection rate limit is
+	// applied over.
+	ConnectionRateLimitPeriod time.Duration
+
+	// MinimumStake is the minimum eligible stake of the remote peer's staking
+	// provider. Nil disables the check.
+	MinimumStake *big.Int
+}
+
+// Validate checks whether the config defines valid firewall policies.
+func (c Config) Validate() error {
+	if _, err := DenyListPolicy(
+		c.DeniedOperators,
+		c.DeniedAddresses,
+		nil,
+	); err != nil {
+		return fmt.Errorf("invalid deny list: [%w]", err)
+	}
+
+	if c.ConnectionRateLimit < 0 {
+		return fmt.Errorf(
+			"connection rate limit must not be negative; got [%v]",
+			c.ConnectionRateLimit,
+		)
+	}
+
+	if c.MinimumStake != nil && c.MinimumStake.Sign() < 0 {
+		return fmt.Errorf(
+			"minimum stake must not be negative; got [%v]",
+			c.MinimumStake,
+		)
+	}
+
+	return nil
+}
+
+// ConfigurablePolicy is a firewall policy composed of the default policy and
+// the policies defined by the firewall Config. The configured policies can
+// be replaced at runtime without restarting the client.
+type ConfigurablePolicy struct {
+	defaultPolicy    net.Firewall
+	signing          chain.Signing
+	stakeApplication StakeApplication
+	allowList        *AllowList
+
+	mutex  sync.RWMutex
+	policy net.ConnectionFirewall
+}
+
+// NewConfigurablePolicy creates a new firewall policy validating remote peers
+// against the given default policy and the policies defined by the given
+// config. The signing is used to resolve chain addresses of denied operators
+// and the stake application is used to enforce the minimum stake. Peers on
+// the allowlist bypass the connection rate limit and the minimum stake
+// policies but not the deny lists.
+func NewConfigurablePolicy(
+	defaultPolicy net.Firewall,
+	config Config,
+	signing chain.Signing,
+	stakeApplication StakeApplication,
+	allowList *AllowList,
+) (*ConfigurablePolicy, error) {
+	cp := &ConfigurablePolicy{
+		defaultPolicy:    defaultPolicy,
+		signing:          signing,
+		stakeApplication: stakeApplication,
+		allowList:        allowList,
+	}
+
+	if err := cp.Reload(config); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// Reload replaces the configured policies with the ones defined by the
+// given config. The config is validated first and the current policies are
+// left intact if it is invalid. Connection rate limit counters are reset.
+func (cp *ConfigurablePolicy) Reload(config Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	denyListPolicy, err := DenyListPolicy(
+		config.DeniedOperators,
+		config.DeniedAddresses,
+		cp.signing,
+	)
+	if err != nil {
+		return fmt.Errorf("invalid deny list: [%w]", err)
+	}
+
+	policies := []net.Firewall{denyListPolicy}
+
+	if config.ConnectionRateLimit > 0 {
+		period := config.ConnectionRateLimitPeriod
+		if period <= 0 {
+			period = DefaultConnectionRateLimitPeriod
+		}
+
+		policies = append(
+			policies,
+			ConnectionRateLimitPolicy(
+				config.ConnectionRateLimit,
+				period,
+				cp.allowList,
+			),
+		)
+	}
+
+	policies = append(policies, cp.defaultPolicy)
+
+	if config.MinimumStake != nil && config.MinimumStake.Sign() > 0 {
+		if cp.stakeApplication == nil {
+			return fmt.Errorf("minimum stake policy is not supported")
+		}
+
+		policies = append(
+			policies,
+			MinimumStakePolicy(
+				cp.stakeApplication,
+				config.MinimumStake,
+				cp.allowList,
+			),
+		)
+	}
+
+	cp.mutex.Lock()
+	cp.policy = AllOf(policies...)
+	cp.mutex.Unlock()
+
+	return nil
+}
+
+// Validate checks whether the given operator meets the conditions of all
+// the currently configured policies.
+func (cp *ConfigurablePolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	cp.mutex.RLock()
+	policy := cp.policy
+	cp.mutex.RUnlock()
+
+	return policy.Validate(remotePeerPublicKey)
+}
+
+// ValidateConnection checks whether the new connection with the given
+// operator coming from the given address meets the conditions of all the
+// currently configured policies.
+func (cp *ConfigurablePolicy) ValidateConnection(
+	remotePeerPublicKey *operator.PublicKey,
+	remoteAddress gonet.Addr,
+) error {
+	cp.mutex.RLock()
+	policy := cp.policy
+	cp.mutex.RUnlock()
+
+	return policy.ValidateConnection(remotePeerPublicKey, remoteAddress)
+}
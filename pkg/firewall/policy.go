@@ -0,0 +1,386 @@
+package firewall
+
+import (
+	"fmt"
+	"math/big"
+	gonet "net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/cache"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/net"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+// AllOf returns a firewall policy composed of the given policies. A remote
+// peer is validated only if it is validated by all the given policies.
+// Policies are evaluated in the given order and the first error is returned.
+// Connection-level checks are delegated to policies implementing
+// net.ConnectionFirewall.
+func AllOf(policies ...net.Firewall) net.ConnectionFirewall {
+	return &allOfPolicy{policies}
+}
+
+type allOfPolicy struct {
+	policies []net.Firewall
+}
+
+func (aop *allOfPolicy) Validate(remotePeerPublicKey *operator.PublicKey) error {
+	for _, policy := range aop.policies {
+		if err := policy.Validate(remotePeerPublicKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (aop *allOfPolicy) ValidateConnection(
+	remotePeerPublicKey *operator.PublicKey,
+	remoteAddress gonet.Addr,
+) error {
+	for _, policy := range aop.policies {
+		var err error
+		if connectionPolicy, ok := policy.(net.ConnectionFirewall); ok {
+			err = connectionPolicy.ValidateConnection(
+				remotePeerPublicKey,
+				remoteAddress,
+			)
+		} else {
+			err = policy.Validate(remotePeerPublicKey)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DenyListPolicy returns a firewall policy rejecting remote peers whose
+// operator is on the given deny list and connections coming from denied
+// network addresses. Denied operators can be given either as compressed
+// operator public keys in hex or as operator chain addresses. Chain addresses
+// are matched only if the signing is provided. Denied addresses can be given
+// either as single IP addresses or as CIDR ranges.
+func DenyListPolicy(
+	deniedOperators []string,
+	deniedAddresses []string,
+	signing chain.Signing,
+) (net.ConnectionFirewall, error) {
+	policy := &denyListPolicy{
+		deniedOperators: make(map[string]bool),
+		deniedNetworks:  make([]*gonet.IPNet, 0, len(deniedAddresses)),
+		signing:         signing,
+	}
+
+	for _, deniedOperator := range deniedOperators {
+		deniedOperator = normalizeHex(deniedOperator)
+
+		if deniedOperator == "" {
+			continue
+		}
+
+		policy.deniedOperators[deniedOperator] = true
+	}
+
+	for _, deniedAddress := range deniedAddresses {
+		deniedAddress = strings.TrimSpace(deniedAddress)
+
+		if deniedAddress == "" {
+			continue
+		}
+
+		deniedNetwork, err := parseNetwork(deniedAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.deniedNetworks = append(policy.deniedNetworks, deniedNetwork)
+	}
+
+	return policy, nil
+}
+
+// normalizeHex trims whitespace, the 0x prefix and lowercases the given hex
+// string so that it can be compared with other hex strings.
+func normalizeHex(value string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "0x")
+}
+
+// parseNetwork parses the given IP address or CIDR range. A single IP
+// address is turned into a network containing only that address.
+func parseNetwork(address string) (*gonet.IPNet, error) {
+	if strings.Contains(address, "/") {
+		_, network, err := gonet.ParseCIDR(address)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid denied CIDR range [%v]: [%v]",
+				address,
+				err,
+			)
+		}
+
+		return network, nil
+	}
+
+	ip := gonet.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid denied IP address [%v]", address)
+	}
+
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return &gonet.IPNet{IP: ipv4, Mask: gonet.CIDRMask(32, 32)}, nil
+	}
+
+	return &gonet.IPNet{IP: ip, Mask: gonet.CIDRMask(128, 128)}, nil
+}
+
+type denyListPolicy struct {
+	// deniedOperators holds normalized operator public keys and chain
+	// addresses. Both are matched against the remote peer.
+	deniedOperators map[string]bool
+	deniedNetworks  []*gonet.IPNet
+	signing         chain.Signing
+}
+
+func (dlp *denyListPolicy) Validate(remotePeerPublicKey *operator.PublicKey) error {
+	if len(dlp.deniedOperators) == 0 {
+		return nil
+	}
+
+	if dlp.deniedOperators[remotePeerPublicKey.String()] {
+		return fmt.Errorf(
+			"remote peer operator [%v] is denied",
+			remotePeerPublicKey,
+		)
+	}
+
+	if dlp.signing != nil {
+		chainAddress, err := dlp.signing.PublicKeyToAddress(remotePeerPublicKey)
+		if err != nil {
+			return fmt.Errorf(
+				"cannot convert remote peer operator public key to "+
+					"chain address: [%v]",
+				err,
+			)
+		}
+
+		if dlp.deniedOperators[normalizeHex(chainAddress.String())] {
+			return fmt.Errorf(
+				"remote peer operator [%v] is denied",
+				chainAddress,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (dlp *denyListPolicy) ValidateConnection(
+	remotePeerPublicKey *operator.PublicKey,
+	remoteAddress gonet.Addr,
+) error {
+	if ip := addressIP(remoteAddress); ip != nil {
+		for _, deniedNetwork := range dlp.deniedNetworks {
+			if deniedNetwork.Contains(ip) {
+				return fmt.Errorf(
+					"remote peer address [%v] is denied by [%v]",
+					ip,
+					deniedNetwork,
+				)
+			}
+		}
+	}
+
+	return dlp.Validate(remotePeerPublicKey)
+}
+
+// addressIP extracts the IP from the given network address. Returns nil if
+// the address does not carry an IP.
+func addressIP(address gonet.Addr) gonet.IP {
+	switch a := address.(type) {
+	case *gonet.TCPAddr:
+		return a.IP
+	case *gonet.UDPAddr:
+		return a.IP
+	case *gonet.IPAddr:
+		return a.IP
+	}
+
+	if address == nil {
+		return nil
+	}
+
+	host, _, err := gonet.SplitHostPort(address.String())
+	if err != nil {
+		return gonet.ParseIP(address.String())
+	}
+
+	return gonet.ParseIP(host)
+}
+
+// ConnectionRateLimitPolicy returns a firewall policy limiting the number of
+// new connections a single remote peer can establish within the given period.
+// Peers on the allowlist are not limited. The policy does not affect periodic
+// validation of already connected peers.
+func ConnectionRateLimitPolicy(
+	limit int,
+	period time.Duration,
+	allowList *AllowList,
+) net.ConnectionFirewall {
+	return &connectionRateLimitPolicy{
+		limit:       limit,
+		period:      period,
+		allowList:   allowList,
+		connections: make(map[string][]time.Time),
+		now:         time.Now,
+	}
+}
+
+type connectionRateLimitPolicy struct {
+	limit     int
+	period    time.Duration
+	allowList *AllowList
+
+	mutex       sync.Mutex
+	connections map[string][]time.Time
+	now         func() time.Time
+}
+
+func (crlp *connectionRateLimitPolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	return nil
+}
+
+func (crlp *connectionRateLimitPolicy) ValidateConnection(
+	remotePeerPublicKey *operator.PublicKey,
+	remoteAddress gonet.Addr,
+) error {
+	if crlp.allowList.Contains(remotePeerPublicKey) {
+		return nil
+	}
+
+	crlp.mutex.Lock()
+	defer crlp.mutex.Unlock()
+
+	now := crlp.now()
+	windowStart := now.Add(-crlp.period)
+
+	// Forget connections of all peers that are outside of the window to
+	// not accumulate entries of peers that are no longer connecting.
+	for key, timestamps := range crlp.connections {
+		recent := timestamps[:0]
+		for _, timestamp := range timestamps {
+			if timestamp.After(windowStart) {
+				recent = append(recent, timestamp)
+			}
+		}
+
+		if len(recent) == 0 {
+			delete(crlp.connections, key)
+		} else {
+			crlp.connections[key] = recent
+		}
+	}
+
+	remotePeerPublicKeyHex := remotePeerPublicKey.String()
+
+	if len(crlp.connections[remotePeerPublicKeyHex]) >= crlp.limit {
+		return fmt.Errorf(
+			"remote peer exceeded the limit of [%v] connections per [%v]",
+			crlp.limit,
+			crlp.period,
+		)
+	}
+
+	crlp.connections[remotePeerPublicKeyHex] = append(
+		crlp.connections[remotePeerPublicKeyHex],
+		now,
+	)
+
+	return nil
+}
+
+// StakeApplication defines functionalities for operator stake verification
+// in the firewall.
+type StakeApplication interface {
+	// OperatorEligibleStake returns the current eligible stake of the
+	// staking provider associated with the given operator. Returns zero if
+	// the operator is not associated with any staking provider.
+	OperatorEligibleStake(operatorPublicKey *operator.PublicKey) (*big.Int, error)
+}
+
+// MinimumStakePolicy returns a firewall policy rejecting remote peers whose
+// eligible stake is below the given minimum. Peers on the allowlist are not
+// checked. Due to performance reasons, the results of validations are stored
+// in a cache for a certain amount of time.
+func MinimumStakePolicy(
+	application StakeApplication,
+	minimumStake *big.Int,
+	allowList *AllowList,
+) net.Firewall {
+	return &minimumStakePolicy{
+		application:         application,
+		minimumStake:        minimumStake,
+		allowList:           allowList,
+		positiveResultCache: cache.NewTimeCache(PositiveIsRecognizedCachePeriod),
+		negativeResultCache: cache.NewTimeCache(NegativeIsRecognizedCachePeriod),
+	}
+}
+
+type minimumStakePolicy struct {
+	application         StakeApplication
+	minimumStake        *big.Int
+	allowList           *AllowList
+	positiveResultCache *cache.TimeCache
+	negativeResultCache *cache.TimeCache
+}
+
+func (msp *minimumStakePolicy) Validate(
+	remotePeerPublicKey *operator.PublicKey,
+) error {
+	if msp.allowList.Contains(remotePeerPublicKey) {
+		return nil
+	}
+
+	msp.positiveResultCache.Sweep()
+	msp.negativeResultCache.Sweep()
+
+	remotePeerPublicKeyHex := remotePeerPublicKey.String()
+
+	if msp.positiveResultCache.Has(remotePeerPublicKeyHex) {
+		return nil
+	}
+
+	if msp.negativeResultCache.Has(remotePeerPublicKeyHex) {
+		return errInsufficientStake
+	}
+
+	eligibleStake, err := msp.application.OperatorEligibleStake(
+		remotePeerPublicKey,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"could not get eligible stake of remote peer: [%w]",
+			err,
+		)
+	}
+
+	if eligibleStake.Cmp(msp.minimumStake) < 0 {
+		msp.negativeResultCache.Add(remotePeerPublicKeyHex)
+		return errInsufficientStake
+	}
+
+	msp.positiveResultCache.Add(remotePeerPublicKeyHex)
+
+	return nil
+}
+
+var errInsufficientStake = fmt.Errorf(
+	"remote peer eligible stake is below the required minimum",
+)
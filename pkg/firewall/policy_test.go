@@ -0,0 +1,312 @@
+package firewall
+
+import (
+	"math/big"
+	gonet "net"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/cache"
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/chain/local_v1"
+	"github.com/keep-network/keep-core/pkg/operator"
+)
+
+func TestDenyListPolicy_DeniedOperatorPublicKey(t *testing.T) {
+	deniedPublicKey := generatePublicKey(t)
+	otherPublicKey := generatePublicKey(t)
+
+	policy, err := DenyListPolicy(
+		[]string{deniedPublicKey.String()},
+		[]string{},
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.Validate(deniedPublicKey); err == nil {
+		t.Errorf("expected denied operator to be rejected")
+	}
+
+	if err := policy.Validate(otherPublicKey); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
+
+func TestDenyListPolicy_DeniedOperatorChainAddress(t *testing.T) {
+	operatorPrivateKey, deniedPublicKey, err := operator.GenerateKeyPair(
+		local_v1.DefaultCurve,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPublicKey := generatePublicKey(t)
+
+	signing := local_v1.NewSigner(operatorPrivateKey)
+
+	deniedAddress, err := signing.PublicKeyToAddress(deniedPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := DenyListPolicy(
+		[]string{deniedAddress.String()},
+		[]string{},
+		signing,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.Validate(deniedPublicKey); err == nil {
+		t.Errorf("expected denied operator to be rejected")
+	}
+
+	if err := policy.Validate(otherPublicKey); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+}
+
+func TestDenyListPolicy_DeniedAddresses(t *testing.T) {
+	peerPublicKey := generatePublicKey(t)
+
+	policy, err := DenyListPolicy(
+		[]string{},
+		[]string{"192.168.10.7", "10.20.0.0/16", "2001:db8::/32"},
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		remoteAddress gonet.Addr
+		expectDenied  bool
+	}{
+		"denied IP address": {
+			remoteAddress: &gonet.TCPAddr{IP: gonet.ParseIP("192.168.10.7"), Port: 3919},
+			expectDenied:  true,
+		},
+		"IP address from denied CIDR range": {
+			remoteAddress: &gonet.TCPAddr{IP: gonet.ParseIP("10.20.3.4"), Port: 3919},
+			expectDenied:  true,
+		},
+		"IPv6 address from denied CIDR range": {
+			remoteAddress: &gonet.TCPAddr{IP: gonet.ParseIP("2001:db8::1"), Port: 3919},
+			expectDenied:  true,
+		},
+		"allowed IP address": {
+			remoteAddress: &gonet.TCPAddr{IP: gonet.ParseIP("192.168.10.8"), Port: 3919},
+			expectDenied:  false,
+		},
+		"address without IP": {
+			remoteAddress: &gonet.UnixAddr{Name: "pipe", Net: "unix"},
+			expectDenied:  false,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := policy.ValidateConnection(peerPublicKey, test.remoteAddress)
+			if test.expectDenied && err == nil {
+				t.Errorf("expected connection to be rejected")
+			}
+			if !test.expectDenied && err != nil {
+				t.Errorf("unexpected error: [%v]", err)
+			}
+		})
+	}
+}
+
+func TestDenyListPolicy_InvalidAddress(t *testing.T) {
+	_, err := DenyListPolicy([]string{}, []string{"10.20.0.0/99"}, nil)
+	if err == nil {
+		t.Errorf("expected error for invalid CIDR range")
+	}
+
+	_, err = DenyListPolicy([]string{}, []string{"not-an-ip"}, nil)
+	if err == nil {
+		t.Errorf("expected error for invalid IP address")
+	}
+}
+
+func TestConnectionRateLimitPolicy(t *testing.T) {
+	peerPublicKey := generatePublicKey(t)
+	otherPublicKey := generatePublicKey(t)
+	allowlistedPublicKey := generatePublicKey(t)
+
+	policy := ConnectionRateLimitPolicy(
+		2,
+		time.Minute,
+		NewAllowList([]*operator.PublicKey{allowlistedPublicKey}),
+	).(*connectionRateLimitPolicy)
+
+	now := time.Unix(1700000000, 0)
+	policy.now = func() time.Time { return now }
+
+	validate := func(publicKey *operator.PublicKey) error {
+		return policy.ValidateConnection(publicKey, nil)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := validate(peerPublicKey); err != nil {
+			t.Fatalf("unexpected error on connection [%v]: [%v]", i, err)
+		}
+	}
+
+	if err := validate(peerPublicKey); err == nil {
+		t.Errorf("expected connection over the limit to be rejected")
+	}
+
+	if err := validate(otherPublicKey); err != nil {
+		t.Errorf("unexpected error for other peer: [%v]", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := validate(allowlistedPublicKey); err != nil {
+			t.Errorf("unexpected error for allowlisted peer: [%v]", err)
+		}
+	}
+
+	// Periodic validation must not be affected by the limit.
+	if err := policy.Validate(peerPublicKey); err != nil {
+		t.Errorf("unexpected error on periodic validation: [%v]", err)
+	}
+
+	now = now.Add(time.Minute)
+
+	if err := validate(peerPublicKey); err != nil {
+		t.Errorf("unexpected error after the period elapsed: [%v]", err)
+	}
+}
+
+func TestMinimumStakePolicy(t *testing.T) {
+	sufficientStakePublicKey := generatePublicKey(t)
+	insufficientStakePublicKey := generatePublicKey(t)
+	allowlistedPublicKey := generatePublicKey(t)
+
+	application := &mockStakeApplication{
+		stakes: map[string]*big.Int{
+			sufficientStakePublicKey.String():   big.NewInt(1000),
+			insufficientStakePublicKey.String(): big.NewInt(999),
+		},
+	}
+
+	policy := &minimumStakePolicy{
+		application:  application,
+		minimumStake: big.NewInt(1000),
+		allowList: NewAllowList(
+			[]*operator.PublicKey{allowlistedPublicKey},
+		),
+		positiveResultCache: cache.NewTimeCache(cachingPeriod),
+		negativeResultCache: cache.NewTimeCache(cachingPeriod),
+	}
+
+	if err := policy.Validate(sufficientStakePublicKey); err != nil {
+		t.Errorf("unexpected error: [%v]", err)
+	}
+
+	err := policy.Validate(insufficientStakePublicKey)
+	testutils.AssertErrorsSame(t, errInsufficientStake, err)
+
+	if err := policy.Validate(allowlistedPublicKey); err != nil {
+		t.Errorf("unexpected error for allowlisted peer: [%v]", err)
+	}
+
+	// The result should be cached.
+	application.stakes[insufficientStakePublicKey.String()] = big.NewInt(1000)
+	err = policy.Validate(insufficientStakePublicKey)
+	testutils.AssertErrorsSame(t, errInsufficientStake, err)
+
+	time.Sleep(cachingPeriod)
+
+	if err := policy.Validate(insufficientStakePublicKey); err != nil {
+		t.Errorf("unexpected error after cache expired: [%v]", err)
+	}
+}
+
+func TestConfigurablePolicy_Reload(t *testing.T) {
+	peerPublicKey := generatePublicKey(t)
+
+	defaultApplication := newMockApplication()
+	defaultApplication.setIsRecognized(peerPublicKey, result{isRecognized: true})
+	defaultPolicy := AnyApplicationPolicy(
+		[]Application{defaultApplication},
+		EmptyAllowList,
+	)
+
+	policy, err := NewConfigurablePolicy(
+		defaultPolicy,
+		Config{},
+		nil,
+		nil,
+		EmptyAllowList,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.Validate(peerPublicKey); err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	err = policy.Reload(Config{
+		DeniedOperators: []string{peerPublicKey.String()},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.Validate(peerPublicKey); err == nil {
+		t.Errorf("expected denied operator to be rejected after reload")
+	}
+
+	// An invalid config must leave the current policies intact.
+	err = policy.Reload(Config{DeniedAddresses: []string{"not-an-ip"}})
+	if err == nil {
+		t.Fatal("expected error for invalid config")
+	}
+
+	if err := policy.Validate(peerPublicKey); err == nil {
+		t.Errorf("expected denied operator to still be rejected")
+	}
+
+	// Minimum stake cannot be enforced without a stake application.
+	err = policy.Reload(Config{MinimumStake: big.NewInt(1)})
+	if err == nil {
+		t.Fatal("expected error for unsupported minimum stake")
+	}
+
+	if err := policy.Reload(Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.Validate(peerPublicKey); err != nil {
+		t.Errorf("unexpected error after the deny list was cleared: [%v]", err)
+	}
+}
+
+func generatePublicKey(t *testing.T) *operator.PublicKey {
+	_, publicKey, err := operator.GenerateKeyPair(local_v1.DefaultCurve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return publicKey
+}
+
+type mockStakeApplication struct {
+	stakes map[string]*big.Int
+}
+
+func (msa *mockStakeApplication) OperatorEligibleStake(
+	operatorPublicKey *operator.PublicKey,
+) (*big.Int, error) {
+	stake, ok := msa.stakes[operatorPublicKey.String()]
+	if !ok {
+		return big.NewInt(0), nil
+	}
+
+	return stake, nil
+}
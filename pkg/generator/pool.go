@@ -3,6 +3,7 @@ package generator
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ipfs/go-log/v2"
@@ -36,8 +37,9 @@ type Persisted[S any] struct {
 // parameter automatically. The pool submits the work to the provided scheduler
 // instance and can be controlled by the scheduler.
 type ParameterPool[T any] struct {
-	persistence Persistence[T]
-	pool        chan *Persisted[T]
+	persistence   Persistence[T]
+	pool          chan *Persisted[T]
+	generateDelay atomic.Int64
 }
 
 // NewParameterPool creates a new instance of ParameterPool.
@@ -73,6 +75,12 @@ func NewParameterPool[T any](
 
 	logger.Infof("loaded [%d] parameters from persistence", len(pool))
 
+	parameterPool := &ParameterPool[T]{
+		persistence: persistence,
+		pool:        pool,
+	}
+	parameterPool.SetGenerateDelay(generateDelay)
+
 	scheduler.compute(func(ctx context.Context) {
 		start := time.Now()
 
@@ -112,13 +120,17 @@ func NewParameterPool[T any](
 		// Wait some time after delivering the result regardless if the delivery
 		// took some time or not. We want to ensure all other processes of the
 		// client receive access to CPU.
-		time.Sleep(generateDelay)
+		time.Sleep(time.Duration(parameterPool.generateDelay.Load()))
 	})
 
-	return &ParameterPool[T]{
-		persistence: persistence,
-		pool:        pool,
-	}
+	return parameterPool
+}
+
+// SetGenerateDelay sets the delay between delivering a generated parameter
+// and starting generation of the next one. The new delay takes effect after
+// the currently generated parameter is delivered.
+func (pp *ParameterPool[T]) SetGenerateDelay(generateDelay time.Duration) {
+	pp.generateDelay.Store(int64(generateDelay))
 }
 
 // GetNow returns a new parameter from the pool. Returns ErrEmptyPool when the
@@ -2,6 +2,7 @@ package maintainer
 
 import (
 	"github.com/keep-network/keep-core/pkg/maintainer/btcdiff"
+	"github.com/keep-network/keep-core/pkg/maintainer/depositrefund"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
 )
 
@@ -9,4 +10,5 @@ import (
 type Config struct {
 	BitcoinDifficulty btcdiff.Config
 	Spv               spv.Config
+	DepositRefund     depositrefund.Config
 }
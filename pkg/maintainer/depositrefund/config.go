@@ -0,0 +1,31 @@
+package depositrefund
+
+import (
+	"time"
+)
+
+const (
+	// DefaultCheckInterval is the default value for the interval between
+	// deposit refund checks.
+	DefaultCheckInterval = time.Hour
+
+	// DefaultWarningPeriod is the default value for the warning period which
+	// is the time before the refund locktime of an unswept deposit at which
+	// the deposit is reported as nearing its refund. The value gives the DAO
+	// a week to react, e.g. by contacting the wallet operators.
+	DefaultWarningPeriod = 7 * 24 * time.Hour
+)
+
+// Config holds configurable properties.
+type Config struct {
+	// Enabled indicates whether the deposit refund watcher should be started.
+	Enabled bool
+
+	// CheckInterval is the interval between deposit refund checks. Each check
+	// inspects all revealed deposits so this value should not be too low.
+	CheckInterval time.Duration
+
+	// WarningPeriod is the time before the refund locktime of an unswept
+	// deposit at which the deposit is reported as nearing its refund.
+	WarningPeriod time.Duration
+}
@@ -0,0 +1,195 @@
+package depositrefund
+
+import (
+	"context"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
+)
+
+var logger = log.Logger("keep-maintainer-deposit-refund")
+
+// Chain represents the interface that the deposit refund watcher expects to
+// interact with the anchoring blockchain on.
+type Chain interface {
+	tbtcpg.Chain
+}
+
+// depositState is the state of an unswept deposit with regard to its refund
+// locktime. The higher the value, the more severe the state.
+type depositState int
+
+const (
+	// depositStateSafe denotes a deposit far from its refund locktime.
+	depositStateSafe depositState = iota
+	// depositStateNearingRefund denotes a deposit whose refund locktime is
+	// within the warning period.
+	depositStateNearingRefund
+	// depositStateRefundable denotes a deposit whose refund locktime passed.
+	depositStateRefundable
+	// depositStateRefunded denotes a deposit refunded on Bitcoin.
+	depositStateRefunded
+)
+
+// Initialize starts the deposit refund watcher. The watcher periodically
+// checks the refund locktimes of all unswept deposits and reports deposits
+// nearing their refund locktime, deposits already refundable, and deposits
+// refunded on Bitcoin. A refunded deposit is excluded from deposit sweeps
+// but stays unswept in the Bridge forever so it should be flagged to the DAO.
+func Initialize(
+	ctx context.Context,
+	config Config,
+	chain Chain,
+	btcChain bitcoin.Chain,
+) {
+	watcher := &depositRefundWatcher{
+		config:   config,
+		chain:    chain,
+		btcChain: btcChain,
+		states:   make(map[string]depositState),
+	}
+
+	go watcher.startControlLoop(ctx)
+}
+
+type depositRefundWatcher struct {
+	config   Config
+	chain    Chain
+	btcChain bitcoin.Chain
+
+	// states holds the states of the unswept deposits as of the last check,
+	// by deposit key. Used to report each deposit only once it enters a more
+	// severe state.
+	states map[string]depositState
+}
+
+func (drw *depositRefundWatcher) startControlLoop(ctx context.Context) {
+	logger.Info("starting deposit refund watcher")
+
+	defer func() {
+		logger.Info("stopping deposit refund watcher")
+	}()
+
+	for {
+		statuses, err := tbtcpg.FindDepositRefunds(
+			logger,
+			drw.chain,
+			drw.btcChain,
+			[20]byte{},
+		)
+		if err != nil {
+			logger.Errorf("cannot check deposit refunds: [%v]", err)
+		} else {
+			drw.report(statuses)
+		}
+
+		select {
+		case <-time.After(drw.config.CheckInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// report reports the deposits that entered a more severe state since the
+// last check and logs the summary of the check.
+func (drw *depositRefundWatcher) report(
+	statuses []*tbtcpg.DepositRefundStatus,
+) {
+	counts := make(map[depositState]int)
+
+	for _, status := range drw.process(statuses) {
+		switch drw.states[status.DepositKey] {
+		case depositStateNearingRefund:
+			logger.Warnf(
+				"deposit [%s] of wallet [0x%x] with amount [%d] satoshi "+
+					"becomes refundable in [%s], at [%s]",
+				status.DepositKey,
+				status.WalletPublicKeyHash,
+				status.Amount,
+				status.TimeUntilRefund.Round(time.Minute),
+				status.RefundLocktime.Format(time.RFC3339),
+			)
+		case depositStateRefundable:
+			logger.Warnf(
+				"deposit [%s] of wallet [0x%x] with amount [%d] satoshi "+
+					"is refundable since [%s]; the depositor can take it back",
+				status.DepositKey,
+				status.WalletPublicKeyHash,
+				status.Amount,
+				status.RefundLocktime.Format(time.RFC3339),
+			)
+		case depositStateRefunded:
+			logger.Errorf(
+				"deposit [%s] of wallet [0x%x] with amount [%d] satoshi "+
+					"was refunded on Bitcoin in transaction [%s]; the "+
+					"deposit will never be swept and should be flagged "+
+					"to the DAO",
+				status.DepositKey,
+				status.WalletPublicKeyHash,
+				status.Amount,
+				status.RefundTxHash.Hex(bitcoin.ReversedByteOrder),
+			)
+		}
+	}
+
+	for _, state := range drw.states {
+		counts[state]++
+	}
+
+	logger.Infof(
+		"checked [%d] unswept deposits; nearing refund: [%d], "+
+			"refundable: [%d], refunded: [%d]; next check in [%s]",
+		len(statuses),
+		counts[depositStateNearingRefund],
+		counts[depositStateRefundable],
+		counts[depositStateRefunded],
+		drw.config.CheckInterval,
+	)
+}
+
+// process updates the states of the unswept deposits and returns the
+// deposits that entered a more severe state since the last check. Deposits
+// no longer unswept, e.g. swept in the meantime, are forgotten.
+func (drw *depositRefundWatcher) process(
+	statuses []*tbtcpg.DepositRefundStatus,
+) []*tbtcpg.DepositRefundStatus {
+	states := make(map[string]depositState)
+	escalated := make([]*tbtcpg.DepositRefundStatus, 0)
+
+	for _, status := range statuses {
+		state := drw.state(status)
+		if state == depositStateSafe {
+			continue
+		}
+
+		states[status.DepositKey] = state
+
+		if state > drw.states[status.DepositKey] {
+			escalated = append(escalated, status)
+		}
+	}
+
+	drw.states = states
+
+	return escalated
+}
+
+// state determines the state of the given unswept deposit.
+func (drw *depositRefundWatcher) state(
+	status *tbtcpg.DepositRefundStatus,
+) depositState {
+	switch {
+	case status.Refunded:
+		return depositStateRefunded
+	case status.TimeUntilRefund <= 0:
+		return depositStateRefundable
+	case status.TimeUntilRefund <= drw.config.WarningPeriod:
+		return depositStateNearingRefund
+	default:
+		return depositStateSafe
+	}
+}
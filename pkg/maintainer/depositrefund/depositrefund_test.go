@@ -0,0 +1,106 @@
+package depositrefund
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/tbtcpg"
+)
+
+func TestDepositRefundWatcher_Process(t *testing.T) {
+	watcher := &depositRefundWatcher{
+		config: Config{
+			CheckInterval: DefaultCheckInterval,
+			WarningPeriod: DefaultWarningPeriod,
+		},
+		states: make(map[string]depositState),
+	}
+
+	status := func(
+		depositKey string,
+		timeUntilRefund time.Duration,
+		refunded bool,
+	) *tbtcpg.DepositRefundStatus {
+		return &tbtcpg.DepositRefundStatus{
+			DepositKey:      depositKey,
+			TimeUntilRefund: timeUntilRefund,
+			Refunded:        refunded,
+		}
+	}
+
+	escalatedKeys := func(
+		statuses []*tbtcpg.DepositRefundStatus,
+	) []string {
+		keys := make([]string, 0)
+		for _, status := range watcher.process(statuses) {
+			keys = append(keys, status.DepositKey)
+		}
+		return keys
+	}
+
+	assertEscalated := func(check string, expected []string, actual []string) {
+		if !reflect.DeepEqual(expected, actual) {
+			t.Errorf(
+				"unexpected escalated deposits in %s\n"+
+					"expected: %v\nactual:   %v",
+				check,
+				expected,
+				actual,
+			)
+		}
+	}
+
+	// The first check reports all deposits that are not safe.
+	assertEscalated(
+		"first check",
+		[]string{"nearing", "refundable", "refunded"},
+		escalatedKeys([]*tbtcpg.DepositRefundStatus{
+			status("safe", 30*24*time.Hour, false),
+			status("nearing", 24*time.Hour, false),
+			status("refundable", -time.Hour, false),
+			status("refunded", -time.Hour, true),
+		}),
+	)
+
+	expectedStates := map[string]depositState{
+		"nearing":    depositStateNearingRefund,
+		"refundable": depositStateRefundable,
+		"refunded":   depositStateRefunded,
+	}
+	if !reflect.DeepEqual(expectedStates, watcher.states) {
+		t.Errorf(
+			"unexpected states\nexpected: %v\nactual:   %v",
+			expectedStates,
+			watcher.states,
+		)
+	}
+
+	// The second check reports only deposits that entered a more severe
+	// state. The refundable deposit got swept so it is no longer returned.
+	assertEscalated(
+		"second check",
+		[]string{"safe", "nearing"},
+		escalatedKeys([]*tbtcpg.DepositRefundStatus{
+			status("safe", 6*24*time.Hour, false),
+			status("nearing", -time.Minute, false),
+			status("refunded", -2*time.Hour, true),
+		}),
+	)
+
+	// The swept deposit is forgotten.
+	if _, ok := watcher.states["refundable"]; ok {
+		t.Errorf("swept deposit should be forgotten")
+	}
+
+	// Nothing changed so nothing is reported.
+	assertEscalated(
+		"third check",
+		[]string{},
+		escalatedKeys([]*tbtcpg.DepositRefundStatus{
+			status("safe", 5*24*time.Hour, false),
+			status("nearing", -2*time.Minute, false),
+			status("refunded", -3*time.Hour, true),
+		}),
+	)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/maintainer/btcdiff"
+	"github.com/keep-network/keep-core/pkg/maintainer/depositrefund"
 	"github.com/keep-network/keep-core/pkg/maintainer/spv"
 )
 
@@ -17,11 +18,13 @@ func Initialize(
 	btcChain bitcoin.Chain,
 	btcDiffChain btcdiff.Chain,
 	spvChain spv.Chain,
+	depositRefundChain depositrefund.Chain,
 ) {
 	// If none of the maintainers was specified in the config (i.e. no option was
 	// provided to the `maintainer` command), all maintainers should be launched.
 	launchAll := !config.BitcoinDifficulty.Enabled &&
-		!config.Spv.Enabled
+		!config.Spv.Enabled &&
+		!config.DepositRefund.Enabled
 
 	if launchAll {
 		logger.Info("initializing all maintainer modules...")
@@ -46,6 +49,15 @@ func Initialize(
 		)
 	}
 
+	if config.DepositRefund.Enabled || launchAll {
+		depositrefund.Initialize(
+			ctx,
+			config.DepositRefund,
+			depositRefundChain,
+			btcChain,
+		)
+	}
+
 	// TODO: Allow for launching multiple maintainers here. Every flag
 	//       indicating a maintainer task should launch a separate maintainer.
 	//       Notice that panic on one maintainer goroutine will crush the whole
@@ -185,6 +185,13 @@ func (ac *authenticatedConnection) checkFirewallRules() error {
 		)
 	}
 
+	if connectionFirewall, ok := ac.firewall.(keepNet.ConnectionFirewall); ok {
+		return connectionFirewall.ValidateConnection(
+			operatorPublicKey,
+			ac.Conn.RemoteAddr(),
+		)
+	}
+
 	return ac.firewall.Validate(operatorPublicKey)
 }
 
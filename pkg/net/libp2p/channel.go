@@ -73,6 +73,8 @@ type channel struct {
 	unmarshalersByType map[string]func() net.TaggedUnmarshaler
 
 	retransmissionTicker *retransmission.Ticker
+
+	replayGuard *replayGuard
 }
 
 type messageHandler struct {
@@ -341,6 +343,19 @@ func (c *channel) processContainerMessage(
 
 	operatorPublicKeyBytes := operator.MarshalUncompressed(operatorPublicKey)
 
+	if err := c.replayGuard.check(
+		operatorPublicKeyBytes,
+		message.SequenceNumber,
+		message.Type,
+		message.Payload,
+	); err != nil {
+		return fmt.Errorf(
+			"rejected message from sender [%v]: [%v]",
+			senderIdentifier.id,
+			err,
+		)
+	}
+
 	netMessage := internal.BasicMessage(
 		senderIdentifier.id,
 		unmarshaled,
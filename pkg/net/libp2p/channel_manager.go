@@ -133,6 +133,7 @@ func (cm *channelManager) newChannel(name string) (*channel, error) {
 	}
 
 	channel := &channel{
+		counter:              initialSeqno(),
 		name:                 name,
 		clientIdentity:       cm.identity,
 		peerStore:            cm.peerStore,
@@ -143,6 +144,7 @@ func (cm *channelManager) newChannel(name string) (*channel, error) {
 		messageHandlers:      make([]*messageHandler, 0),
 		unmarshalersByType:   make(map[string]func() net.TaggedUnmarshaler),
 		retransmissionTicker: cm.retransmissionTicker,
+		replayGuard:          newReplayGuard(),
 	}
 
 	go channel.handleMessages(cm.ctx)
@@ -234,3 +236,14 @@ func (cm *channelManager) getTopic(name string) (*pubsub.Topic, error) {
 
 	return topic, nil
 }
+
+// topicPeers returns identifiers of peers subscribed to the topic with the
+// given name, as known by the pubsub router.
+func (cm *channelManager) topicPeers(name string) []string {
+	peers := make([]string, 0)
+	for _, peerID := range cm.pubsub.ListPeers(name) {
+		peers = append(peers, peerID.String())
+	}
+
+	return peers
+}
@@ -88,6 +88,63 @@ func TestFuzzBroadcastChannelRoundtrip(t *testing.T) {
 	})
 }
 
+func TestFuzzReplayGuard(t *testing.T) {
+	guard := newReplayGuard()
+
+	for i := 0; i < 1000; i++ {
+		var (
+			sender  []byte
+			seqno   uint64
+			payload []byte
+		)
+
+		f := fuzz.New().NilChance(0.01).NumElements(0, 64)
+		f.Fuzz(&sender)
+		f.Fuzz(&seqno)
+		f.Fuzz(&payload)
+
+		_ = guard.check(sender, seqno, payload)
+	}
+}
+
+func TestFuzzReplayGuardRetransmissions(t *testing.T) {
+	guard := newReplayGuard()
+	sender := []byte("sender")
+
+	seen := make(map[uint64][]byte)
+
+	for i := 0; i < 1000; i++ {
+		var (
+			offset  uint16
+			payload []byte
+		)
+
+		f := fuzz.New().NilChance(0.01).NumElements(0, 64)
+		f.Fuzz(&offset)
+		f.Fuzz(&payload)
+
+		// Keep all sequence numbers within a single window so that the only
+		// reason for rejection is a different content for a known seqno.
+		seqno := legacySeqnoThreshold + uint64(offset)%replayWindowSize
+
+		err := guard.check(sender, seqno, payload)
+
+		previous, ok := seen[seqno]
+		switch {
+		case !ok && err != nil:
+			t.Fatalf("unexpected rejection of new seqno [%v]: [%v]", seqno, err)
+		case ok && string(previous) == string(payload) && err != nil:
+			t.Fatalf("unexpected rejection of retransmission [%v]: [%v]", seqno, err)
+		case ok && string(previous) != string(payload) && err == nil:
+			t.Fatalf("expected rejection of replayed seqno [%v]", seqno)
+		}
+
+		if !ok {
+			seen[seqno] = payload
+		}
+	}
+}
+
 type fuzzingMessage struct {
 	Bytes []byte
 }
@@ -124,6 +124,10 @@ func (p *provider) CreateTransportIdentifier(operatorPublicKey *operator.PublicK
 	return peer.IDFromPublicKey(networkPublicKey)
 }
 
+func (p *provider) BroadcastChannelPeers(name string) []string {
+	return p.broadcastChannelManager.topicPeers(name)
+}
+
 func (p *provider) BroadcastChannelForwarderFor(name string) {
 	if p.disseminationTime == 0 {
 		return
@@ -233,6 +237,53 @@ func (cm *connectionManager) IsConnected(address string) bool {
 	return cm.Network().Connectedness(peerInfos[0].ID) == libp2pnet.Connected
 }
 
+func (cm *connectionManager) ConnectToPeer(
+	ctx context.Context,
+	address string,
+) (time.Duration, error) {
+	peerInfos, err := extractMultiAddrFromPeers([]string{address})
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to extract multiaddress from [%s]: [%v]",
+			address,
+			err,
+		)
+	}
+
+	start := time.Now()
+
+	if err := cm.Connect(ctx, peerInfos[0]); err != nil {
+		return 0, err
+	}
+
+	return time.Since(start), nil
+}
+
+func (cm *connectionManager) PingPeer(
+	ctx context.Context,
+	connectedPeer string,
+) (time.Duration, error) {
+	peerID, err := peer.Decode(connectedPeer)
+	if err != nil {
+		return 0, fmt.Errorf(
+			"failed to decode peer ID from [%s]: [%v]",
+			connectedPeer,
+			err,
+		)
+	}
+
+	select {
+	case result := <-ping.Ping(ctx, cm.Host, peerID):
+		if result.Error != nil {
+			return 0, result.Error
+		}
+
+		return result.RTT, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
 func (cm *connectionManager) monitorConnectedPeers(ctx context.Context) {
 	ticker := time.NewTicker(ConnectedPeersCheckTick)
 	defer ticker.Stop()
@@ -0,0 +1,218 @@
+package libp2p
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// replayWindowSize is the number of the most recent sequence numbers
+	// tracked for each sender. A message with a sequence number lower than
+	// the highest sequence number seen from the sender minus the window size
+	// is considered stale and rejected. The window must be large enough to
+	// let retransmissions of older messages through while the sender keeps
+	// sending new messages on the same channel.
+	replayWindowSize = 4096
+
+	// replayGuardSenderTTL is the time after which the replay window of a
+	// sender is forgotten if no new message has been accepted from them.
+	// Only the highest sequence number seen from the sender is retained
+	// afterwards, as the sender's sequence number floor. It must be longer
+	// than libp2pSeenMessagesTTL for the replay guard to protect from replays
+	// the pubsub seen messages cache no longer covers.
+	replayGuardSenderTTL = 30 * time.Minute
+
+	// legacySeqnoThreshold is the sequence number below which the sender is
+	// assumed to use a sequence number counter starting from zero on every
+	// client restart. The replay guard does not track such senders because
+	// a restart cannot be distinguished from a replay. Senders initializing
+	// the counter with the current time in nanoseconds always start above
+	// this threshold. Once a sender is seen above the threshold, all its
+	// messages below the window are rejected as stale.
+	legacySeqnoThreshold = uint64(1) << 48
+)
+
+// initialSeqno returns the initial value of the channel sequence number
+// counter. The counter is initialized with the current time so that sequence
+// numbers of messages sent by the client keep increasing across restarts and
+// receivers can reject stale sequence numbers.
+func initialSeqno() uint64 {
+	return uint64(time.Now().UnixNano())
+}
+
+// replayGuard rejects replayed messages received on a broadcast channel. It
+// tracks a window of recently seen sequence numbers for each sender. A message
+// whose sequence number has already been seen is accepted only if its content
+// is exactly the same as the content of the message seen before. This way,
+// retransmissions pass through the guard and are deduplicated later by
+// retransmission.WithRetransmissionSupport for each handler separately, while
+// a different message replayed under an already used sequence number is
+// rejected. Messages with sequence numbers older than the window are rejected
+// as stale.
+//
+// Once the window of an inactive sender expires, the highest sequence number
+// seen from the sender is kept as the sender's floor. Messages with sequence
+// numbers at or below the floor are rejected as stale so a captured message
+// cannot be replayed by just waiting until the window expires. Sequence
+// numbers of a sender increase across client restarts so the floor never
+// blocks new messages.
+//
+// The guard is created for each broadcast channel separately so the windows
+// are effectively keyed by sender public key and topic.
+type replayGuard struct {
+	mutex   sync.Mutex
+	senders map[string]*senderReplayWindow
+	// floors holds sequence number floors of senders whose windows expired.
+	floors map[string]uint64
+
+	windowSize uint64
+	senderTTL  time.Duration
+	now        func() time.Time
+}
+
+// senderReplayWindow holds the replay window of a single sender.
+type senderReplayWindow struct {
+	// floor is the sequence number at or below which all messages are
+	// rejected as stale. It is inherited from the expired window of the
+	// sender, if any.
+	floor        uint64
+	highestSeqno uint64
+	// digests holds message digests for sequence numbers in the window.
+	digests      map[uint64][sha256.Size]byte
+	lastAccepted time.Time
+}
+
+func newReplayGuard() *replayGuard {
+	return &replayGuard{
+		senders:    make(map[string]*senderReplayWindow),
+		floors:     make(map[string]uint64),
+		windowSize: replayWindowSize,
+		senderTTL:  replayGuardSenderTTL,
+		now:        time.Now,
+	}
+}
+
+// check verifies whether the message with the given sequence number and
+// content, sent by the sender with the given public key, is not a replay.
+// Returns an error if the message should be rejected. Accepted messages
+// are recorded in the sender's window.
+func (rg *replayGuard) check(
+	senderPublicKey []byte,
+	seqno uint64,
+	content ...[]byte,
+) error {
+	digest := messageDigest(content...)
+
+	rg.mutex.Lock()
+	defer rg.mutex.Unlock()
+
+	now := rg.now()
+	senderKey := string(senderPublicKey)
+
+	window, ok := rg.senders[senderKey]
+	if ok && now.Sub(window.lastAccepted) > rg.senderTTL {
+		rg.expire(senderKey, window)
+		ok = false
+	}
+
+	floor, hasFloor := rg.floors[senderKey]
+
+	if seqno < legacySeqnoThreshold && !ok && !hasFloor {
+		// Sender uses a legacy sequence number counter. A client restart
+		// cannot be distinguished from a replay in that case so the
+		// message is let through and left to the pubsub seen messages
+		// cache and the handlers.
+		return nil
+	}
+
+	if !ok {
+		if seqno <= floor {
+			return fmt.Errorf(
+				"stale sequence number [%v]; sender's floor is [%v]",
+				seqno,
+				floor,
+			)
+		}
+
+		rg.pruneExpired(now)
+
+		window = &senderReplayWindow{
+			floor:        floor,
+			highestSeqno: floor,
+			digests:      make(map[uint64][sha256.Size]byte),
+			lastAccepted: now,
+		}
+		rg.senders[senderKey] = window
+		delete(rg.floors, senderKey)
+	}
+
+	if seenDigest, seen := window.digests[seqno]; seen {
+		if seenDigest != digest {
+			return fmt.Errorf(
+				"message with sequence number [%v] already seen "+
+					"with a different content",
+				seqno,
+			)
+		}
+
+		// Retransmission of an already seen message.
+		window.lastAccepted = now
+		return nil
+	}
+
+	if seqno <= window.floor || seqno+rg.windowSize <= window.highestSeqno {
+		return fmt.Errorf(
+			"stale sequence number [%v]; highest seen is [%v]",
+			seqno,
+			window.highestSeqno,
+		)
+	}
+
+	window.digests[seqno] = digest
+	window.lastAccepted = now
+
+	if seqno > window.highestSeqno {
+		window.highestSeqno = seqno
+
+		for windowSeqno := range window.digests {
+			if windowSeqno+rg.windowSize <= seqno {
+				delete(window.digests, windowSeqno)
+			}
+		}
+	}
+
+	return nil
+}
+
+// pruneExpired replaces windows of senders that have not sent any accepted
+// message for longer than the sender TTL with their sequence number floors.
+// Must be called with the mutex held.
+func (rg *replayGuard) pruneExpired(now time.Time) {
+	for senderKey, window := range rg.senders {
+		if now.Sub(window.lastAccepted) > rg.senderTTL {
+			rg.expire(senderKey, window)
+		}
+	}
+}
+
+// expire replaces the window of the given sender with the sender's sequence
+// number floor. Must be called with the mutex held.
+func (rg *replayGuard) expire(senderKey string, window *senderReplayWindow) {
+	rg.floors[senderKey] = window.highestSeqno
+	delete(rg.senders, senderKey)
+}
+
+func messageDigest(content ...[]byte) [sha256.Size]byte {
+	hash := sha256.New()
+	for _, part := range content {
+		// Prefix each part with its length to make the digest unambiguous.
+		hash.Write([]byte(fmt.Sprintf("%d:", len(part))))
+		hash.Write(part)
+	}
+
+	var digest [sha256.Size]byte
+	copy(digest[:], hash.Sum(nil))
+	return digest
+}
@@ -0,0 +1,172 @@
+package libp2p
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	senderA = []byte("sender-a")
+	senderB = []byte("sender-b")
+)
+
+func TestReplayGuard_AcceptsRetransmissions(t *testing.T) {
+	guard := newReplayGuard()
+	seqno := legacySeqnoThreshold + 10
+
+	for i := 0; i < 3; i++ {
+		if err := guard.check(senderA, seqno, []byte("payload")); err != nil {
+			t.Fatalf("unexpected error on attempt [%v]: [%v]", i, err)
+		}
+	}
+}
+
+func TestReplayGuard_RejectsDuplicateSeqnoWithDifferentContent(t *testing.T) {
+	guard := newReplayGuard()
+	seqno := legacySeqnoThreshold + 10
+
+	if err := guard.check(senderA, seqno, []byte("payload")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := guard.check(senderA, seqno, []byte("other")); err == nil {
+		t.Fatal("expected duplicate sequence number to be rejected")
+	}
+
+	// The same sequence number from another sender is fine.
+	if err := guard.check(senderB, seqno, []byte("other")); err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestReplayGuard_RejectsStaleSeqno(t *testing.T) {
+	guard := newReplayGuard()
+	seqno := legacySeqnoThreshold + 10
+
+	if err := guard.check(senderA, seqno, []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+
+	newest := seqno + replayWindowSize
+	if err := guard.check(senderA, newest, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The old message fell out of the window so even its exact
+	// retransmission is stale now.
+	if err := guard.check(senderA, seqno, []byte("old")); err == nil {
+		t.Fatal("expected stale sequence number to be rejected")
+	}
+
+	if err := guard.check(senderA, newest-1, []byte("in-window")); err != nil {
+		t.Fatalf("unexpected error for in-window message: [%v]", err)
+	}
+
+	// Messages from before the client upgrade are stale too.
+	if err := guard.check(senderA, 1, []byte("legacy")); err == nil {
+		t.Fatal("expected legacy sequence number to be rejected")
+	}
+}
+
+func TestReplayGuard_AcceptsOutOfOrderWithinWindow(t *testing.T) {
+	guard := newReplayGuard()
+	base := legacySeqnoThreshold + 100
+
+	for _, offset := range []uint64{5, 1, 3, 2, 4} {
+		if err := guard.check(senderA, base+offset, []byte{byte(offset)}); err != nil {
+			t.Fatalf("unexpected error for offset [%v]: [%v]", offset, err)
+		}
+	}
+}
+
+func TestReplayGuard_LegacySenders(t *testing.T) {
+	guard := newReplayGuard()
+
+	// Legacy sender restarts and reuses sequence numbers with a different
+	// content; this cannot be told apart from a replay.
+	if err := guard.check(senderA, 1, []byte("before restart")); err != nil {
+		t.Fatal(err)
+	}
+	if err := guard.check(senderA, 1, []byte("after restart")); err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
+
+func TestReplayGuard_ForgetsInactiveSenders(t *testing.T) {
+	guard := newReplayGuard()
+
+	now := time.Now()
+	guard.now = func() time.Time { return now }
+
+	seqno := legacySeqnoThreshold + 10
+
+	if err := guard.check(senderA, seqno, []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(replayGuardSenderTTL + time.Second)
+
+	// A message from another sender prunes the expired window of the
+	// first sender.
+	if err := guard.check(senderB, seqno, []byte("other")); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(guard.senders) != 1 {
+		t.Fatalf("unexpected number of tracked senders: [%v]", len(guard.senders))
+	}
+
+	if floor, ok := guard.floors[string(senderA)]; !ok || floor != seqno {
+		t.Fatalf("unexpected floor of the inactive sender: [%v]", floor)
+	}
+}
+
+func TestReplayGuard_RejectsReplayAfterExpiry(t *testing.T) {
+	guard := newReplayGuard()
+
+	now := time.Now()
+	guard.now = func() time.Time { return now }
+
+	seqno := legacySeqnoThreshold + 10
+
+	for _, offset := range []uint64{0, 1, 2} {
+		err := guard.check(senderA, seqno+offset, []byte{byte(offset)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now = now.Add(replayGuardSenderTTL + time.Second)
+
+	// The captured messages are stale even though the sender's window
+	// expired.
+	for _, offset := range []uint64{0, 1, 2} {
+		err := guard.check(senderA, seqno+offset, []byte{byte(offset)})
+		if err == nil {
+			t.Fatalf("expected replay of offset [%v] to be rejected", offset)
+		}
+	}
+
+	// Legacy sequence numbers are stale as well.
+	if err := guard.check(senderA, 1, []byte("legacy")); err == nil {
+		t.Fatal("expected legacy sequence number to be rejected")
+	}
+
+	// New messages of the sender are accepted.
+	if err := guard.check(senderA, seqno+3, []byte("new")); err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+
+	// The floor stays in force for the new window.
+	if err := guard.check(senderA, seqno+1, []byte{1}); err == nil {
+		t.Fatal("expected replay below the floor to be rejected")
+	}
+
+	// The sender restarts the client and continues with a higher sequence
+	// number.
+	now = now.Add(replayGuardSenderTTL + time.Second)
+
+	if err := guard.check(senderA, seqno+1000, []byte("restart")); err != nil {
+		t.Fatalf("unexpected error: [%v]", err)
+	}
+}
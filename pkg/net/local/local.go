@@ -4,7 +4,10 @@
 package local
 
 import (
+	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/operator"
 
@@ -81,6 +84,12 @@ func ConnectWithKey(operatorPublicKey *operator.PublicKey) Provider {
 	}
 }
 
+// BroadcastChannelPeers returns all peers added to the local provider as
+// local broadcast channels are shared by all local providers.
+func (lp *localProvider) BroadcastChannelPeers(name string) []string {
+	return lp.connectionManager.ConnectedPeers()
+}
+
 func (lp *localProvider) ConnectionManager() net.ConnectionManager {
 	return lp.connectionManager
 }
@@ -135,3 +144,17 @@ func (lcm *localConnectionManager) AddrStrings() []string {
 func (lcm *localConnectionManager) IsConnected(address string) bool {
 	panic("not implemented")
 }
+
+func (lcm *localConnectionManager) ConnectToPeer(
+	ctx context.Context,
+	address string,
+) (time.Duration, error) {
+	return 0, fmt.Errorf("connecting to peers is not supported by the local provider")
+}
+
+func (lcm *localConnectionManager) PingPeer(
+	ctx context.Context,
+	connectedPeer string,
+) (time.Duration, error) {
+	return 0, fmt.Errorf("pinging peers is not supported by the local provider")
+}
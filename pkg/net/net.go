@@ -2,6 +2,8 @@ package net
 
 import (
 	"context"
+	gonet "net"
+	"time"
 
 	"github.com/keep-network/keep-core/pkg/internal/pb"
 	"github.com/keep-network/keep-core/pkg/operator"
@@ -73,6 +75,12 @@ type Provider interface {
 
 	// BroadcastChannelForwarderFor creates a message relay for given channel name.
 	BroadcastChannelForwarderFor(name string)
+
+	// BroadcastChannelPeers returns transport identifiers of remote peers
+	// the provider sees as subscribed to the broadcast channel with the
+	// given name. Peers not subscribed to the channel will not receive
+	// messages published to it even if they are connected.
+	BroadcastChannelPeers(name string) []string
 }
 
 // ConnectionManager is an interface which exposes peers a client is connected
@@ -88,6 +96,17 @@ type ConnectionManager interface {
 	AddrStrings() []string
 
 	IsConnected(address string) bool
+
+	// ConnectToPeer establishes a connection with the peer under the given
+	// address in the format <endpoint>/ipfs/<cid> and returns the time the
+	// connection establishment took, including the security handshake. If
+	// the connection with the peer already exists, no new connection is
+	// established.
+	ConnectToPeer(ctx context.Context, address string) (time.Duration, error)
+
+	// PingPeer measures the round-trip time of a single ping exchanged with
+	// the given connected peer.
+	PingPeer(ctx context.Context, connectedPeer string) (time.Duration, error)
 }
 
 // TaggedUnmarshaler is an interface that includes the proto.Unmarshaler
@@ -156,3 +175,20 @@ type Firewall interface {
 	// describing what is wrong.
 	Validate(remotePeerPublicKey *operator.PublicKey) error
 }
+
+// ConnectionFirewall represents a Firewall that can additionally validate
+// newly established connections based on the remote peer's network address.
+// Unlike Validate, which may be called periodically for already connected
+// peers, ValidateConnection is called exactly once for each new connection.
+type ConnectionFirewall interface {
+	Firewall
+
+	// ValidateConnection takes the remote peer public key and the remote
+	// network address of a new connection and executes all the checks needed
+	// to decide whether the connection can be approved. If expectations are
+	// not met, this function should return an error describing what is wrong.
+	ValidateConnection(
+		remotePeerPublicKey *operator.PublicKey,
+		remoteAddress gonet.Addr,
+	) error
+}
@@ -0,0 +1,90 @@
+package secret
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+// AgentRequest is the request sent to the secret agent. The agent protocol is
+// a single newline-terminated JSON request followed by a single
+// newline-terminated JSON response, exchanged over a new Unix socket
+// connection for each secret.
+type AgentRequest struct {
+	// Name is the name of the requested secret.
+	Name string `json:"name"`
+}
+
+// AgentResponse is the response sent by the secret agent.
+type AgentResponse struct {
+	// Found tells whether the agent holds the requested secret.
+	Found bool `json:"found"`
+	// Value is the value of the secret, base64 encoded in JSON.
+	Value []byte `json:"value,omitempty"`
+	// Error is set if the agent failed to serve the request.
+	Error string `json:"error,omitempty"`
+}
+
+// agentProvider reads secrets from an agent listening on the Unix socket.
+type agentProvider struct {
+	socket  string
+	timeout time.Duration
+}
+
+func newAgentProvider(socket string, timeout time.Duration) *agentProvider {
+	return &agentProvider{
+		socket:  socket,
+		timeout: timeout,
+	}
+}
+
+func (ap *agentProvider) Secret(ctx context.Context, name string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, ap.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", ap.socket)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot connect to agent [%s]: [%w]",
+			ap.socket,
+			err,
+		)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := json.NewEncoder(conn).Encode(
+		&AgentRequest{Name: name},
+	); err != nil {
+		return nil, fmt.Errorf("cannot send request to agent: [%w]", err)
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("cannot read response from agent: [%w]", err)
+	}
+
+	var response AgentResponse
+	if err := json.Unmarshal(line, &response); err != nil {
+		return nil, fmt.Errorf("cannot parse response from agent: [%w]", err)
+	}
+
+	if response.Error != "" {
+		return nil, fmt.Errorf("agent error: [%s]", response.Error)
+	}
+
+	if !response.Found {
+		return nil, ErrNotFound
+	}
+
+	return response.Value, nil
+}
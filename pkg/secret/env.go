@@ -0,0 +1,31 @@
+package secret
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// envProvider reads secrets from environment variables. The variable name is
+// the secret name in upper case, with dashes replaced by underscores and
+// prefixed with `KEEP_`, e.g. `KEEP_ETHEREUM_PASSWORD`.
+type envProvider struct{}
+
+func newEnvProvider() *envProvider {
+	return &envProvider{}
+}
+
+func (ep *envProvider) Secret(ctx context.Context, name string) ([]byte, error) {
+	value, ok := os.LookupEnv(EnvVariable(name))
+	if !ok || value == "" {
+		return nil, ErrNotFound
+	}
+
+	return []byte(value), nil
+}
+
+// EnvVariable returns the name of the environment variable the secret with
+// the given name is read from by the `env` provider.
+func EnvVariable(name string) string {
+	return "KEEP_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
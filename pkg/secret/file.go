@@ -0,0 +1,57 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fileProvider reads secrets from files in the given directory. Each secret
+// is kept in a separate file named after the secret. The files must not be
+// accessible by the group and other users.
+type fileProvider struct {
+	dir string
+}
+
+func newFileProvider(dir string) *fileProvider {
+	return &fileProvider{dir: dir}
+}
+
+func (fp *fileProvider) Secret(ctx context.Context, name string) ([]byte, error) {
+	if !filepath.IsLocal(name) {
+		return nil, fmt.Errorf("invalid secret name [%s]", name)
+	}
+
+	filePath := filepath.Join(fp.dir, name)
+
+	info, err := os.Stat(filePath)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf(
+			"secret file [%s] is accessible by other users; "+
+				"expected permissions 0600 or stricter, got [%#o]",
+			filePath,
+			info.Mode().Perm(),
+		)
+	}
+
+	// #nosec G304 (file path provided as taint input)
+	// The path is built from the configured secrets directory and a constant
+	// secret name.
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Editors usually end files with a new line that is not a part of the
+	// secret.
+	return bytes.TrimRight(content, "\r\n"), nil
+}
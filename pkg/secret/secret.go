@@ -0,0 +1,169 @@
+// Package secret provides sources of secrets used by the client, like the
+// operator key file and the passwords. Keeping secrets in an external source
+// allows to keep them out of the configuration file and environment variables
+// on shared hosts.
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-log"
+)
+
+var logger = log.Logger("keep-secret")
+
+const (
+	// EthereumPassword is the name of the secret holding the password of the
+	// operator Ethereum key file.
+	EthereumPassword = "ethereum-password"
+	// EthereumKey is the name of the secret holding the content of the
+	// operator Ethereum key file. If the secret is not provided, the key file
+	// is read from the path set in the configuration.
+	EthereumKey = "ethereum-key"
+	// StoragePassword is the name of the secret holding the password the
+	// storage is encrypted with. If the secret is not provided, the Ethereum
+	// key file password is used.
+	StoragePassword = "storage-password"
+)
+
+const (
+	// EnvProvider is the provider reading secrets from environment variables.
+	EnvProvider = "env"
+	// FileProvider is the provider reading secrets from files in a directory.
+	FileProvider = "file"
+	// AgentProvider is the provider reading secrets from an agent listening
+	// on a Unix socket.
+	AgentProvider = "agent"
+	// VaultProvider is the provider reading secrets from a HashiCorp
+	// Vault-compatible key-value HTTP API.
+	VaultProvider = "vault"
+)
+
+// DefaultTimeout is the default timeout of a single secret request to the
+// agent and vault providers.
+const DefaultTimeout = 10 * time.Second
+
+// ErrNotFound is returned when the provider does not hold the requested
+// secret.
+var ErrNotFound = errors.New("secret not found")
+
+// Provider is a source of secrets.
+type Provider interface {
+	// Secret returns the value of the secret with the given name. Returns
+	// ErrNotFound if the provider does not hold the secret.
+	Secret(ctx context.Context, name string) ([]byte, error)
+}
+
+// Config holds the configuration of the secret provider.
+type Config struct {
+	// Provider is the name of the secret provider: `env` (default), `file`,
+	// `agent` or `vault`.
+	Provider string
+	// Dir is the directory the `file` provider reads secrets from. Each
+	// secret is kept in a separate file named after the secret.
+	Dir string
+	// AgentSocket is the path to the Unix socket the `agent` provider
+	// connects to.
+	AgentSocket string
+	// Vault holds the configuration of the `vault` provider.
+	Vault VaultConfig
+	// Timeout is the timeout of a single secret request to the agent and
+	// vault providers.
+	Timeout time.Duration
+}
+
+// VaultConfig holds the configuration of the `vault` secret provider.
+type VaultConfig struct {
+	// URL is the address of the Vault server, e.g. `https://vault:8200`.
+	URL string
+	// Path is the path of the key-value secret holding the client secrets,
+	// e.g. `secret/data/keep` for the version 2 key-value engine or
+	// `kv/keep` for the version 1 key-value engine. The secret fields are
+	// named after the client secrets.
+	Path string
+	// TokenFile is the path to the file holding the Vault token. If not set,
+	// the token is read from the VAULT_TOKEN environment variable.
+	TokenFile string
+}
+
+// Validate checks whether the configuration is complete for the configured
+// provider.
+func (c Config) Validate() error {
+	switch c.Provider {
+	case "", EnvProvider:
+		return nil
+	case FileProvider:
+		if c.Dir == "" {
+			return fmt.Errorf("missing secrets directory for file provider")
+		}
+	case AgentProvider:
+		if c.AgentSocket == "" {
+			return fmt.Errorf("missing agent socket for agent provider")
+		}
+	case VaultProvider:
+		if c.Vault.URL == "" || c.Vault.Path == "" {
+			return fmt.Errorf("missing URL or path for vault provider")
+		}
+	default:
+		return fmt.Errorf(
+			"unsupported secret provider [%s]; expected one of: %s, %s, %s, %s",
+			c.Provider,
+			EnvProvider,
+			FileProvider,
+			AgentProvider,
+			VaultProvider,
+		)
+	}
+
+	return nil
+}
+
+// NewProvider creates the secret provider set in the configuration.
+func NewProvider(config Config) (Provider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	switch config.Provider {
+	case FileProvider:
+		return newFileProvider(config.Dir), nil
+	case AgentProvider:
+		return newAgentProvider(config.AgentSocket, timeout), nil
+	case VaultProvider:
+		return newVaultProvider(config.Vault, timeout)
+	default:
+		return newEnvProvider(), nil
+	}
+}
+
+// Lookup returns the value of the secret with the given name. Returns false
+// if the provider does not hold the secret.
+func Lookup(
+	ctx context.Context,
+	provider Provider,
+	name string,
+) ([]byte, bool, error) {
+	value, err := provider.Secret(ctx, name)
+	if errors.Is(err, ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf(
+			"cannot read secret [%s]: [%w]",
+			name,
+			err,
+		)
+	}
+
+	logger.Debugf("read secret [%s]", name)
+
+	return value, true, nil
+}
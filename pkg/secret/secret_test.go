@@ -0,0 +1,327 @@
+package secret
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+func TestEnvProvider(t *testing.T) {
+	t.Setenv("KEEP_STORAGE_PASSWORD", "storage-secret")
+
+	provider, err := NewProvider(Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSecret(t, provider, StoragePassword, "storage-secret")
+	assertSecretNotFound(t, provider, EthereumKey)
+}
+
+func TestEnvVariable(t *testing.T) {
+	testutils.AssertStringsEqual(
+		t,
+		"environment variable",
+		"KEEP_ETHEREUM_PASSWORD",
+		EnvVariable(EthereumPassword),
+	)
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.WriteFile(
+		filepath.Join(dir, EthereumPassword),
+		[]byte("ethereum-secret\n"),
+		0600,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(
+		filepath.Join(dir, StoragePassword),
+		[]byte("storage-secret"),
+		0644,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewProvider(Config{Provider: FileProvider, Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSecret(t, provider, EthereumPassword, "ethereum-secret")
+	assertSecretNotFound(t, provider, EthereumKey)
+
+	if _, err := provider.Secret(
+		context.Background(),
+		StoragePassword,
+	); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected error for secret file readable by other users")
+	}
+
+	if _, err := provider.Secret(
+		context.Background(),
+		"../"+EthereumPassword,
+	); err == nil {
+		t.Errorf("expected error for secret outside of the directory")
+	}
+}
+
+func TestAgentProvider(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	secrets := map[string]string{
+		EthereumPassword: "ethereum-secret",
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				line, err := bufio.NewReader(conn).ReadBytes('\n')
+				if err != nil {
+					return
+				}
+
+				var request AgentRequest
+				response := AgentResponse{}
+				if err := json.Unmarshal(line, &request); err != nil {
+					response.Error = err.Error()
+				} else if request.Name == "broken" {
+					response.Error = "agent is locked"
+				} else if value, ok := secrets[request.Name]; ok {
+					response.Found = true
+					response.Value = []byte(value)
+				}
+
+				_ = json.NewEncoder(conn).Encode(&response)
+			}()
+		}
+	}()
+
+	provider, err := NewProvider(Config{
+		Provider:    AgentProvider,
+		AgentSocket: socket,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSecret(t, provider, EthereumPassword, "ethereum-secret")
+	assertSecretNotFound(t, provider, EthereumKey)
+
+	if _, err := provider.Secret(context.Background(), "broken"); err == nil {
+		t.Errorf("expected agent error")
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	var tests = map[string]struct {
+		path     string
+		response string
+	}{
+		"key-value version 1": {
+			path:     "kv/keep",
+			response: `{"data":{"ethereum-password":"ethereum-secret"}}`,
+		},
+		"key-value version 2": {
+			path: "secret/data/keep",
+			response: `{"data":{"data":{"ethereum-password":"ethereum-secret"},` +
+				`"metadata":{"version":3}}}`,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("X-Vault-Token") != "test-token" {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+
+					if r.URL.Path != "/v1/"+test.path {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+
+					_, _ = w.Write([]byte(test.response))
+				},
+			))
+			defer server.Close()
+
+			t.Setenv(vaultTokenEnvVariable, "test-token")
+
+			provider, err := NewProvider(Config{
+				Provider: VaultProvider,
+				Vault: VaultConfig{
+					URL:  server.URL,
+					Path: test.path,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertSecret(t, provider, EthereumPassword, "ethereum-secret")
+			assertSecretNotFound(t, provider, EthereumKey)
+
+			// A mistyped path must not be mistaken for a missing secret.
+			provider, err = NewProvider(Config{
+				Provider: VaultProvider,
+				Vault: VaultConfig{
+					URL:  server.URL,
+					Path: test.path + "-mistyped",
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := provider.Secret(
+				context.Background(),
+				EthereumPassword,
+			); err == nil || errors.Is(err, ErrNotFound) {
+				t.Errorf("expected error for missing secret path; got [%v]", err)
+			}
+		})
+	}
+}
+
+func TestVaultProvider_TokenFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "file-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"data":{"storage-password":"storage-secret"}}`))
+		},
+	))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := Config{
+		Provider: VaultProvider,
+		Vault: VaultConfig{
+			URL:       server.URL,
+			Path:      "kv/keep",
+			TokenFile: tokenFile,
+		},
+	}
+
+	provider, err := NewProvider(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertSecret(t, provider, StoragePassword, "storage-secret")
+
+	// A wrong token must not be mistaken for a missing secret.
+	if err := os.WriteFile(tokenFile, []byte("wrong-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err = NewProvider(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := provider.Secret(
+		context.Background(),
+		StoragePassword,
+	); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected error for wrong token")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	var tests = map[string]struct {
+		config      Config
+		expectError bool
+	}{
+		"default": {
+			config: Config{},
+		},
+		"file": {
+			config: Config{Provider: FileProvider, Dir: "/secrets"},
+		},
+		"file without directory": {
+			config:      Config{Provider: FileProvider},
+			expectError: true,
+		},
+		"agent without socket": {
+			config:      Config{Provider: AgentProvider},
+			expectError: true,
+		},
+		"vault without path": {
+			config: Config{
+				Provider: VaultProvider,
+				Vault:    VaultConfig{URL: "http://localhost:8200"},
+			},
+			expectError: true,
+		},
+		"unsupported": {
+			config:      Config{Provider: "keychain"},
+			expectError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			err := test.config.Validate()
+			if test.expectError != (err != nil) {
+				t.Errorf(
+					"unexpected error\nexpected error: %v\nactual:         %v",
+					test.expectError,
+					err,
+				)
+			}
+		})
+	}
+}
+
+func assertSecret(t *testing.T, provider Provider, name string, expected string) {
+	value, err := provider.Secret(context.Background(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertStringsEqual(t, "secret "+name, expected, string(value))
+}
+
+func assertSecretNotFound(t *testing.T, provider Provider, name string) {
+	if _, err := provider.Secret(
+		context.Background(),
+		name,
+	); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected secret [%s] not found; got [%v]", name, err)
+	}
+}
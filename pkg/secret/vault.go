@@ -0,0 +1,143 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// vaultTokenEnvVariable is the environment variable the Vault token is read
+// from if the token file is not set.
+//
+// #nosec G101 (look for hardcoded credentials)
+// This line doesn't contain any credentials.
+// It's just the name of the environment variable.
+const vaultTokenEnvVariable = "VAULT_TOKEN"
+
+// vaultMaxResponseSize is the maximum size of the Vault response body.
+const vaultMaxResponseSize = 1 << 20
+
+// vaultProvider reads secrets from the fields of a single secret kept in
+// the HashiCorp Vault-compatible key-value secrets engine. Both version 1
+// and version 2 engines are supported. Only a field missing in the secret
+// is reported as a missing secret. The secret itself missing at the
+// configured path is an error, so a mistyped path does not make the client
+// silently fall back to other sources of secrets.
+type vaultProvider struct {
+	path   string
+	url    string
+	token  string
+	client *http.Client
+}
+
+func newVaultProvider(
+	config VaultConfig,
+	timeout time.Duration,
+) (*vaultProvider, error) {
+	token := os.Getenv(vaultTokenEnvVariable)
+	if config.TokenFile != "" {
+		content, err := os.ReadFile(filepath.Clean(config.TokenFile))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read vault token file: [%w]", err)
+		}
+
+		token = strings.TrimSpace(string(content))
+	}
+
+	if token == "" {
+		return nil, fmt.Errorf(
+			"missing vault token; set the token file or the %s "+
+				"environment variable",
+			vaultTokenEnvVariable,
+		)
+	}
+
+	return &vaultProvider{
+		path: config.Path,
+		url: strings.TrimRight(config.URL, "/") + "/v1/" +
+			strings.Trim(config.Path, "/"),
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (vp *vaultProvider) Secret(ctx context.Context, name string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, vp.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("X-Vault-Token", vp.token)
+
+	response, err := vp.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("cannot reach vault: [%w]", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, vaultMaxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read vault response: [%w]", err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf(
+			"vault secret not found at path [%s]",
+			vp.path,
+		)
+	default:
+		return nil, fmt.Errorf(
+			"unexpected vault response status [%s]: [%s]",
+			response.Status,
+			strings.TrimSpace(string(body)),
+		)
+	}
+
+	fields, err := parseVaultSecret(body)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := fields[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return []byte(value), nil
+}
+
+// parseVaultSecret parses the fields of the key-value secret from the
+// Vault response body.
+func parseVaultSecret(body []byte) (map[string]string, error) {
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("cannot parse vault response: [%w]", err)
+	}
+
+	// The version 2 engine nests the secret fields in the data along with
+	// the secret metadata.
+	var versioned struct {
+		Data     map[string]string `json:"data"`
+		Metadata json.RawMessage   `json:"metadata"`
+	}
+	if err := json.Unmarshal(response.Data, &versioned); err == nil &&
+		versioned.Metadata != nil {
+		return versioned.Data, nil
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal(response.Data, &fields); err != nil {
+		return nil, fmt.Errorf("cannot parse vault secret: [%w]", err)
+	}
+
+	return fields, nil
+}
@@ -3,6 +3,7 @@ package sortition
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ipfs/go-log"
@@ -14,31 +15,82 @@ const (
 
 var errOperatorUnknown = fmt.Errorf("operator not registered for the staking provider, check Threshold dashboard")
 
+// OperatorStatus is the status of the operator in the sortition pool
+// determined by the last status check.
+type OperatorStatus struct {
+	// CheckedAt is the time of the status check.
+	CheckedAt time.Time
+	// IsOperatorInPool is true if the operator is in the sortition pool.
+	IsOperatorInPool bool
+	// IsOperatorUpToDate is true if the operator's weight in the sortition
+	// pool is in sync with their authorized stake.
+	IsOperatorUpToDate bool
+	// IsEligibleForRewards is true if the operator is eligible for rewards.
+	// Determined only if the operator is in the sortition pool.
+	IsEligibleForRewards bool
+	// Err is the error that interrupted the status check, if any.
+	Err error
+}
+
+// PoolMonitor exposes the results of the periodic operator status checks
+// run by MonitorPool.
+type PoolMonitor struct {
+	mutex  sync.RWMutex
+	status *OperatorStatus
+}
+
+// Status returns the operator status determined by the last status check
+// or nil if no check completed yet.
+func (pm *PoolMonitor) Status() *OperatorStatus {
+	pm.mutex.RLock()
+	defer pm.mutex.RUnlock()
+
+	return pm.status
+}
+
+func (pm *PoolMonitor) checkOperatorStatus(
+	logger log.StandardLogger,
+	chain Chain,
+	policy JoinPolicy,
+) {
+	status := &OperatorStatus{CheckedAt: time.Now()}
+
+	err := checkOperatorStatus(logger, chain, policy, status)
+	if err != nil {
+		logger.Errorf("could not check operator sortition pool status: [%v]", err)
+		status.Err = err
+	}
+
+	pm.mutex.Lock()
+	pm.status = status
+	pm.mutex.Unlock()
+}
+
 // MonitorPool periodically checks the status of the operator in the sortition
 // pool. If the operator is supposed to be in the sortition pool but is not
 // there yet, the function attempts to add the operator to the pool. If the
 // operator is already in the pool and its status is no longer up to date, the
-// function attempts to update the operator's status in the pool.
+// function attempts to update the operator's status in the pool. The returned
+// PoolMonitor exposes the results of the checks.
 func MonitorPool(
 	ctx context.Context,
 	logger log.StandardLogger,
 	chain Chain,
 	tick time.Duration,
 	policy JoinPolicy,
-) error {
+) (*PoolMonitor, error) {
 	_, isRegistered, err := chain.OperatorToStakingProvider()
 	if err != nil {
-		return fmt.Errorf("could not resolve staking provider: [%w]", err)
+		return nil, fmt.Errorf("could not resolve staking provider: [%w]", err)
 	}
 
 	if !isRegistered {
-		return errOperatorUnknown
+		return nil, errOperatorUnknown
 	}
 
-	err = checkOperatorStatus(logger, chain, policy)
-	if err != nil {
-		logger.Errorf("could not check operator sortition pool status: [%v]", err)
-	}
+	monitor := &PoolMonitor{}
+
+	monitor.checkOperatorStatus(logger, chain, policy)
 
 	ticker := time.NewTicker(tick)
 
@@ -49,22 +101,21 @@ func MonitorPool(
 				ticker.Stop()
 				return
 			case <-ticker.C:
-				err = checkOperatorStatus(logger, chain, policy)
-				if err != nil {
-					logger.Errorf("could not check operator sortition pool status: [%v]", err)
-					continue
-				}
+				monitor.checkOperatorStatus(logger, chain, policy)
 			}
 		}
 	}()
 
-	return nil
+	return monitor, nil
 }
 
+// checkOperatorStatus checks the status of the operator in the sortition pool
+// and records it in the given status.
 func checkOperatorStatus(
 	logger log.StandardLogger,
 	chain Chain,
 	policy JoinPolicy,
+	status *OperatorStatus,
 ) error {
 	logger.Info("checking sortition pool operator status")
 
@@ -72,16 +123,18 @@ func checkOperatorStatus(
 	if err != nil {
 		return err
 	}
+	status.IsOperatorInPool = isOperatorInPool
 
 	isOperatorUpToDate, err := chain.IsOperatorUpToDate()
 	if err != nil {
 		return err
 	}
+	status.IsOperatorUpToDate = isOperatorUpToDate
 
 	if isOperatorInPool {
 		logger.Info("operator is in the sortition pool")
 
-		err = checkRewardsEligibility(logger, chain)
+		status.IsEligibleForRewards, err = checkRewardsEligibility(logger, chain)
 		if err != nil {
 			logger.Errorf("could not check for rewards eligibility: [%v]", err)
 		}
@@ -130,10 +183,13 @@ func checkOperatorStatus(
 	return nil
 }
 
-func checkRewardsEligibility(logger log.StandardLogger, chain Chain) error {
+// checkRewardsEligibility checks whether the operator is eligible for rewards
+// and restores the eligibility if possible. Returns true if the operator is
+// eligible for rewards at the end of the check.
+func checkRewardsEligibility(logger log.StandardLogger, chain Chain) (bool, error) {
 	isEligibleForRewards, err := chain.IsEligibleForRewards()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if isEligibleForRewards {
@@ -147,7 +203,7 @@ func checkRewardsEligibility(logger log.StandardLogger, chain Chain) error {
 
 		canRestoreRewardEligibility, err := chain.CanRestoreRewardEligibility()
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		if canRestoreRewardEligibility {
@@ -155,12 +211,16 @@ func checkRewardsEligibility(logger log.StandardLogger, chain Chain) error {
 
 			err = chain.RestoreRewardEligibility()
 			if err != nil {
-				return err
+				return false, err
 			}
-		} else {
-			logger.Info("cannot restore eligibility for rewards yet")
+
+			return true, nil
 		}
+
+		logger.Info("cannot restore eligibility for rewards yet")
+
+		return false, nil
 	}
 
-	return nil
+	return true, nil
 }
@@ -41,7 +41,7 @@ func TestMonitorPool_NotRegisteredOperator(t *testing.T) {
 
 	localChain := local.Connect(testOperatorAddress)
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -58,7 +58,7 @@ func TestMonitorPool_NoStake(t *testing.T) {
 	localChain := local.Connect(testOperatorAddress)
 	localChain.RegisterOperator(testStakingProviderAddress, testOperatorAddress)
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -87,7 +87,7 @@ func TestMonitor_JoinPool(t *testing.T) {
 	localChain.RegisterOperator(testStakingProviderAddress, testOperatorAddress)
 	localChain.SetEligibleStake(testStakingProviderAddress, big.NewInt(100))
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -116,7 +116,7 @@ func TestMonitor_JoinPool_PolicyNotSatisfied(t *testing.T) {
 	localChain.RegisterOperator(testStakingProviderAddress, testOperatorAddress)
 	localChain.SetEligibleStake(testStakingProviderAddress, big.NewInt(100))
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -148,7 +148,7 @@ func TestMonitor_UpdatePool(t *testing.T) {
 
 	localChain.SetEligibleStake(testStakingProviderAddress, big.NewInt(101))
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -175,7 +175,7 @@ func TestMonitor_JoinPool_WithDelay(t *testing.T) {
 	localChain := local.Connect(testOperatorAddress)
 	localChain.RegisterOperator(testStakingProviderAddress, testOperatorAddress)
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -217,7 +217,7 @@ func TestMonitor_UpdatePool_WithDelay(t *testing.T) {
 	localChain.SetEligibleStake(testStakingProviderAddress, big.NewInt(100))
 	localChain.JoinSortitionPool()
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -263,7 +263,7 @@ func TestMonitor_CannotRestoreRewardsEligibility_TimeNotPassed(t *testing.T) {
 	localChain.SetRewardIneligibility(big.NewInt(1))
 	localChain.SetCurrentTimestamp(big.NewInt(0))
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -297,7 +297,7 @@ func TestMonitor_CanRestoreRewardsEligibility(t *testing.T) {
 	localChain.SetRewardIneligibility(big.NewInt(1))
 	localChain.SetCurrentTimestamp(big.NewInt(2))
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx, &testutils.MockLogger{}, localChain, statusCheckTick, UnconditionalJoinPolicy)
 	if err != nil {
 		t.Fatal(err)
@@ -326,7 +326,7 @@ func TestMonitor_CanRestoreRewardsEligibility_WithDelay(t *testing.T) {
 	localChain.SetRewardIneligibility(big.NewInt(1))
 	localChain.SetCurrentTimestamp(big.NewInt(0))
 
-	err := MonitorPool(
+	_, err := MonitorPool(
 		ctx,
 		&testutils.MockLogger{},
 		localChain,
@@ -356,3 +356,41 @@ type neverJoinPolicy struct{}
 func (njp *neverJoinPolicy) ShouldJoin() bool {
 	return false
 }
+
+func TestMonitorPool_Status(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	localChain := local.Connect(testOperatorAddress)
+	localChain.RegisterOperator(testStakingProviderAddress, testOperatorAddress)
+	localChain.SetEligibleStake(testStakingProviderAddress, big.NewInt(100))
+	localChain.JoinSortitionPool()
+
+	monitor, err := MonitorPool(
+		ctx,
+		&testutils.MockLogger{},
+		localChain,
+		statusCheckTick,
+		UnconditionalJoinPolicy,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := monitor.Status()
+	if status == nil {
+		t.Fatal("expected the operator status to be determined")
+	}
+
+	testutils.AssertBoolsEqual(t, "in pool", true, status.IsOperatorInPool)
+	testutils.AssertBoolsEqual(t, "up to date", true, status.IsOperatorUpToDate)
+	testutils.AssertBoolsEqual(
+		t,
+		"eligible for rewards",
+		true,
+		status.IsEligibleForRewards,
+	)
+	if status.Err != nil {
+		t.Errorf("unexpected error: [%v]", status.Err)
+	}
+}
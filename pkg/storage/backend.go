@@ -0,0 +1,152 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	// DiskBackend is the storage backend keeping each entry in a separate
+	// file on disk.
+	DiskBackend = "disk"
+	// BoltBackend is the storage backend keeping entries in embedded
+	// transactional key-value databases.
+	BoltBackend = "bolt"
+)
+
+// Backend is a storage backend providing raw, not encrypted, persistence
+// handles for the key store and work data.
+type Backend interface {
+	// KeyStoreHandle returns a handle for the key store data kept under the
+	// given directory, e.g. `tbtc`.
+	KeyStoreHandle(dir string) (persistence.ProtectedHandle, error)
+
+	// WorkHandle returns a handle for the work data kept under the given
+	// directory, e.g. `tbtc`.
+	WorkHandle(dir string) (persistence.BasicHandle, error)
+
+	// ExportKeyStore returns all the key store entries, including archived
+	// ones, keyed by slash separated paths following the disk layout, e.g.
+	// `tbtc/current/<directory>/<name>`.
+	ExportKeyStore() (map[string][]byte, error)
+
+	// ImportKeyStore writes the given key store entries, keyed the same way
+	// as returned by ExportKeyStore. Returns an error if the key store is not
+	// empty.
+	ImportKeyStore(entries map[string][]byte) error
+
+	// ExportAll returns all the entries kept by the backend, including work
+	// data and entries internal to the backend, keyed by slash separated
+	// paths starting with the `keystore` or `work` directory name.
+	ExportAll() (map[string][]byte, error)
+
+	// ImportAll writes the given entries, keyed the same way as returned by
+	// ExportAll. Returns an error if the backend is not empty.
+	ImportAll(entries map[string][]byte) error
+
+	// Close releases resources held by the backend.
+	Close() error
+}
+
+// newBackend locks the storage directory with the shared or exclusive lock and
+// creates the storage backend set in the config. The lock is held until the
+// backend is closed. If the password rotation was interrupted, it is recovered
+// first.
+func newBackend(config Config, exclusive bool) (Backend, error) {
+	lock, err := lockDirectory(filepath.Clean(config.Dir), exclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := openBackend(config)
+	if err != nil {
+		_ = lock.release()
+		return nil, err
+	}
+
+	return &lockedBackend{Backend: backend, lock: lock}, nil
+}
+
+// openBackend creates the storage backend set in the config without locking
+// the storage directory. If the password rotation was interrupted, it is
+// recovered first. The caller must hold the lock on the storage directory.
+func openBackend(config Config) (Backend, error) {
+	if err := recoverRotation(filepath.Clean(config.Dir)); err != nil {
+		return nil, fmt.Errorf(
+			"cannot recover interrupted password rotation: [%w]",
+			err,
+		)
+	}
+
+	switch config.Backend {
+	case "", DiskBackend:
+		if err := checkKeyStoreBackend(config.Dir, DiskBackend); err != nil {
+			return nil, err
+		}
+		return newDiskBackend(config.Dir)
+	case BoltBackend:
+		if err := checkKeyStoreBackend(config.Dir, BoltBackend); err != nil {
+			return nil, err
+		}
+		return newBoltBackend(config.Dir)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend [%s]", config.Backend)
+	}
+}
+
+// checkKeyStoreBackend makes sure the keystore directory under the given
+// storage directory does not hold data of a storage backend other than the
+// given one. Otherwise, the backend would open an empty key store next to
+// the existing one.
+func checkKeyStoreBackend(dir string, backend string) error {
+	keyStoreDir := filepath.Join(filepath.Clean(dir), keyStoreDirName)
+
+	entries, err := os.ReadDir(keyStoreDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read keystore directory: [%w]", err)
+	}
+
+	for _, entry := range entries {
+		foundBackend := DiskBackend
+		if entry.Name() == keyStoreDatabaseName {
+			foundBackend = BoltBackend
+		}
+
+		if foundBackend != backend {
+			return fmt.Errorf(
+				"keystore directory [%s] holds data of the [%s] storage "+
+					"backend but the [%s] backend is set; to change the "+
+					"backend, back up the keystore with the `keystore "+
+					"backup` command and restore it with the `keystore "+
+					"restore` command into an empty storage directory",
+				keyStoreDir,
+				foundBackend,
+				backend,
+			)
+		}
+	}
+
+	return nil
+}
+
+// ValidateBackend checks whether the storage backend set in the config is
+// supported.
+func (c Config) ValidateBackend() error {
+	switch c.Backend {
+	case "", DiskBackend, BoltBackend:
+		return nil
+	default:
+		return fmt.Errorf(
+			"unsupported storage backend [%s]; expected one of: %s, %s",
+			c.Backend,
+			DiskBackend,
+			BoltBackend,
+		)
+	}
+}
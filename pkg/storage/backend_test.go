@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOpenBackend_OtherBackendData(t *testing.T) {
+	var tests = map[string]struct {
+		writtenBackend string
+		openedBackend  string
+		expectedError  bool
+	}{
+		"same backend - disk": {
+			writtenBackend: DiskBackend,
+			openedBackend:  "",
+		},
+		"same backend - bolt": {
+			writtenBackend: BoltBackend,
+			openedBackend:  BoltBackend,
+		},
+		"disk data opened with bolt": {
+			writtenBackend: DiskBackend,
+			openedBackend:  BoltBackend,
+			expectedError:  true,
+		},
+		"bolt data opened with disk": {
+			writtenBackend: BoltBackend,
+			openedBackend:  DiskBackend,
+			expectedError:  true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			dir := t.TempDir()
+
+			backend, err := openBackend(Config{
+				Dir:     dir,
+				Backend: test.writtenBackend,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			handle, err := backend.KeyStoreHandle("tbtc")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = handle.Save([]byte("member-1"), "wallet-1", "membership_1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := backend.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			backend, err = openBackend(Config{
+				Dir:     dir,
+				Backend: test.openedBackend,
+			})
+			if !test.expectedError {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := backend.Close(); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), "keystore restore") {
+				t.Errorf("error does not point to the restore: [%v]", err)
+			}
+		})
+	}
+}
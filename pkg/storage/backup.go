@@ -0,0 +1,399 @@
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+)
+
+const (
+	// backupMagic identifies key store backup archives.
+	backupMagic = "KEEPKSB"
+	// backupVersion is the version of the key store backup archive format.
+	backupVersion = byte(1)
+	// backupSaltLength is the byte length of the salt used to derive the
+	// backup encryption key from the backup password.
+	backupSaltLength = 32
+	// backupManifestName is the name of the manifest entry in the backup
+	// archive.
+	backupManifestName = "manifest.json"
+	// backupKeyStorePrefix is the prefix of key store entries in the backup
+	// archive.
+	backupKeyStorePrefix = "keystore/"
+
+	// Parameters of the scrypt key derivation function used to derive the
+	// backup encryption key from the backup password.
+	backupScryptN = 1 << 16
+	backupScryptR = 8
+	backupScryptP = 1
+)
+
+// BackupFileInfo describes a single key store file included in the backup.
+type BackupFileInfo struct {
+	// Path of the file relative to the key store directory, with slash
+	// separated elements, e.g. `tbtc/current/<wallet>/membership_1`.
+	Path string `json:"path"`
+	// Size of the file in bytes.
+	Size int `json:"size"`
+	// SHA256 is the hex-encoded SHA-256 hash of the file content.
+	SHA256 string `json:"sha256"`
+}
+
+// BackupManifest describes the content of the key store backup.
+type BackupManifest struct {
+	Version   int              `json:"version"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Files     []BackupFileInfo `json:"files"`
+}
+
+// KeyStoreBackup is a decrypted key store backup whose content has been
+// checked against the backup manifest.
+type KeyStoreBackup struct {
+	Manifest BackupManifest
+	files    map[string][]byte
+}
+
+// Content returns the content of the key store file with the given path,
+// as stored on disk. Key store files are encrypted with the key store
+// encryption password. Returns nil if there is no such file in the backup.
+func (ksb *KeyStoreBackup) Content(path string) []byte {
+	return ksb.files[path]
+}
+
+// Decrypt returns the decrypted content of the key store file with the given
+// path. The given password must be the one the key store was encrypted with.
+func (ksb *KeyStoreBackup) Decrypt(path string, encryptionPassword string) (
+	[]byte,
+	error,
+) {
+	content, ok := ksb.files[path]
+	if !ok {
+		return nil, fmt.Errorf("file [%s] not found in the backup", path)
+	}
+
+	box := encryption.NewBox(sha256.Sum256([]byte(encryptionPassword)))
+
+	return box.Decrypt(content)
+}
+
+// BackupKeyStore writes an encrypted backup of all the key store files,
+// current and archived, found in the storage directory to the given writer.
+// The backup is encrypted with a key derived from the given backup password.
+// The returned manifest describes the backup content.
+func BackupKeyStore(
+	config Config,
+	writer io.Writer,
+	backupPassword string,
+) (*BackupManifest, error) {
+	backend, err := newBackend(config, false)
+	if err != nil {
+		return nil, err
+	}
+	defer backend.Close()
+
+	files, err := backend.ExportKeyStore()
+	if err != nil {
+		return nil, fmt.Errorf("cannot read key store: [%w]", err)
+	}
+
+	paths := make([]string, 0, len(files))
+	for backupPath := range files {
+		paths = append(paths, backupPath)
+	}
+	sort.Strings(paths)
+
+	manifest := &BackupManifest{
+		Version:   int(backupVersion),
+		CreatedAt: time.Now().UTC(),
+		Files:     make([]BackupFileInfo, 0, len(paths)),
+	}
+	for _, backupPath := range paths {
+		manifest.Files = append(
+			manifest.Files,
+			newBackupFileInfo(backupPath, files[backupPath]),
+		)
+	}
+
+	archive, err := packBackup(manifest, files)
+	if err != nil {
+		return nil, fmt.Errorf("cannot pack backup archive: [%w]", err)
+	}
+
+	salt := make([]byte, backupSaltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, fmt.Errorf("cannot generate backup salt: [%w]", err)
+	}
+
+	box, err := newBackupBox(backupPassword, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := box.Encrypt(archive)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encrypt backup archive: [%w]", err)
+	}
+
+	header := append([]byte(backupMagic), backupVersion)
+	header = append(header, salt...)
+
+	if _, err := writer.Write(append(header, ciphertext...)); err != nil {
+		return nil, fmt.Errorf("cannot write backup archive: [%w]", err)
+	}
+
+	return manifest, nil
+}
+
+// ReadKeyStoreBackup reads and decrypts the key store backup from the given
+// reader using the given backup password. The backup content is checked
+// against the backup manifest and an error is returned if any of the files
+// is missing, unexpected or corrupted.
+func ReadKeyStoreBackup(
+	reader io.Reader,
+	backupPassword string,
+) (*KeyStoreBackup, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read backup archive: [%w]", err)
+	}
+
+	headerLength := len(backupMagic) + 1 + backupSaltLength
+	if len(data) < headerLength ||
+		string(data[:len(backupMagic)]) != backupMagic {
+		return nil, fmt.Errorf("not a key store backup archive")
+	}
+
+	if version := data[len(backupMagic)]; version != backupVersion {
+		return nil, fmt.Errorf(
+			"unsupported backup archive version [%v]",
+			version,
+		)
+	}
+
+	salt := data[len(backupMagic)+1 : headerLength]
+
+	box, err := newBackupBox(backupPassword, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := box.Decrypt(data[headerLength:])
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot decrypt backup archive; wrong password or corrupted "+
+				"archive: [%w]",
+			err,
+		)
+	}
+
+	manifest, files, err := unpackBackup(archive)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unpack backup archive: [%w]", err)
+	}
+
+	if len(files) != len(manifest.Files) {
+		return nil, fmt.Errorf(
+			"backup archive contains [%v] files but manifest lists [%v]",
+			len(files),
+			len(manifest.Files),
+		)
+	}
+
+	for _, fileInfo := range manifest.Files {
+		content, ok := files[fileInfo.Path]
+		if !ok {
+			return nil, fmt.Errorf(
+				"file [%s] listed in the manifest is missing",
+				fileInfo.Path,
+			)
+		}
+
+		if newBackupFileInfo(fileInfo.Path, content) != fileInfo {
+			return nil, fmt.Errorf(
+				"file [%s] does not match the manifest",
+				fileInfo.Path,
+			)
+		}
+	}
+
+	return &KeyStoreBackup{
+		Manifest: *manifest,
+		files:    files,
+	}, nil
+}
+
+// RestoreKeyStore restores the given key store backup into the storage
+// directory, using the backend set in the config. To not overwrite or mix
+// key shares, the key store must be empty and the storage must not be used
+// by a running client.
+func RestoreKeyStore(config Config, backup *KeyStoreBackup) error {
+	backend, err := newBackend(config, true)
+	if err != nil {
+		return err
+	}
+	defer backend.Close()
+
+	if err := backend.ImportKeyStore(backup.files); err != nil {
+		return fmt.Errorf(
+			"%w; restore requires a fresh storage directory",
+			err,
+		)
+	}
+
+	return nil
+}
+
+func newBackupFileInfo(path string, content []byte) BackupFileInfo {
+	hash := sha256.Sum256(content)
+
+	return BackupFileInfo{
+		Path:   path,
+		Size:   len(content),
+		SHA256: hex.EncodeToString(hash[:]),
+	}
+}
+
+func newBackupBox(backupPassword string, salt []byte) (encryption.Box, error) {
+	key, err := scrypt.Key(
+		[]byte(backupPassword),
+		salt,
+		backupScryptN,
+		backupScryptR,
+		backupScryptP,
+		encryption.KeyLength,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive backup encryption key: [%w]", err)
+	}
+
+	var boxKey [encryption.KeyLength]byte
+	copy(boxKey[:], key)
+
+	return encryption.NewBox(boxKey), nil
+}
+
+// packBackup creates a gzipped tar archive with the manifest and all the
+// given key store files.
+func packBackup(
+	manifest *BackupManifest,
+	files map[string][]byte,
+) ([]byte, error) {
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal manifest: [%w]", err)
+	}
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	writeEntry := func(name string, content []byte) error {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0600,
+			Size:    int64(len(content)),
+			ModTime: manifest.CreatedAt,
+		}); err != nil {
+			return err
+		}
+
+		_, err := tarWriter.Write(content)
+		return err
+	}
+
+	if err := writeEntry(backupManifestName, manifestBytes); err != nil {
+		return nil, err
+	}
+
+	for _, fileInfo := range manifest.Files {
+		if err := writeEntry(
+			backupKeyStorePrefix+fileInfo.Path,
+			files[fileInfo.Path],
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// unpackBackup reads the manifest and key store files from the given gzipped
+// tar archive.
+func unpackBackup(archive []byte) (
+	*BackupManifest,
+	map[string][]byte,
+	error,
+) {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+
+	var manifest *BackupManifest
+	files := make(map[string][]byte)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case header.Name == backupManifestName:
+			manifest = &BackupManifest{}
+			if err := json.Unmarshal(content, manifest); err != nil {
+				return nil, nil, fmt.Errorf(
+					"cannot unmarshal manifest: [%w]",
+					err,
+				)
+			}
+		case strings.HasPrefix(header.Name, backupKeyStorePrefix):
+			files[path.Clean(
+				strings.TrimPrefix(header.Name, backupKeyStorePrefix),
+			)] = content
+		default:
+			return nil, nil, fmt.Errorf(
+				"unexpected archive entry [%s]",
+				header.Name,
+			)
+		}
+	}
+
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("manifest not found")
+	}
+
+	return manifest, files, nil
+}
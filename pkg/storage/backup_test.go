@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+const (
+	testEncryptionPassword = "encryption-password"
+	testBackupPassword     = "backup-password"
+)
+
+func TestBackupKeyStore_ReadKeyStoreBackup(t *testing.T) {
+	config := setupKeyStore(t)
+
+	var archive bytes.Buffer
+	manifest, err := BackupKeyStore(config, &archive, testBackupPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{
+		"tbtc/archive/wallet-1/membership_1",
+		"tbtc/current/wallet-2/membership_2",
+		"tbtc/current/wallet-2/membership_3",
+	}
+
+	actualPaths := make([]string, 0)
+	for _, fileInfo := range manifest.Files {
+		actualPaths = append(actualPaths, fileInfo.Path)
+	}
+
+	if !reflect.DeepEqual(expectedPaths, actualPaths) {
+		t.Errorf(
+			"unexpected backup files\nexpected: %v\nactual:   %v",
+			expectedPaths,
+			actualPaths,
+		)
+	}
+
+	backup, err := ReadKeyStoreBackup(
+		bytes.NewReader(archive.Bytes()),
+		testBackupPassword,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(*manifest, backup.Manifest) {
+		t.Errorf("read manifest differs from the written one")
+	}
+
+	content, err := backup.Decrypt(
+		"tbtc/current/wallet-2/membership_3",
+		testEncryptionPassword,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertBytesEqual(t, []byte("member-3"), content)
+
+	if _, err := backup.Decrypt(
+		"tbtc/current/wallet-2/membership_3",
+		"wrong-password",
+	); err == nil {
+		t.Errorf("expected decryption error for wrong encryption password")
+	}
+}
+
+func TestReadKeyStoreBackup_Invalid(t *testing.T) {
+	config := setupKeyStore(t)
+
+	var archive bytes.Buffer
+	if _, err := BackupKeyStore(config, &archive, testBackupPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	corrupted := append([]byte{}, archive.Bytes()...)
+	corrupted[len(corrupted)-1] ^= 0xff
+
+	var tests = map[string]struct {
+		archive  []byte
+		password string
+	}{
+		"wrong password": {
+			archive:  archive.Bytes(),
+			password: "wrong-password",
+		},
+		"corrupted archive": {
+			archive:  corrupted,
+			password: testBackupPassword,
+		},
+		"not an archive": {
+			archive:  []byte("definitely not a backup archive"),
+			password: testBackupPassword,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			_, err := ReadKeyStoreBackup(
+				bytes.NewReader(test.archive),
+				test.password,
+			)
+			if err == nil {
+				t.Fatal("expected backup read error")
+			}
+		})
+	}
+}
+
+func TestRestoreKeyStore(t *testing.T) {
+	config := setupKeyStore(t)
+
+	var archive bytes.Buffer
+	if _, err := BackupKeyStore(config, &archive, testBackupPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := ReadKeyStoreBackup(&archive, testBackupPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restoreConfig := Config{Dir: t.TempDir()}
+	if err := RestoreKeyStore(restoreConfig, backup); err != nil {
+		t.Fatal(err)
+	}
+
+	storage, err := Initialize(restoreConfig, testEncryptionPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handle, err := storage.InitializeKeyStorePersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	descriptors, errs := handle.ReadAll()
+	go func() {
+		for err := range errs {
+			t.Errorf("unexpected read error: [%v]", err)
+		}
+	}()
+
+	restored := make(map[string]string)
+	for descriptor := range descriptors {
+		content, err := descriptor.Content()
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored[descriptor.Directory()+"/"+descriptor.Name()] = string(content)
+	}
+
+	// Archived wallets are not read by the handle but must be restored.
+	expectedRestored := map[string]string{
+		"wallet-2/membership_2": "member-2",
+		"wallet-2/membership_3": "member-3",
+	}
+	if !reflect.DeepEqual(expectedRestored, restored) {
+		t.Errorf(
+			"unexpected restored files\nexpected: %v\nactual:   %v",
+			expectedRestored,
+			restored,
+		)
+	}
+
+	if _, err := os.Stat(filepath.Join(
+		restoreConfig.Dir,
+		keyStoreDirName,
+		"tbtc/archive/wallet-1/membership_1",
+	)); err != nil {
+		t.Errorf("archived wallet not restored: [%v]", err)
+	}
+
+	// Restoring again must not overwrite the existing key store.
+	if err := RestoreKeyStore(restoreConfig, backup); err == nil {
+		t.Errorf("expected error when restoring into non-empty key store")
+	}
+}
+
+// setupKeyStore creates a key store with a current wallet having two
+// members and an archived wallet having one member.
+func setupKeyStore(t *testing.T) Config {
+	config := Config{Dir: t.TempDir()}
+
+	storage, err := Initialize(config, testEncryptionPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	handle, err := storage.InitializeKeyStorePersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := []struct{ directory, name, content string }{
+		{"wallet-1", "membership_1", "member-1"},
+		{"wallet-2", "membership_2", "member-2"},
+		{"wallet-2", "membership_3", "member-3"},
+	}
+	for _, file := range files {
+		if err := handle.Save(
+			[]byte(file.content),
+			file.directory,
+			file.name,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := handle.Archive("wallet-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	return config
+}
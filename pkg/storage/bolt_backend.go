@@ -0,0 +1,673 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+const (
+	// keyStoreDatabaseName is the name of the key store database file
+	// created in the keystore directory.
+	keyStoreDatabaseName = "keystore.db"
+	// workDatabaseName is the name of the work database file created in the
+	// work directory.
+	workDatabaseName = "work.db"
+
+	// boltOpenTimeout is the maximum time to wait for the database file lock.
+	// The lock is held by the process that opened the database, so the
+	// timeout is hit if the database is used by another running client.
+	boltOpenTimeout = 5 * time.Second
+
+	// boltSchemaVersion is the version of the buckets layout stored in the
+	// databases.
+	boltSchemaVersion = uint64(1)
+)
+
+var (
+	boltMetaBucket       = []byte("meta")
+	boltSchemaVersionKey = []byte("schema_version")
+
+	boltCurrentBucket  = []byte("current")
+	boltArchiveBucket  = []byte("archive")
+	boltSnapshotBucket = []byte("snapshot")
+	boltHistoryBucket  = []byte("history")
+)
+
+// boltBackend is the storage backend keeping entries in bbolt databases, one
+// in the `keystore` and one in the `work` directory. Each write is executed
+// in a transaction so it is either fully persisted or not at all. Within a
+// database, each handle directory, e.g. `tbtc`, is a top-level bucket holding
+// a nested bucket for each entry directory.
+//
+// Key store buckets follow the disk layout and hold `current`, `archive` and
+// `snapshot` buckets. Entries overwritten in the key store are not lost but
+// kept in the `history` bucket under names suffixed with a version number.
+type boltBackend struct {
+	keyStore *bolt.DB
+	work     *bolt.DB
+}
+
+func newBoltBackend(dir string) (*boltBackend, error) {
+	storageRootDir := filepath.Clean(dir)
+
+	keyStore, err := openBoltDatabase(
+		storageRootDir,
+		keyStoreDirName,
+		keyStoreDatabaseName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open keystore database: [%w]", err)
+	}
+
+	work, err := openBoltDatabase(
+		storageRootDir,
+		workDirName,
+		workDatabaseName,
+	)
+	if err != nil {
+		_ = keyStore.Close()
+		return nil, fmt.Errorf("cannot open work database: [%w]", err)
+	}
+
+	return &boltBackend{
+		keyStore: keyStore,
+		work:     work,
+	}, nil
+}
+
+// openBoltDatabase opens the database file with the given name in the given
+// subdirectory of the storage root directory and makes sure the database
+// schema version is supported.
+func openBoltDatabase(
+	storageRootDir string,
+	dirName string,
+	databaseName string,
+) (*bolt.DB, error) {
+	if err := persistence.EnsureDirectoryExists(
+		storageRootDir,
+		dirName,
+	); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(
+		filepath.Join(storageRootDir, dirName, databaseName),
+		0600,
+		&bolt.Options{Timeout: boltOpenTimeout},
+	)
+	if err == bolt.ErrTimeout {
+		return nil, fmt.Errorf("database is used by another process")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		meta, err := tx.CreateBucketIfNotExists(boltMetaBucket)
+		if err != nil {
+			return err
+		}
+
+		if value := meta.Get(boltSchemaVersionKey); value != nil {
+			if version := binary.BigEndian.Uint64(value); version > boltSchemaVersion {
+				return fmt.Errorf(
+					"unsupported database schema version [%v]",
+					version,
+				)
+			}
+
+			return nil
+		}
+
+		return meta.Put(
+			boltSchemaVersionKey,
+			binary.BigEndian.AppendUint64(nil, boltSchemaVersion),
+		)
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// KeyStoreHandle returns a handle for the key store data kept in the bucket
+// with the given name.
+func (bb *boltBackend) KeyStoreHandle(dir string) (
+	persistence.ProtectedHandle,
+	error,
+) {
+	err := bb.keyStore.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(dir))
+		if err != nil {
+			return err
+		}
+
+		for _, name := range [][]byte{
+			boltCurrentBucket,
+			boltArchiveBucket,
+			boltSnapshotBucket,
+			boltHistoryBucket,
+		} {
+			if _, err := bucket.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create [%s] keystore bucket: [%w]", dir, err)
+	}
+
+	return &boltProtectedHandle{
+		db:     bb.keyStore,
+		bucket: []byte(dir),
+		snapshotSuffixGenerator: func() string {
+			return fmt.Sprintf(".%d", time.Now().UnixMilli())
+		},
+	}, nil
+}
+
+// WorkHandle returns a handle for the work data kept in the bucket with the
+// given name.
+func (bb *boltBackend) WorkHandle(dir string) (
+	persistence.BasicHandle,
+	error,
+) {
+	err := bb.work.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(dir))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create [%s] work bucket: [%w]", dir, err)
+	}
+
+	return &boltBasicHandle{
+		db:     bb.work,
+		bucket: []byte(dir),
+	}, nil
+}
+
+// ExportKeyStore reads all the current, archived and snapshot entries of
+// the key store database.
+func (bb *boltBackend) ExportKeyStore() (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+
+	err := bb.keyStore.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+			if bytes.Equal(name, boltMetaBucket) {
+				return nil
+			}
+
+			for _, kind := range [][]byte{
+				boltCurrentBucket,
+				boltArchiveBucket,
+				boltSnapshotBucket,
+			} {
+				err := forEachEntry(
+					bucket.Bucket(kind),
+					func(directory, entryName, content []byte) error {
+						entryPath := strings.Join([]string{
+							string(name),
+							string(kind),
+							string(directory),
+							string(entryName),
+						}, "/")
+
+						entries[entryPath] = append([]byte{}, content...)
+
+						return nil
+					},
+				)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ImportKeyStore writes all the given entries to the key store database in
+// a single transaction. The key store database must not hold any entries.
+func (bb *boltBackend) ImportKeyStore(entries map[string][]byte) error {
+	exported, err := bb.ExportKeyStore()
+	if err != nil {
+		return fmt.Errorf("cannot check key store database: [%w]", err)
+	}
+	if len(exported) > 0 {
+		return fmt.Errorf("key store database is not empty")
+	}
+
+	return bb.keyStore.Update(func(tx *bolt.Tx) error {
+		for entryPath, content := range entries {
+			elements := strings.Split(entryPath, "/")
+			if len(elements) != 4 {
+				return fmt.Errorf("invalid key store path [%s]", entryPath)
+			}
+
+			name, kind, directory, entryName := elements[0], elements[1],
+				elements[2], elements[3]
+
+			if kind != string(boltCurrentBucket) &&
+				kind != string(boltArchiveBucket) &&
+				kind != string(boltSnapshotBucket) {
+				return fmt.Errorf("invalid key store path [%s]", entryPath)
+			}
+
+			bucket, err := createBuckets(
+				tx,
+				[]byte(name),
+				[]byte(kind),
+				[]byte(directory),
+			)
+			if err != nil {
+				return err
+			}
+
+			if err := bucket.Put([]byte(entryName), content); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ExportAll reads all the entries of both databases, including previous
+// versions of the key store entries kept in the history.
+func (bb *boltBackend) ExportAll() (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+
+	for dirName, db := range map[string]*bolt.DB{
+		keyStoreDirName: bb.keyStore,
+		workDirName:     bb.work,
+	} {
+		err := db.View(func(tx *bolt.Tx) error {
+			return tx.ForEach(func(name []byte, bucket *bolt.Bucket) error {
+				if bytes.Equal(name, boltMetaBucket) {
+					return nil
+				}
+
+				return exportBucket(
+					bucket,
+					path.Join(dirName, string(name)),
+					entries,
+				)
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// ImportAll writes all the given entries, keyed the same way as returned by
+// ExportAll, in a single transaction per database. Both databases must not
+// hold any entries.
+func (bb *boltBackend) ImportAll(entries map[string][]byte) error {
+	exported, err := bb.ExportAll()
+	if err != nil {
+		return fmt.Errorf("cannot check databases: [%w]", err)
+	}
+	if len(exported) > 0 {
+		return fmt.Errorf("databases are not empty")
+	}
+
+	for dirName, db := range map[string]*bolt.DB{
+		keyStoreDirName: bb.keyStore,
+		workDirName:     bb.work,
+	} {
+		err := db.Update(func(tx *bolt.Tx) error {
+			for entryPath, content := range entries {
+				elements := strings.Split(entryPath, "/")
+				if len(elements) < 3 {
+					return fmt.Errorf("invalid storage path [%s]", entryPath)
+				}
+				if elements[0] != dirName {
+					continue
+				}
+
+				buckets := make([][]byte, 0, len(elements)-2)
+				for _, element := range elements[1 : len(elements)-1] {
+					buckets = append(buckets, []byte(element))
+				}
+
+				bucket, err := createBuckets(tx, buckets...)
+				if err != nil {
+					return err
+				}
+
+				if err := bucket.Put(
+					[]byte(elements[len(elements)-1]),
+					content,
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes both databases.
+func (bb *boltBackend) Close() error {
+	keyStoreErr := bb.keyStore.Close()
+	workErr := bb.work.Close()
+
+	if keyStoreErr != nil {
+		return keyStoreErr
+	}
+
+	return workErr
+}
+
+// boltBasicHandle is a persistence handle keeping entries in nested buckets
+// of the given top-level bucket.
+type boltBasicHandle struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+func (bbh *boltBasicHandle) Save(data []byte, directory string, name string) error {
+	return bbh.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := createBuckets(tx, bbh.bucket, []byte(directory))
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put([]byte(name), data)
+	})
+}
+
+func (bbh *boltBasicHandle) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	return readAllEntries(bbh.db, bbh.bucket)
+}
+
+func (bbh *boltBasicHandle) Delete(directory string, name string) error {
+	return bbh.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bbh.bucket)
+
+		directoryBucket := bucket.Bucket([]byte(directory))
+		if directoryBucket == nil || directoryBucket.Get([]byte(name)) == nil {
+			return fmt.Errorf("entry [%s/%s] not found", directory, name)
+		}
+
+		if err := directoryBucket.Delete([]byte(name)); err != nil {
+			return err
+		}
+
+		// Do not leave empty directories behind.
+		if key, _ := directoryBucket.Cursor().First(); key == nil {
+			return bucket.DeleteBucket([]byte(directory))
+		}
+
+		return nil
+	})
+}
+
+// boltProtectedHandle is a persistence handle keeping entries in the nested
+// buckets of the given top-level bucket, following the disk layout of the
+// protected handle.
+type boltProtectedHandle struct {
+	db     *bolt.DB
+	bucket []byte
+
+	snapshotSuffixGenerator func() string
+}
+
+func (bph *boltProtectedHandle) Save(data []byte, directory string, name string) error {
+	return bph.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := createBuckets(
+			tx,
+			bph.bucket,
+			boltCurrentBucket,
+			[]byte(directory),
+		)
+		if err != nil {
+			return err
+		}
+
+		// Keep the overwritten version in the history.
+		if previous := bucket.Get([]byte(name)); previous != nil {
+			history, err := createBuckets(
+				tx,
+				bph.bucket,
+				boltHistoryBucket,
+				[]byte(directory),
+			)
+			if err != nil {
+				return err
+			}
+
+			version, err := history.NextSequence()
+			if err != nil {
+				return err
+			}
+
+			if err := history.Put(
+				[]byte(fmt.Sprintf("%s.v%d", name, version)),
+				previous,
+			); err != nil {
+				return err
+			}
+		}
+
+		return bucket.Put([]byte(name), data)
+	})
+}
+
+func (bph *boltProtectedHandle) ReadAll() (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	return readAllEntries(bph.db, bph.bucket, boltCurrentBucket)
+}
+
+func (bph *boltProtectedHandle) Archive(directory string) error {
+	return bph.db.Update(func(tx *bolt.Tx) error {
+		current := tx.Bucket(bph.bucket).Bucket(boltCurrentBucket)
+
+		from := current.Bucket([]byte(directory))
+		if from == nil {
+			return fmt.Errorf("directory [%s] not found", directory)
+		}
+
+		to, err := createBuckets(
+			tx,
+			bph.bucket,
+			boltArchiveBucket,
+			[]byte(directory),
+		)
+		if err != nil {
+			return err
+		}
+
+		err = from.ForEach(func(name, content []byte) error {
+			return to.Put(name, content)
+		})
+		if err != nil {
+			return err
+		}
+
+		return current.DeleteBucket([]byte(directory))
+	})
+}
+
+func (bph *boltProtectedHandle) Snapshot(data []byte, directory string, name string) error {
+	snapshotName := []byte(name + bph.snapshotSuffixGenerator())
+
+	return bph.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := createBuckets(
+			tx,
+			bph.bucket,
+			boltSnapshotBucket,
+			[]byte(directory),
+		)
+		if err != nil {
+			return err
+		}
+
+		// very unlikely but better fail than overwrite an existing snapshot
+		if bucket.Get(snapshotName) != nil {
+			return fmt.Errorf(
+				"could not create unique snapshot; " +
+					"snapshot name collision has been detected",
+			)
+		}
+
+		return bucket.Put(snapshotName, data)
+	})
+}
+
+// createBuckets returns the bucket with the given path, creating all missing
+// buckets on the way.
+func createBuckets(tx *bolt.Tx, path ...[]byte) (*bolt.Bucket, error) {
+	bucket, err := tx.CreateBucketIfNotExists(path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range path[1:] {
+		bucket, err = bucket.CreateBucketIfNotExists(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bucket, nil
+}
+
+// exportBucket adds all the entries of the given bucket and all of its nested
+// buckets to the given map, keyed by slash separated paths starting with the
+// given prefix.
+func exportBucket(
+	bucket *bolt.Bucket,
+	prefix string,
+	entries map[string][]byte,
+) error {
+	return bucket.ForEach(func(name, content []byte) error {
+		entryPath := path.Join(prefix, string(name))
+
+		if content == nil {
+			return exportBucket(bucket.Bucket(name), entryPath, entries)
+		}
+
+		entries[entryPath] = append([]byte{}, content...)
+
+		return nil
+	})
+}
+
+// forEachEntry calls the given function for each entry of each directory
+// bucket nested in the given bucket. Does nothing if the bucket is nil.
+func forEachEntry(
+	bucket *bolt.Bucket,
+	fn func(directory, name, content []byte) error,
+) error {
+	if bucket == nil {
+		return nil
+	}
+
+	return bucket.ForEachBucket(func(directory []byte) error {
+		return bucket.Bucket(directory).ForEach(func(name, content []byte) error {
+			return fn(directory, name, content)
+		})
+	})
+}
+
+// readAllEntries reads all entries of the bucket with the given path in
+// a single transaction and streams them through the returned channels the
+// same way the disk persistence does.
+func readAllEntries(db *bolt.DB, path ...[]byte) (
+	<-chan persistence.DataDescriptor,
+	<-chan error,
+) {
+	descriptors := make([]persistence.DataDescriptor, 0)
+
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(path[0])
+		for _, name := range path[1:] {
+			if bucket == nil {
+				break
+			}
+			bucket = bucket.Bucket(name)
+		}
+
+		return forEachEntry(bucket, func(directory, name, content []byte) error {
+			// Values are valid only within the transaction so they must be
+			// copied.
+			descriptors = append(descriptors, &boltDataDescriptor{
+				name:      string(name),
+				directory: string(directory),
+				content:   append([]byte{}, content...),
+			})
+			return nil
+		})
+	})
+
+	outputData := make(chan persistence.DataDescriptor)
+	outputErrors := make(chan error)
+
+	go func() {
+		defer close(outputData)
+		defer close(outputErrors)
+
+		if err != nil {
+			outputErrors <- err
+			return
+		}
+
+		for _, descriptor := range descriptors {
+			outputData <- descriptor
+		}
+	}()
+
+	return outputData, outputErrors
+}
+
+type boltDataDescriptor struct {
+	name      string
+	directory string
+	content   []byte
+}
+
+func (bdd *boltDataDescriptor) Name() string {
+	return bdd.name
+}
+
+func (bdd *boltDataDescriptor) Directory() string {
+	return bdd.directory
+}
+
+func (bdd *boltDataDescriptor) Content() ([]byte, error) {
+	return bdd.content, nil
+}
@@ -0,0 +1,279 @@
+package storage
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+func TestBoltBackend_KeyStoreHandle(t *testing.T) {
+	backend := newTestBoltBackend(t)
+
+	handle, err := backend.KeyStoreHandle("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.Save([]byte("member-1"), "wallet-1", "membership_1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Save([]byte("member-2"), "wallet-2", "membership_2"); err != nil {
+		t.Fatal(err)
+	}
+	// Overwrite the entry; the previous version must land in the history.
+	if err := handle.Save([]byte("member-2-v2"), "wallet-2", "membership_2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Snapshot([]byte("snapshot-2"), "wallet-2", "membership_2"); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCurrent := map[string]string{
+		"wallet-1/membership_1": "member-1",
+		"wallet-2/membership_2": "member-2-v2",
+	}
+	assertEntries(t, expectedCurrent, readAll(t, handle))
+
+	if err := handle.Archive("wallet-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Archive("wallet-1"); err == nil {
+		t.Errorf("expected error when archiving non-existing directory")
+	}
+
+	expectedCurrent = map[string]string{
+		"wallet-2/membership_2": "member-2-v2",
+	}
+	assertEntries(t, expectedCurrent, readAll(t, handle))
+
+	exported, err := backend.ExportKeyStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshotCount := 0
+	for path, content := range exported {
+		if strings.HasPrefix(path, "tbtc/snapshot/wallet-2/membership_2.") {
+			snapshotCount++
+			if string(content) != "snapshot-2" {
+				t.Errorf("unexpected snapshot content [%s]", content)
+			}
+			delete(exported, path)
+		}
+	}
+	if snapshotCount != 1 {
+		t.Errorf("unexpected number of snapshots: [%v]", snapshotCount)
+	}
+
+	// History entries are not exported.
+	expectedExported := map[string]string{
+		"tbtc/archive/wallet-1/membership_1": "member-1",
+		"tbtc/current/wallet-2/membership_2": "member-2-v2",
+	}
+	assertEntries(t, expectedExported, exported)
+
+	history, errs := readAllEntries(
+		backend.keyStore,
+		[]byte("tbtc"),
+		boltHistoryBucket,
+	)
+	expectedHistory := map[string]string{
+		"wallet-2/membership_2.v1": "member-2",
+	}
+	assertEntries(t, expectedHistory, collectEntries(t, history, errs))
+}
+
+func TestBoltBackend_WorkHandle(t *testing.T) {
+	backend := newTestBoltBackend(t)
+
+	handle, err := backend.WorkHandle("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handle.Save([]byte("params-1"), "pre_params", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Save([]byte("params-2"), "pre_params", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"pre_params/1": "params-1",
+		"pre_params/2": "params-2",
+	}
+	assertEntries(t, expected, readAll(t, handle))
+
+	if err := handle.Delete("pre_params", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := handle.Delete("pre_params", "1"); err == nil {
+		t.Errorf("expected error when deleting non-existing entry")
+	}
+
+	expected = map[string]string{
+		"pre_params/2": "params-2",
+	}
+	assertEntries(t, expected, readAll(t, handle))
+
+	if err := handle.Delete("pre_params", "2"); err != nil {
+		t.Fatal(err)
+	}
+	assertEntries(t, map[string]string{}, readAll(t, handle))
+}
+
+func TestBoltBackend_ImportKeyStore(t *testing.T) {
+	backend := newTestBoltBackend(t)
+
+	if err := backend.ImportKeyStore(map[string][]byte{
+		"tbtc/wallet-1/membership_1": []byte("member-1"),
+	}); err == nil {
+		t.Errorf("expected error for invalid key store path")
+	}
+	if err := backend.ImportKeyStore(map[string][]byte{
+		"tbtc/history/wallet-1/membership_1": []byte("member-1"),
+	}); err == nil {
+		t.Errorf("expected error for invalid key store path")
+	}
+
+	entries := map[string][]byte{
+		"tbtc/archive/wallet-1/membership_1":       []byte("member-1"),
+		"tbtc/current/wallet-2/membership_2":       []byte("member-2"),
+		"tbtc/snapshot/wallet-2/membership_2.1000": []byte("snapshot-2"),
+		"beacon/current/group-1/membership_3":      []byte("member-3"),
+	}
+
+	if err := backend.ImportKeyStore(entries); err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := backend.ExportKeyStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(entries, exported) {
+		t.Errorf(
+			"unexpected exported entries\nexpected: %v\nactual:   %v",
+			entries,
+			exported,
+		)
+	}
+
+	if err := backend.ImportKeyStore(entries); err == nil {
+		t.Errorf("expected error when importing into non-empty key store")
+	}
+}
+
+func TestRestoreKeyStore_BoltBackend(t *testing.T) {
+	// Back up a disk key store and restore it into the bolt backend.
+	config := setupKeyStore(t)
+
+	var archive bytes.Buffer
+	if _, err := BackupKeyStore(config, &archive, testBackupPassword); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := ReadKeyStoreBackup(&archive, testBackupPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restoreConfig := Config{Dir: t.TempDir(), Backend: BoltBackend}
+	if err := RestoreKeyStore(restoreConfig, backup); err != nil {
+		t.Fatal(err)
+	}
+
+	storage, err := Initialize(restoreConfig, testEncryptionPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	handle, err := storage.InitializeKeyStorePersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"wallet-2/membership_2": "member-2",
+		"wallet-2/membership_3": "member-3",
+	}
+	assertEntries(t, expected, readAll(t, handle))
+}
+
+func newTestBoltBackend(t *testing.T) *boltBackend {
+	backend, err := newBoltBackend(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := backend.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return backend
+}
+
+func readAll(t *testing.T, handle persistence.RWHandle) map[string][]byte {
+	descriptors, errs := handle.ReadAll()
+	return collectEntries(t, descriptors, errs)
+}
+
+func collectEntries(
+	t *testing.T,
+	descriptors <-chan persistence.DataDescriptor,
+	errs <-chan error,
+) map[string][]byte {
+	entries := make(map[string][]byte)
+
+	for descriptors != nil || errs != nil {
+		select {
+		case descriptor, ok := <-descriptors:
+			if !ok {
+				descriptors = nil
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entries[descriptor.Directory()+"/"+descriptor.Name()] = content
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+
+			t.Errorf("unexpected read error: [%v]", err)
+		}
+	}
+
+	return entries
+}
+
+func assertEntries(
+	t *testing.T,
+	expected map[string]string,
+	actual map[string][]byte,
+) {
+	actualStrings := make(map[string]string)
+	for key, value := range actual {
+		actualStrings[key] = string(value)
+	}
+
+	if !reflect.DeepEqual(expected, actualStrings) {
+		t.Errorf(
+			"unexpected entries\nexpected: %v\nactual:   %v",
+			expected,
+			actualStrings,
+		)
+	}
+}
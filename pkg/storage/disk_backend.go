@@ -0,0 +1,271 @@
+package storage
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+)
+
+// diskBackend is the storage backend keeping each entry in a separate file
+// in the `keystore` and `work` directories.
+type diskBackend struct {
+	rootDir     string
+	keystoreDir string
+	workDir     string
+}
+
+func newDiskBackend(dir string) (*diskBackend, error) {
+	storageRootDir := filepath.Clean(dir)
+
+	if err := persistence.EnsureDirectoryExists(
+		storageRootDir,
+		keyStoreDirName,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"cannot create storage directory for keystore: [%w]",
+			err,
+		)
+	}
+
+	if err := persistence.EnsureDirectoryExists(
+		storageRootDir,
+		workDirName,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"cannot create storage directory for work: [%w]",
+			err,
+		)
+	}
+
+	return &diskBackend{
+		rootDir:     storageRootDir,
+		keystoreDir: filepath.Join(storageRootDir, keyStoreDirName),
+		workDir:     filepath.Join(storageRootDir, workDirName),
+	}, nil
+}
+
+// KeyStoreHandle creates a persistent directory under the keystore directory.
+func (db *diskBackend) KeyStoreHandle(dir string) (
+	persistence.ProtectedHandle,
+	error,
+) {
+	if err := persistence.EnsureDirectoryExists(db.keystoreDir, dir); err != nil {
+		return nil, fmt.Errorf(
+			"cannot create storage directory [%s] in [%s]: [%w]",
+			dir,
+			db.keystoreDir,
+			err,
+		)
+	}
+
+	path := path.Join(db.keystoreDir, dir)
+
+	diskHandle, err := persistence.NewProtectedDiskHandle(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create [%s] disk handle: [%w]", path, err)
+	}
+
+	return diskHandle, nil
+}
+
+// WorkHandle creates a persistent directory under the work directory.
+func (db *diskBackend) WorkHandle(dir string) (
+	persistence.BasicHandle,
+	error,
+) {
+	if err := persistence.EnsureDirectoryExists(db.workDir, dir); err != nil {
+		return nil, fmt.Errorf(
+			"cannot create storage directory [%s] in [%s]: [%w]",
+			dir,
+			db.workDir,
+			err,
+		)
+	}
+
+	path := path.Join(db.workDir, dir)
+
+	diskHandle, err := persistence.NewBasicDiskHandle(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create [%s] disk handle: [%w]", path, err)
+	}
+
+	return diskHandle, nil
+}
+
+// ExportKeyStore reads all the files found in the keystore directory.
+func (db *diskBackend) ExportKeyStore() (map[string][]byte, error) {
+	return readFiles(db.keystoreDir)
+}
+
+// ImportKeyStore writes the given entries as files in the keystore directory.
+// To not overwrite or mix key shares, the keystore directory must not contain
+// any files.
+func (db *diskBackend) ImportKeyStore(entries map[string][]byte) error {
+	empty, err := isDirectoryEmpty(db.keystoreDir)
+	if err != nil {
+		return fmt.Errorf("cannot check key store directory: [%w]", err)
+	}
+	if !empty {
+		return fmt.Errorf(
+			"key store directory [%s] is not empty",
+			db.keystoreDir,
+		)
+	}
+
+	return writeFiles(db.keystoreDir, entries)
+}
+
+// ExportAll reads all the files found in the keystore and work directories.
+func (db *diskBackend) ExportAll() (map[string][]byte, error) {
+	entries := make(map[string][]byte)
+
+	for _, dirName := range []string{keyStoreDirName, workDirName} {
+		files, err := readFiles(filepath.Join(db.rootDir, dirName))
+		if err != nil {
+			return nil, err
+		}
+
+		for filePath, content := range files {
+			entries[path.Join(dirName, filePath)] = content
+		}
+	}
+
+	return entries, nil
+}
+
+// ImportAll writes the given entries as files in the keystore and work
+// directories. Both directories must not contain any files.
+func (db *diskBackend) ImportAll(entries map[string][]byte) error {
+	for _, dir := range []string{db.keystoreDir, db.workDir} {
+		empty, err := isDirectoryEmpty(dir)
+		if err != nil {
+			return fmt.Errorf("cannot check directory [%s]: [%w]", dir, err)
+		}
+		if !empty {
+			return fmt.Errorf("directory [%s] is not empty", dir)
+		}
+	}
+
+	for entryPath := range entries {
+		dirName, _, _ := strings.Cut(entryPath, "/")
+		if dirName != keyStoreDirName && dirName != workDirName {
+			return fmt.Errorf("invalid storage path [%s]", entryPath)
+		}
+	}
+
+	return writeFiles(db.rootDir, entries)
+}
+
+// Close does nothing as the disk backend does not hold any resources.
+func (db *diskBackend) Close() error {
+	return nil
+}
+
+// readFiles reads all the regular files found in the given directory and its
+// subdirectories, keyed by slash separated paths relative to the directory.
+func readFiles(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	err := filepath.WalkDir(
+		dir,
+		func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+
+			relativePath, err := filepath.Rel(dir, filePath)
+			if err != nil {
+				return err
+			}
+
+			// #nosec G304 (file path provided as taint input)
+			// The path is resolved while walking the directory.
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				return err
+			}
+
+			files[filepath.ToSlash(relativePath)] = content
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// writeFiles writes the given files, keyed by slash separated paths relative
+// to the given directory, creating all the missing directories.
+func writeFiles(dir string, files map[string][]byte) error {
+	paths := make([]string, 0, len(files))
+	for filePath := range files {
+		// Do not let crafted paths write outside of the directory.
+		if !filepath.IsLocal(filepath.FromSlash(filePath)) {
+			return fmt.Errorf("invalid storage path [%s]", filePath)
+		}
+
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+
+	for _, filePath := range paths {
+		fullPath := filepath.Join(dir, filepath.FromSlash(filePath))
+
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0700); err != nil {
+			return fmt.Errorf(
+				"cannot create directory for file [%s]: [%w]",
+				filePath,
+				err,
+			)
+		}
+
+		if err := os.WriteFile(fullPath, files[filePath], 0600); err != nil {
+			return fmt.Errorf("cannot write file [%s]: [%w]", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// isDirectoryEmpty checks whether the given directory and all of its
+// subdirectories contain no files. A non-existing directory is empty.
+func isDirectoryEmpty(dir string) (bool, error) {
+	empty := true
+
+	err := filepath.WalkDir(
+		dir,
+		func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !entry.IsDir() {
+				empty = false
+				return fs.SkipAll
+			}
+
+			return nil
+		},
+	)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return empty, nil
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+)
+
+const (
+	// KeyStoreCurrent is the kind of key store entries in use.
+	KeyStoreCurrent = "current"
+	// KeyStoreArchive is the kind of archived key store entries.
+	KeyStoreArchive = "archive"
+	// KeyStoreSnapshot is the kind of key store entry snapshots.
+	KeyStoreSnapshot = "snapshot"
+)
+
+// KeyStoreEntry is a single decrypted key store entry.
+type KeyStoreEntry struct {
+	// Path of the entry with slash separated elements following the disk
+	// layout, e.g. `tbtc/current/<wallet>/membership_1`.
+	Path string
+	// Handle is the directory of the key store handle, e.g. `tbtc`.
+	Handle string
+	// Kind is the kind of the entry: current, archive or snapshot.
+	Kind string
+	// Directory is the entry directory within the handle, e.g. the wallet
+	// directory.
+	Directory string
+	// Name is the entry name.
+	Name string
+	// Content is the decrypted entry content.
+	Content []byte
+}
+
+// ReadKeyStore reads and decrypts all the key store entries, current,
+// archived and snapshots, kept in the storage directory. Unlike the other
+// storage functions, ReadKeyStore never modifies the storage directory, so
+// it refuses to read the key store if the password rotation was interrupted.
+// The returned entries are sorted by path.
+func ReadKeyStore(config Config, encryptionPassword string) (
+	[]*KeyStoreEntry,
+	error,
+) {
+	storageRootDir := filepath.Clean(config.Dir)
+
+	if _, err := os.Stat(
+		filepath.Join(storageRootDir, rotationDirName),
+	); err == nil {
+		return nil, fmt.Errorf(
+			"storage password rotation was interrupted; open the storage " +
+				"with the client or the keystore commands to recover it first",
+		)
+	}
+
+	var (
+		files map[string][]byte
+		err   error
+	)
+	switch config.Backend {
+	case "", DiskBackend:
+		files, err = readKeyStoreDirectory(storageRootDir)
+	case BoltBackend:
+		files, err = readKeyStoreDatabase(storageRootDir)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend [%s]", config.Backend)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read key store: [%w]", err)
+	}
+
+	box := encryption.NewBox(sha256.Sum256([]byte(encryptionPassword)))
+
+	entries := make([]*KeyStoreEntry, 0, len(files))
+	for entryPath, content := range files {
+		elements := strings.Split(entryPath, "/")
+		if len(elements) != 4 {
+			return nil, fmt.Errorf(
+				"unexpected key store entry path [%s]",
+				entryPath,
+			)
+		}
+
+		decrypted, err := box.Decrypt(content)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot decrypt key store entry [%s]: [%w]",
+				entryPath,
+				err,
+			)
+		}
+
+		entries = append(entries, &KeyStoreEntry{
+			Path:      entryPath,
+			Handle:    elements[0],
+			Kind:      elements[1],
+			Directory: elements[2],
+			Name:      elements[3],
+			Content:   decrypted,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Path < entries[j].Path
+	})
+
+	return entries, nil
+}
+
+// readKeyStoreDirectory reads all the files of the disk backend key store.
+// A missing key store directory is treated as an empty key store.
+func readKeyStoreDirectory(storageRootDir string) (map[string][]byte, error) {
+	keyStoreDir := filepath.Join(storageRootDir, keyStoreDirName)
+
+	if _, err := os.Stat(keyStoreDir); os.IsNotExist(err) {
+		return map[string][]byte{}, nil
+	}
+
+	return readFiles(keyStoreDir)
+}
+
+// readKeyStoreDatabase reads all the entries of the bolt backend key store.
+// The database is opened read-only so it can not be read while the client
+// holds it open. A missing database is treated as an empty key store.
+func readKeyStoreDatabase(storageRootDir string) (map[string][]byte, error) {
+	databasePath := filepath.Join(
+		storageRootDir,
+		keyStoreDirName,
+		keyStoreDatabaseName,
+	)
+
+	if _, err := os.Stat(databasePath); os.IsNotExist(err) {
+		return map[string][]byte{}, nil
+	}
+
+	db, err := bolt.Open(
+		databasePath,
+		0600,
+		&bolt.Options{Timeout: boltOpenTimeout, ReadOnly: true},
+	)
+	if err == bolt.ErrTimeout {
+		return nil, fmt.Errorf("database is used by another process")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return (&boltBackend{keyStore: db}).ExportKeyStore()
+}
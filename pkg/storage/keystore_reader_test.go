@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadKeyStore(t *testing.T) {
+	for _, backend := range []string{DiskBackend, BoltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			config := Config{Dir: t.TempDir(), Backend: backend}
+
+			setupStorage(t, config, "password", "")
+
+			storage, err := Initialize(config, "password")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			keyStoreHandle, err := storage.InitializeKeyStorePersistence("tbtc")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := keyStoreHandle.Save(
+				[]byte("member-3"),
+				"wallet-2",
+				"membership_3",
+			); err != nil {
+				t.Fatal(err)
+			}
+			if err := keyStoreHandle.Archive("wallet-2"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := storage.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			entries, err := ReadKeyStore(config, "password")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				actual = append(actual, fmt.Sprintf(
+					"%s %s %s %s %s",
+					entry.Handle,
+					entry.Kind,
+					entry.Directory,
+					entry.Name,
+					entry.Content,
+				))
+			}
+
+			expected := []string{
+				"tbtc archive wallet-2 membership_3 member-3",
+				"tbtc current wallet-1 membership_1 member-1",
+				"tbtc current wallet-1 membership_2 member-2",
+			}
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf(
+					"unexpected entries\nexpected: %v\nactual:   %v",
+					expected,
+					actual,
+				)
+			}
+
+			if _, err := ReadKeyStore(config, "wrong-password"); err == nil {
+				t.Errorf("expected error for wrong password")
+			}
+		})
+	}
+}
+
+func TestReadKeyStore_Empty(t *testing.T) {
+	for _, backend := range []string{DiskBackend, BoltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			config := Config{Dir: t.TempDir(), Backend: backend}
+
+			entries, err := ReadKeyStore(config, "password")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(entries) != 0 {
+				t.Errorf("unexpected entries: %v", entries)
+			}
+
+			// Reading must not create anything in the storage directory.
+			files, err := os.ReadDir(config.Dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(files) != 0 {
+				t.Errorf("unexpected files in storage directory: %v", files)
+			}
+		})
+	}
+}
+
+func TestReadKeyStore_InterruptedRotation(t *testing.T) {
+	config := Config{Dir: t.TempDir()}
+
+	setupStorage(t, config, "password", "")
+
+	if err := os.Mkdir(
+		filepath.Join(config.Dir, rotationDirName),
+		0700,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadKeyStore(config, "password"); err == nil {
+		t.Fatal("expected error for interrupted rotation")
+	}
+}
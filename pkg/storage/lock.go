@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// lockFileName is the name of the file in the storage directory locked by
+// the process using the storage.
+const lockFileName = ".lock"
+
+// directoryLock is a lock on the storage directory. Processes reading and
+// writing the storage entries, like the client, hold a shared lock. Processes
+// replacing the storage content as a whole, like the password rotation, hold
+// an exclusive lock. This way, the password cannot be rotated while the client
+// is running, while the key store can still be backed up. The lock is
+// released by the operating system if the process holding it exits.
+type directoryLock struct {
+	file *os.File
+}
+
+// lockDirectory acquires the shared or exclusive lock on the given storage
+// directory. Returns an error immediately if the lock cannot be acquired
+// because of a lock held by another process.
+func lockDirectory(dir string, exclusive bool) (*directoryLock, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("cannot create storage directory: [%w]", err)
+	}
+
+	// #nosec G304 (file path provided as taint input)
+	// The path is built from a constant in the storage directory.
+	file, err := os.OpenFile(
+		filepath.Join(dir, lockFileName),
+		os.O_RDWR|os.O_CREATE,
+		0600,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open storage lock file: [%w]", err)
+	}
+
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+
+	err = syscall.Flock(int(file.Fd()), how|syscall.LOCK_NB)
+	if err == syscall.EWOULDBLOCK {
+		_ = file.Close()
+		return nil, fmt.Errorf(
+			"storage directory [%s] is used by another process",
+			dir,
+		)
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("cannot lock storage directory: [%w]", err)
+	}
+
+	return &directoryLock{file: file}, nil
+}
+
+// release releases the lock.
+func (dl *directoryLock) release() error {
+	if err := syscall.Flock(int(dl.file.Fd()), syscall.LOCK_UN); err != nil {
+		_ = dl.file.Close()
+		return err
+	}
+
+	return dl.file.Close()
+}
+
+// lockedBackend is a backend holding the lock on its storage directory until
+// it is closed.
+type lockedBackend struct {
+	Backend
+
+	lock *directoryLock
+}
+
+// Close closes the backend and releases the lock on the storage directory.
+// It is safe to call Close more than once.
+func (lb *lockedBackend) Close() error {
+	if lb.lock == nil {
+		return nil
+	}
+
+	backendErr := lb.Backend.Close()
+	lockErr := lb.lock.release()
+	lb.lock = nil
+
+	if backendErr != nil {
+		return backendErr
+	}
+
+	return lockErr
+}
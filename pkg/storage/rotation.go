@@ -0,0 +1,334 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ipfs/go-log"
+
+	"github.com/keep-network/keep-common/pkg/encryption"
+)
+
+var logger = log.Logger("keep-storage")
+
+const (
+	// rotationDirName is the name of the directory in the storage directory
+	// used to stage the data re-encrypted with the new password. The
+	// directory exists only while the rotation is in progress or if the
+	// rotation was interrupted.
+	rotationDirName = ".rotation"
+	// rotationStagedDirName is the name of the directory in the rotation
+	// directory holding the staged `keystore` and `work` directories.
+	rotationStagedDirName = "staged"
+	// rotationReplacedDirName is the name of the directory in the rotation
+	// directory the original `keystore` and `work` directories are moved to
+	// before they are removed.
+	rotationReplacedDirName = "replaced"
+	// rotationCommittedFileName is the name of the file marking the staged
+	// data as verified. Once the file exists, an interrupted rotation is
+	// completed instead of being rolled back.
+	rotationCommittedFileName = "committed"
+)
+
+// RotatePassword re-encrypts all the key store and work data kept in the
+// storage directory with the new password. Returns the number of
+// re-encrypted entries.
+//
+// The data re-encrypted with the new password are first staged in
+// a separate directory and verified to decrypt to the original content.
+// Only then the staged directories replace the original ones. If the rotation
+// is interrupted before the staged data are verified, the original data are
+// left untouched and the staged data are removed the next time the storage
+// is opened. If the rotation is interrupted later, it is completed the next
+// time the storage is opened. Either way, all the data are encrypted with
+// the same password. The storage directory is locked for the whole rotation
+// so the rotation fails if the storage is used by a running client.
+func RotatePassword(
+	config Config,
+	currentPassword string,
+	newPassword string,
+) (int, error) {
+	rootDir := filepath.Clean(config.Dir)
+	rotationDir := filepath.Join(rootDir, rotationDirName)
+
+	lock, err := lockDirectory(rootDir, true)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := lock.release(); err != nil {
+			logger.Errorf("cannot release storage lock: [%v]", err)
+		}
+	}()
+
+	backend, err := openBackend(config)
+	if err != nil {
+		return 0, err
+	}
+	defer backend.Close()
+
+	entries, err := backend.ExportAll()
+	if err != nil {
+		return 0, fmt.Errorf("cannot read storage: [%w]", err)
+	}
+
+	currentBox := encryption.NewBox(sha256.Sum256([]byte(currentPassword)))
+	newBox := encryption.NewBox(sha256.Sum256([]byte(newPassword)))
+
+	reencrypted := make(map[string][]byte, len(entries))
+	checksums := make(map[string][sha256.Size]byte, len(entries))
+	for entryPath, content := range entries {
+		plaintext, err := currentBox.Decrypt(content)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"cannot decrypt [%s] with the current password: [%w]",
+				entryPath,
+				err,
+			)
+		}
+
+		ciphertext, err := newBox.Encrypt(plaintext)
+		if err != nil {
+			return 0, fmt.Errorf("cannot encrypt [%s]: [%w]", entryPath, err)
+		}
+
+		reencrypted[entryPath] = ciphertext
+		checksums[entryPath] = sha256.Sum256(plaintext)
+	}
+
+	stagedDir := filepath.Join(rotationDir, rotationStagedDirName)
+	if err := os.MkdirAll(stagedDir, 0700); err != nil {
+		return 0, fmt.Errorf("cannot create rotation directory: [%w]", err)
+	}
+
+	err = stageRotation(
+		Config{
+			Dir:     stagedDir,
+			Backend: config.Backend,
+		},
+		reencrypted,
+		func(entryPath string, content []byte) error {
+			plaintext, err := newBox.Decrypt(content)
+			if err != nil {
+				return fmt.Errorf(
+					"cannot decrypt staged [%s]: [%w]",
+					entryPath,
+					err,
+				)
+			}
+
+			if checksum, ok := checksums[entryPath]; !ok ||
+				checksum != sha256.Sum256(plaintext) {
+				return fmt.Errorf("staged [%s] differs from original", entryPath)
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		if removeErr := os.RemoveAll(rotationDir); removeErr != nil {
+			logger.Errorf(
+				"cannot remove rotation directory [%s]: [%v]",
+				rotationDir,
+				removeErr,
+			)
+		}
+
+		return 0, err
+	}
+
+	// The original data must not be used while they are being replaced.
+	if err := backend.Close(); err != nil {
+		return 0, fmt.Errorf("cannot close storage: [%w]", err)
+	}
+
+	if err := writeFileSync(
+		filepath.Join(rotationDir, rotationCommittedFileName),
+		nil,
+	); err != nil {
+		return 0, fmt.Errorf("cannot commit rotation: [%w]", err)
+	}
+
+	if err := completeRotation(rootDir); err != nil {
+		return 0, fmt.Errorf(
+			"cannot complete rotation; it will be completed once the "+
+				"storage is opened again: [%w]",
+			err,
+		)
+	}
+
+	return len(entries), nil
+}
+
+// stageRotation writes the given entries to the storage in the given staging
+// directory, verifies all the written entries with the given function, and
+// makes sure all the staged files are flushed to disk.
+func stageRotation(
+	stagingConfig Config,
+	entries map[string][]byte,
+	verify func(entryPath string, content []byte) error,
+) error {
+	stagingBackend, err := openBackend(stagingConfig)
+	if err != nil {
+		return fmt.Errorf("cannot create staging storage: [%w]", err)
+	}
+	defer stagingBackend.Close()
+
+	if err := stagingBackend.ImportAll(entries); err != nil {
+		return fmt.Errorf("cannot stage re-encrypted data: [%w]", err)
+	}
+
+	staged, err := stagingBackend.ExportAll()
+	if err != nil {
+		return fmt.Errorf("cannot read staged data: [%w]", err)
+	}
+
+	if len(staged) != len(entries) {
+		return fmt.Errorf(
+			"unexpected number of staged entries [%v]; expected [%v]",
+			len(staged),
+			len(entries),
+		)
+	}
+
+	for entryPath, content := range staged {
+		if err := verify(entryPath, content); err != nil {
+			return fmt.Errorf("staged data verification failed: [%w]", err)
+		}
+	}
+
+	if err := stagingBackend.Close(); err != nil {
+		return fmt.Errorf("cannot close staging storage: [%w]", err)
+	}
+
+	return syncDirectory(stagingConfig.Dir)
+}
+
+// recoverRotation completes or rolls back the password rotation interrupted
+// in the given storage directory, if any.
+func recoverRotation(rootDir string) error {
+	rotationDir := filepath.Join(rootDir, rotationDirName)
+
+	if _, err := os.Stat(rotationDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	_, err := os.Stat(filepath.Join(rotationDir, rotationCommittedFileName))
+	if os.IsNotExist(err) {
+		logger.Warn(
+			"rolling back interrupted storage password rotation; " +
+				"data remain encrypted with the previous password",
+		)
+
+		return os.RemoveAll(rotationDir)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Warn(
+		"completing interrupted storage password rotation; " +
+			"data are encrypted with the new password",
+	)
+
+	return completeRotation(rootDir)
+}
+
+// completeRotation replaces the original `keystore` and `work` directories
+// with the staged ones and removes the rotation directory. Every step can be
+// safely repeated if the completion is interrupted.
+func completeRotation(rootDir string) error {
+	rotationDir := filepath.Join(rootDir, rotationDirName)
+	replacedDir := filepath.Join(rotationDir, rotationReplacedDirName)
+
+	if err := os.MkdirAll(replacedDir, 0700); err != nil {
+		return err
+	}
+
+	for _, dirName := range []string{keyStoreDirName, workDirName} {
+		stagedPath := filepath.Join(rotationDir, rotationStagedDirName, dirName)
+		originalPath := filepath.Join(rootDir, dirName)
+
+		if _, err := os.Stat(stagedPath); os.IsNotExist(err) {
+			// Already moved in place.
+			continue
+		}
+
+		if _, err := os.Stat(originalPath); err == nil {
+			if err := os.Rename(
+				originalPath,
+				filepath.Join(replacedDir, dirName),
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := os.Rename(stagedPath, originalPath); err != nil {
+			return err
+		}
+	}
+
+	if err := syncDirectory(rootDir); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(rotationDir)
+}
+
+// syncDirectory flushes all the files and directories found in the given
+// directory, including the directory itself, to disk.
+func syncDirectory(dir string) error {
+	return filepath.WalkDir(
+		dir,
+		func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !entry.IsDir() && !entry.Type().IsRegular() {
+				return nil
+			}
+
+			// #nosec G304 (file path provided as taint input)
+			// The path is resolved while walking the directory.
+			file, err := os.Open(filePath)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			return file.Sync()
+		},
+	)
+}
+
+// writeFileSync writes the given data to the file and flushes both the file
+// and the directory containing it to disk.
+func writeFileSync(filePath string, data []byte) error {
+	// #nosec G304 (file path provided as taint input)
+	// The path is built from constants in the storage directory.
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(data); err != nil {
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(filepath.Dir(filePath))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	return dir.Sync()
+}
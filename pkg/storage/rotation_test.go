@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testNewEncryptionPassword = "new-encryption-password"
+
+func TestRotatePassword(t *testing.T) {
+	for _, backend := range []string{DiskBackend, BoltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			config := Config{Dir: t.TempDir(), Backend: backend}
+			setupStorage(t, config, testEncryptionPassword, "")
+
+			count, err := RotatePassword(
+				config,
+				testEncryptionPassword,
+				testNewEncryptionPassword,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count != 3 {
+				t.Errorf("unexpected number of rotated entries: [%v]", count)
+			}
+
+			assertStorage(t, config, testNewEncryptionPassword, "")
+			assertNoRotationDir(t, config)
+
+			// Data must not be readable with the previous password anymore.
+			storage, err := Initialize(config, testEncryptionPassword)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer storage.Close()
+
+			handle, err := storage.InitializeKeyStorePersistence("tbtc")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			descriptors, errs := handle.ReadAll()
+			for descriptor := range descriptors {
+				if _, err := descriptor.Content(); err == nil {
+					t.Errorf("expected decryption error for previous password")
+				}
+			}
+			for err := range errs {
+				t.Errorf("unexpected read error: [%v]", err)
+			}
+		})
+	}
+}
+
+func TestRotatePassword_WrongCurrentPassword(t *testing.T) {
+	for _, backend := range []string{DiskBackend, BoltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			config := Config{Dir: t.TempDir(), Backend: backend}
+			setupStorage(t, config, testEncryptionPassword, "")
+
+			if _, err := RotatePassword(
+				config,
+				"wrong-password",
+				testNewEncryptionPassword,
+			); err == nil {
+				t.Fatal("expected error for wrong current password")
+			}
+
+			assertStorage(t, config, testEncryptionPassword, "")
+			assertNoRotationDir(t, config)
+		})
+	}
+}
+
+func TestRotatePassword_StorageInUse(t *testing.T) {
+	for _, backend := range []string{DiskBackend, BoltBackend} {
+		t.Run(backend, func(t *testing.T) {
+			config := Config{Dir: t.TempDir(), Backend: backend}
+			setupStorage(t, config, testEncryptionPassword, "")
+
+			// The storage is used by a running client.
+			storage, err := Initialize(config, testEncryptionPassword)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := RotatePassword(
+				config,
+				testEncryptionPassword,
+				testNewEncryptionPassword,
+			); err == nil {
+				t.Fatal("expected error for storage in use")
+			}
+
+			// The key store can still be backed up if the backend does not
+			// lock its files on its own.
+			if backend == DiskBackend {
+				if _, err := BackupKeyStore(
+					config,
+					io.Discard,
+					testBackupPassword,
+				); err != nil {
+					t.Fatalf("unexpected backup error: [%v]", err)
+				}
+			}
+
+			if err := storage.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			assertStorage(t, config, testEncryptionPassword, "")
+			assertNoRotationDir(t, config)
+
+			if _, err := RotatePassword(
+				config,
+				testEncryptionPassword,
+				testNewEncryptionPassword,
+			); err != nil {
+				t.Fatal(err)
+			}
+
+			assertStorage(t, config, testNewEncryptionPassword, "")
+		})
+	}
+}
+
+func TestRecoverRotation(t *testing.T) {
+	var tests = map[string]struct {
+		committed               bool
+		keyStoreReplaced        bool
+		expectedPassword        string
+		expectedWorkPreParamsID string
+	}{
+		"not committed": {
+			committed:               false,
+			expectedPassword:        testEncryptionPassword,
+			expectedWorkPreParamsID: "original",
+		},
+		"committed": {
+			committed:               true,
+			expectedPassword:        testNewEncryptionPassword,
+			expectedWorkPreParamsID: "staged",
+		},
+		"committed and partially completed": {
+			committed:               true,
+			keyStoreReplaced:        true,
+			expectedPassword:        testNewEncryptionPassword,
+			expectedWorkPreParamsID: "staged",
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			config := Config{Dir: t.TempDir()}
+			setupStorage(t, config, testEncryptionPassword, "original")
+
+			rotationDir := filepath.Join(config.Dir, rotationDirName)
+			stagedDir := filepath.Join(rotationDir, rotationStagedDirName)
+			if err := os.MkdirAll(stagedDir, 0700); err != nil {
+				t.Fatal(err)
+			}
+			setupStorage(
+				t,
+				Config{Dir: stagedDir},
+				testNewEncryptionPassword,
+				"staged",
+			)
+
+			if test.committed {
+				if err := writeFileSync(
+					filepath.Join(rotationDir, rotationCommittedFileName),
+					nil,
+				); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if test.keyStoreReplaced {
+				replacedDir := filepath.Join(rotationDir, rotationReplacedDirName)
+				if err := os.MkdirAll(replacedDir, 0700); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Rename(
+					filepath.Join(config.Dir, keyStoreDirName),
+					filepath.Join(replacedDir, keyStoreDirName),
+				); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			assertStorage(
+				t,
+				config,
+				test.expectedPassword,
+				test.expectedWorkPreParamsID,
+			)
+			assertNoRotationDir(t, config)
+		})
+	}
+}
+
+// setupStorage creates a storage with two key store entries and one work
+// entry, encrypted with the given password. The work entry name is suffixed
+// with the given identifier.
+func setupStorage(
+	t *testing.T,
+	config Config,
+	password string,
+	workID string,
+) {
+	storage, err := Initialize(config, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	keyStoreHandle, err := storage.InitializeKeyStorePersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := keyStoreHandle.Save(
+		[]byte("member-1"),
+		"wallet-1",
+		"membership_1",
+	); err != nil {
+		t.Fatal(err)
+	}
+	if err := keyStoreHandle.Save(
+		[]byte("member-2"),
+		"wallet-1",
+		"membership_2",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	workHandle, err := storage.InitializeWorkPersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := workHandle.Save(
+		[]byte("pre-params"),
+		"pre_params",
+		"1"+workID,
+	); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// assertStorage checks whether the storage holds entries created by
+// setupStorage, decryptable with the given password.
+func assertStorage(
+	t *testing.T,
+	config Config,
+	password string,
+	workID string,
+) {
+	storage, err := Initialize(config, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+
+	keyStoreHandle, err := storage.InitializeKeyStorePersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEntries(
+		t,
+		map[string]string{
+			"wallet-1/membership_1": "member-1",
+			"wallet-1/membership_2": "member-2",
+		},
+		readAll(t, keyStoreHandle),
+	)
+
+	workHandle, err := storage.InitializeWorkPersistence("tbtc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEntries(
+		t,
+		map[string]string{
+			"pre_params/1" + workID: "pre-params",
+		},
+		readAll(t, workHandle),
+	)
+}
+
+func assertNoRotationDir(t *testing.T, config Config) {
+	if _, err := os.Stat(
+		filepath.Join(config.Dir, rotationDirName),
+	); !os.IsNotExist(err) {
+		t.Errorf("rotation directory should not exist")
+	}
+}
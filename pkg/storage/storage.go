@@ -1,10 +1,6 @@
 package storage
 
 import (
-	"fmt"
-	"path"
-	"path/filepath"
-
 	"github.com/keep-network/keep-common/pkg/persistence"
 )
 
@@ -12,6 +8,9 @@ import (
 type Config struct {
 	// Path to the persistent storage directory on disk.
 	Dir string
+	// Backend is the storage backend used to persist data in the storage
+	// directory: `disk` (default) or `bolt`.
+	Backend string
 }
 
 const (
@@ -27,116 +26,64 @@ const (
 	workDirName = "work"
 )
 
-// Storage is a disk persistent storage for the client.
+// Storage is a persistent storage for the client.
 type Storage struct {
-	keystoreDir        string
-	workDir            string
+	backend            Backend
 	encryptionPassword string
 }
 
-// Initialize initializes a disk storage with `keystore` and `work` directories.
-// The provided `encryptionPassword` will be used to encrypt the work persisted
-// to the storage.
+// Initialize initializes a storage with `keystore` and `work` directories
+// using the backend set in the config. The provided `encryptionPassword` will
+// be used to encrypt the data persisted to the storage. The storage directory
+// is locked with the shared lock until the storage is closed.
 func Initialize(config Config, encryptionPassword string) (Storage, error) {
 	storage := Storage{}
 
-	storageRootDir := filepath.Clean(config.Dir)
-
-	if err := persistence.EnsureDirectoryExists(
-		storageRootDir,
-		keyStoreDirName,
-	); err != nil {
-		return storage, fmt.Errorf(
-			"cannot create storage directory for keystore: [%w]",
-			err,
-		)
-	}
-	storage.keystoreDir = filepath.Join(storageRootDir, keyStoreDirName)
-
-	if err := persistence.EnsureDirectoryExists(
-		storageRootDir,
-		workDirName,
-	); err != nil {
-		return storage, fmt.Errorf(
-			"cannot create storage directory for work: [%w]",
-			err,
-		)
+	backend, err := newBackend(config, false)
+	if err != nil {
+		return storage, err
 	}
-	storage.workDir = filepath.Join(storageRootDir, workDirName)
 
+	storage.backend = backend
 	storage.encryptionPassword = encryptionPassword
 
 	return storage, nil
 }
 
-// InitializeKeyStorePersistence initializes a disk persistence under keystore parent.
+// InitializeKeyStorePersistence initializes a persistence under keystore parent.
 func (s *Storage) InitializeKeyStorePersistence(dir string) (
 	persistence.ProtectedHandle,
 	error,
 ) {
-	return s.initializeKeyStorePersistence(s.keystoreDir, dir)
-}
-
-// InitializeWorkPersistence initializes a disk persistence under work parent.
-func (s *Storage) InitializeWorkPersistence(dir string) (
-	persistence.BasicHandle,
-	error,
-) {
-	return s.initializeWorkPersistence(s.workDir, dir)
-}
-
-// initializeKeyStorePersistence creates a persistent directory under a parent directory.
-// It returns an error is the parent directory doesn't exist.
-func (s *Storage) initializeKeyStorePersistence(parentDir string, dir string) (
-	persistence.ProtectedHandle,
-	error,
-) {
-	if err := persistence.EnsureDirectoryExists(parentDir, dir); err != nil {
-		return nil, fmt.Errorf(
-			"cannot create storage directory [%s] in [%s]: [%w]",
-			dir,
-			parentDir,
-			err,
-		)
-	}
-
-	path := path.Join(parentDir, dir)
-
-	diskHandle, err := persistence.NewProtectedDiskHandle(path)
+	handle, err := s.backend.KeyStoreHandle(dir)
 	if err != nil {
-		return nil, fmt.Errorf("cannot create [%s] disk handle: [%w]", path, err)
+		return nil, err
 	}
 
 	return persistence.NewEncryptedProtectedPersistence(
-		diskHandle,
+		handle,
 		s.encryptionPassword,
 	), nil
 }
 
-// initializeWorkPersistence creates a persistent directory under a parent directory.
-// It returns an error is the parent directory doesn't exist.
-func (s *Storage) initializeWorkPersistence(parentDir string, dir string) (
+// InitializeWorkPersistence initializes a persistence under work parent.
+func (s *Storage) InitializeWorkPersistence(dir string) (
 	persistence.BasicHandle,
 	error,
 ) {
-	if err := persistence.EnsureDirectoryExists(parentDir, dir); err != nil {
-		return nil, fmt.Errorf(
-			"cannot create storage directory [%s] in [%s]: [%w]",
-			dir,
-			parentDir,
-			err,
-		)
-	}
-
-	path := path.Join(parentDir, dir)
-
-	diskHandle, err := persistence.NewBasicDiskHandle(path)
+	handle, err := s.backend.WorkHandle(dir)
 	if err != nil {
-		return nil, fmt.Errorf("cannot create [%s] disk handle: [%w]", path, err)
+		return nil, err
 	}
 
 	return persistence.NewEncryptedBasicPersistence(
-		diskHandle,
+		handle,
 		s.encryptionPassword,
 	), nil
 }
+
+// Close releases resources held by the storage backend. Persistence handles
+// initialized by the storage must not be used after the storage is closed.
+func (s *Storage) Close() error {
+	return s.backend.Close()
+}
@@ -154,6 +154,12 @@ func (lbc *localBitcoinChain) GetTxHashesForPublicKeyHash(
 	return matchingTxHashes, nil
 }
 
+func (lbc *localBitcoinChain) GetTxHashesForScript(
+	script bitcoin.Script,
+) ([]bitcoin.Hash, error) {
+	panic("unsupported")
+}
+
 func (lbc *localBitcoinChain) GetMempoolForPublicKeyHash(
 	publicKeyHash [20]byte,
 ) ([]*bitcoin.Transaction, error) {
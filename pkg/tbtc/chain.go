@@ -24,6 +24,21 @@ const (
 	Challenge
 )
 
+func (ds DKGState) String() string {
+	switch ds {
+	case Idle:
+		return "Idle"
+	case AwaitingSeed:
+		return "AwaitingSeed"
+	case AwaitingResult:
+		return "AwaitingResult"
+	case Challenge:
+		return "Challenge"
+	default:
+		return "Unknown"
+	}
+}
+
 // GroupSelectionChain defines the subset of the TBTC chain interface that
 // pertains to the group selection activities.
 type GroupSelectionChain interface {
@@ -253,6 +268,14 @@ type BridgeChain interface {
 	// ECDSA wallet registry.
 	IsWalletRegistered(EcdsaWalletID [32]byte) (bool, error)
 
+	// IsWalletSigningGroup checks whether the given operators, ordered by
+	// signing group member index, are the signing group members of the
+	// given wallet registered in the ECDSA wallet registry.
+	IsWalletSigningGroup(
+		EcdsaWalletID [32]byte,
+		signingGroupOperators chain.Addresses,
+	) (bool, error)
+
 	// GetWallet gets the on-chain data for the given wallet. Returns an error
 	// if the wallet was not found.
 	GetWallet(walletPublicKeyHash [20]byte) (*WalletChainData, error)
@@ -424,6 +447,53 @@ type WalletChainData struct {
 	MovingFundsTargetWalletsCommitmentHash [32]byte
 }
 
+// CoordinationPolicyChain defines the subset of the TBTC chain interface that
+// pertains specifically to the coordination policies. The state is read as
+// of the given block so that all operators of a wallet read the same state.
+type CoordinationPolicyChain interface {
+	// GetWalletAtBlock gets the on-chain data for the given wallet as of the
+	// given block. Returns an error if the wallet was not found.
+	GetWalletAtBlock(
+		walletPublicKeyHash [20]byte,
+		blockNumber uint64,
+	) (*WalletChainData, error)
+
+	// GetDepositRequestAtBlock gets the on-chain deposit request for the given
+	// funding transaction hash and output index as of the given block. The
+	// returned bool value indicates whether the request was found or not.
+	GetDepositRequestAtBlock(
+		fundingTxHash bitcoin.Hash,
+		fundingOutputIndex uint32,
+		blockNumber uint64,
+	) (*DepositChainRequest, bool, error)
+}
+
+// RedemptionMonitorChain defines the subset of the TBTC chain interface that
+// pertains specifically to monitoring pending redemption requests against
+// the redemption timeout.
+type RedemptionMonitorChain interface {
+	// PastRedemptionRequestedEvents fetches past redemption requested events
+	// according to the provided filter or unfiltered if the filter is nil.
+	// Returned events are sorted by the block number in the ascending order,
+	// i.e. the latest event is at the end of the slice.
+	PastRedemptionRequestedEvents(
+		filter *RedemptionRequestedEventFilter,
+	) ([]*RedemptionRequestedEvent, error)
+
+	// GetRedemptionParameters gets the current value of parameters relevant
+	// for the redemption process.
+	GetRedemptionParameters() (
+		dustThreshold uint64,
+		treasuryFeeDivisor uint64,
+		txMaxFee uint64,
+		txMaxTotalFee uint64,
+		timeout uint32,
+		timeoutSlashingAmount *big.Int,
+		timeoutNotifierRewardMultiplier uint32,
+		err error,
+	)
+}
+
 // WalletProposalValidatorChain defines the subset of the TBTC chain interface
 // that pertains specifically to the tBTC wallet proposal validator.
 type WalletProposalValidatorChain interface {
@@ -558,4 +628,6 @@ type Chain interface {
 	InactivityClaimChain
 	BridgeChain
 	WalletProposalValidatorChain
+	CoordinationPolicyChain
+	RedemptionMonitorChain
 }
@@ -66,8 +66,11 @@ type localChain struct {
 	dkgResult      *DKGChainResult
 	dkgResultValid bool
 
-	walletsMutex sync.Mutex
-	wallets      map[[20]byte]*WalletChainData
+	walletsMutex   sync.Mutex
+	wallets        map[[20]byte]*WalletChainData
+	walletsAtBlock map[uint64]map[[20]byte]*WalletChainData
+
+	walletSigningGroups map[[32]byte]chain.Addresses
 
 	inactivityNonceMutex sync.Mutex
 	inactivityNonces     map[[32]byte]uint64
@@ -93,6 +96,12 @@ type localChain struct {
 	redemptionProposalValidationsMutex sync.Mutex
 	redemptionProposalValidations      map[[32]byte]bool
 
+	pastRedemptionRequestedEventsMutex sync.Mutex
+	pastRedemptionRequestedEvents      []*RedemptionRequestedEvent
+
+	redemptionTimeoutMutex sync.Mutex
+	redemptionTimeout      uint32
+
 	movingFundsProposalValidationsMutex sync.Mutex
 	movingFundsProposalValidations      map[[32]byte]bool
 
@@ -803,6 +812,83 @@ func buildRedemptionRequestKey(
 	return sha256.Sum256(append(walletPublicKeyHash[:], redeemerOutputScript...))
 }
 
+func (lc *localChain) PastRedemptionRequestedEvents(
+	filter *RedemptionRequestedEventFilter,
+) ([]*RedemptionRequestedEvent, error) {
+	lc.pastRedemptionRequestedEventsMutex.Lock()
+	defer lc.pastRedemptionRequestedEventsMutex.Unlock()
+
+	matchesWallet := func(walletPublicKeyHash [20]byte) bool {
+		if filter == nil || len(filter.WalletPublicKeyHash) == 0 {
+			return true
+		}
+
+		for _, filterWalletPublicKeyHash := range filter.WalletPublicKeyHash {
+			if filterWalletPublicKeyHash == walletPublicKeyHash {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	events := make([]*RedemptionRequestedEvent, 0)
+	for _, event := range lc.pastRedemptionRequestedEvents {
+		if filter != nil && event.BlockNumber < filter.StartBlock {
+			continue
+		}
+
+		if filter != nil &&
+			filter.EndBlock != nil &&
+			event.BlockNumber > *filter.EndBlock {
+			continue
+		}
+
+		if !matchesWallet(event.WalletPublicKeyHash) {
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func (lc *localChain) addPastRedemptionRequestedEvent(
+	event *RedemptionRequestedEvent,
+) {
+	lc.pastRedemptionRequestedEventsMutex.Lock()
+	defer lc.pastRedemptionRequestedEventsMutex.Unlock()
+
+	lc.pastRedemptionRequestedEvents = append(
+		lc.pastRedemptionRequestedEvents,
+		event,
+	)
+}
+
+func (lc *localChain) GetRedemptionParameters() (
+	dustThreshold uint64,
+	treasuryFeeDivisor uint64,
+	txMaxFee uint64,
+	txMaxTotalFee uint64,
+	timeout uint32,
+	timeoutSlashingAmount *big.Int,
+	timeoutNotifierRewardMultiplier uint32,
+	err error,
+) {
+	lc.redemptionTimeoutMutex.Lock()
+	defer lc.redemptionTimeoutMutex.Unlock()
+
+	return 0, 0, 0, 0, lc.redemptionTimeout, big.NewInt(0), 0, nil
+}
+
+func (lc *localChain) setRedemptionTimeout(timeout uint32) {
+	lc.redemptionTimeoutMutex.Lock()
+	defer lc.redemptionTimeoutMutex.Unlock()
+
+	lc.redemptionTimeout = timeout
+}
+
 func (lc *localChain) GetDepositRequest(
 	fundingTxHash bitcoin.Hash,
 	fundingOutputIndex uint32,
@@ -820,6 +906,14 @@ func (lc *localChain) GetDepositRequest(
 	return request, true, nil
 }
 
+func (lc *localChain) GetDepositRequestAtBlock(
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+	blockNumber uint64,
+) (*DepositChainRequest, bool, error) {
+	return lc.GetDepositRequest(fundingTxHash, fundingOutputIndex)
+}
+
 func (lc *localChain) setDepositRequest(
 	fundingTxHash bitcoin.Hash,
 	fundingOutputIndex uint32,
@@ -892,6 +986,38 @@ func (lc *localChain) GetWallet(walletPublicKeyHash [20]byte) (
 	return walletChainData, nil
 }
 
+func (lc *localChain) GetWalletAtBlock(
+	walletPublicKeyHash [20]byte,
+	blockNumber uint64,
+) (*WalletChainData, error) {
+	lc.walletsMutex.Lock()
+	walletChainData, ok := lc.walletsAtBlock[blockNumber][walletPublicKeyHash]
+	lc.walletsMutex.Unlock()
+
+	if ok {
+		return walletChainData, nil
+	}
+
+	return lc.GetWallet(walletPublicKeyHash)
+}
+
+// setWalletAtBlock sets the wallet chain data returned by GetWalletAtBlock for
+// the given block. For other blocks, the data set by setWallet is returned.
+func (lc *localChain) setWalletAtBlock(
+	walletPublicKeyHash [20]byte,
+	blockNumber uint64,
+	walletChainData *WalletChainData,
+) {
+	lc.walletsMutex.Lock()
+	defer lc.walletsMutex.Unlock()
+
+	if _, ok := lc.walletsAtBlock[blockNumber]; !ok {
+		lc.walletsAtBlock[blockNumber] = make(map[[20]byte]*WalletChainData)
+	}
+
+	lc.walletsAtBlock[blockNumber][walletPublicKeyHash] = walletChainData
+}
+
 func (lc *localChain) IsWalletRegistered(EcdsaWalletID [32]byte) (bool, error) {
 	lc.walletsMutex.Lock()
 	defer lc.walletsMutex.Unlock()
@@ -906,7 +1032,34 @@ func (lc *localChain) IsWalletRegistered(EcdsaWalletID [32]byte) (bool, error) {
 		}
 	}
 
-	return false, fmt.Errorf("wallet not found")
+	// The wallet registry does not tell unknown wallets apart from
+	// unregistered ones.
+	return false, nil
+}
+
+func (lc *localChain) IsWalletSigningGroup(
+	EcdsaWalletID [32]byte,
+	signingGroupOperators chain.Addresses,
+) (bool, error) {
+	lc.walletsMutex.Lock()
+	defer lc.walletsMutex.Unlock()
+
+	members, ok := lc.walletSigningGroups[EcdsaWalletID]
+	if !ok {
+		return false, fmt.Errorf("wallet signing group not found")
+	}
+
+	return reflect.DeepEqual(members, signingGroupOperators), nil
+}
+
+func (lc *localChain) setWalletSigningGroup(
+	EcdsaWalletID [32]byte,
+	signingGroupOperators chain.Addresses,
+) {
+	lc.walletsMutex.Lock()
+	defer lc.walletsMutex.Unlock()
+
+	lc.walletSigningGroups[EcdsaWalletID] = signingGroupOperators
 }
 
 func (lc *localChain) setWallet(
@@ -1463,6 +1616,8 @@ func ConnectWithKey(
 			map[int]func(submission *InactivityClaimedEvent),
 		),
 		wallets:                                  make(map[[20]byte]*WalletChainData),
+		walletsAtBlock:                           make(map[uint64]map[[20]byte]*WalletChainData),
+		walletSigningGroups:                      make(map[[32]byte]chain.Addresses),
 		inactivityNonces:                         make(map[[32]byte]uint64),
 		blocksByTimestamp:                        make(map[uint64]uint64),
 		blocksHashesByNumber:                     make(map[uint64][32]byte),
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"strings"
@@ -46,6 +48,15 @@ const (
 	// hash can be used as an ingredient for the coordination seed, computed
 	// for the given coordination window.
 	coordinationSafeBlockShift = 32
+	// coordinationBackupLeadersCount is the number of backup leaders of the
+	// upgraded coordination windows. If no valid proposal is received from
+	// the leader by the end of their slot of the active phase, the first
+	// backup leader proposes, and so on.
+	coordinationBackupLeadersCount = 2
+	// coordinationBackupLeaderSlotDurationBlocks is the number of blocks in
+	// the slot of a single backup leader. The backup leaders' slots close
+	// the active phase; the leader's slot is the rest of the active phase.
+	coordinationBackupLeaderSlotDurationBlocks = 20
 	// coordinationHeartbeatProbability is the probability of proposing a
 	// heartbeat action during the coordination procedure, assuming no other
 	// higher-priority action is proposed.
@@ -60,10 +71,41 @@ const (
 	coordinationMessageReceiveBuffer = 512
 )
 
+// coordinationUpgradeBlock is the first coordination block of the windows
+// using the upgraded coordination procedure. All operators of a wallet must
+// follow the same procedure, otherwise they consider each other's proposals
+// invalid, so the upgrade is not a local choice of the operator. Instead, it
+// is activated at the same block by all operators once the client release
+// setting the block is widely adopted. The upgrade is disabled until then.
+var coordinationUpgradeBlock = uint64(math.MaxUint64)
+
 // errCoordinationExecutorBusy is an error returned when the coordination
 // executor cannot execute the requested coordination due to an ongoing one.
 var errCoordinationExecutorBusy = fmt.Errorf("coordination executor is busy")
 
+// errCoordinationMessageNotReceived is an error returned by the follower's
+// routine when no valid coordination message was received from the
+// followed leaders on time.
+var errCoordinationMessageNotReceived = fmt.Errorf(
+	"coordination message not received on time",
+)
+
+// coordinationFailedError is an error returned by the coordination executor
+// when the coordination fails after some faults have been observed, e.g.
+// the backup leader's routine fails after the preceding leaders were idle.
+type coordinationFailedError struct {
+	err    error
+	faults []*coordinationFault
+}
+
+func (cfe *coordinationFailedError) Error() string {
+	return cfe.err.Error()
+}
+
+func (cfe *coordinationFailedError) Unwrap() error {
+	return cfe.err
+}
+
 // coordinationWindow represents a single coordination window. The coordination
 // block is the first block of the window.
 type coordinationWindow struct {
@@ -90,6 +132,37 @@ func (cw *coordinationWindow) endBlock() uint64 {
 	return cw.coordinationBlock + coordinationDurationBlocks
 }
 
+// leaderSlotStartBlock returns the block number at which the given leader
+// slot of the active phase starts. Slot 0 belongs to the leader, the
+// subsequent slots to the backup leaders.
+func (cw *coordinationWindow) leaderSlotStartBlock(slot int) uint64 {
+	if slot <= 0 {
+		return cw.coordinationBlock
+	}
+
+	return cw.activePhaseEndBlock() -
+		uint64(coordinationBackupLeadersCount-slot+1)*
+			coordinationBackupLeaderSlotDurationBlocks
+}
+
+// leaderSlotEndBlock returns the block number at which the given leader
+// slot of the active phase ends. Coordination windows preceding the
+// coordination upgrade have no backup leaders so the leader's slot is the
+// entire active phase.
+func (cw *coordinationWindow) leaderSlotEndBlock(slot int) uint64 {
+	if slot >= coordinationBackupLeadersCount || !cw.isUpgraded() {
+		return cw.activePhaseEndBlock()
+	}
+
+	return cw.leaderSlotStartBlock(slot + 1)
+}
+
+// isUpgraded returns true if the coordination window uses the upgraded
+// coordination procedure.
+func (cw *coordinationWindow) isUpgraded() bool {
+	return cw.coordinationBlock >= coordinationUpgradeBlock
+}
+
 // isAfter returns true if this coordination window is after the other
 // window.
 func (cw *coordinationWindow) isAfter(other *coordinationWindow) bool {
@@ -204,6 +277,11 @@ type CoordinationProposalRequest struct {
 	WalletOperators     []chain.Address
 	ExecutingOperator   chain.Address
 	ActionsChecklist    []WalletActionType
+	// UpgradedCoordination determines whether the proposal is made in
+	// a coordination window using the upgraded coordination rules. In such
+	// windows, the redemption transaction fee is split between the requests
+	// in proportion to their maximum fees rather than evenly.
+	UpgradedCoordination bool
 }
 
 // CoordinationProposalGenerator is a component responsible for generating
@@ -246,11 +324,17 @@ func (np *NoopProposal) ValidityBlocks() uint64 {
 // coordinationResult represents the result of the coordination procedure
 // executed for the given wallet in the given coordination window.
 type coordinationResult struct {
-	wallet   wallet
-	window   *coordinationWindow
-	leader   chain.Address
-	proposal CoordinationProposal
-	faults   []*coordinationFault
+	wallet wallet
+	window *coordinationWindow
+	seed   [32]byte
+	leader chain.Address
+	// proposer is the address of the operator whose proposal was accepted:
+	// the leader or one of the backup leaders. Empty if no proposal was
+	// received.
+	proposer         chain.Address
+	actionsChecklist []WalletActionType
+	proposal         CoordinationProposal
+	faults           []*coordinationFault
 }
 
 func (cr *coordinationResult) String() string {
@@ -289,6 +373,7 @@ type coordinationExecutor struct {
 	operatorAddress   chain.Address
 
 	proposalGenerator CoordinationProposalGenerator
+	policy            CoordinationPolicy
 
 	broadcastChannel    net.BroadcastChannel
 	membershipValidator *group.MembershipValidator
@@ -305,6 +390,7 @@ func newCoordinationExecutor(
 	membersIndexes []group.MemberIndex,
 	operatorAddress chain.Address,
 	proposalGenerator CoordinationProposalGenerator,
+	policy CoordinationPolicy,
 	broadcastChannel net.BroadcastChannel,
 	membershipValidator *group.MembershipValidator,
 	protocolLatch *generator.ProtocolLatch,
@@ -317,6 +403,7 @@ func newCoordinationExecutor(
 		membersIndexes:      membersIndexes,
 		operatorAddress:     operatorAddress,
 		proposalGenerator:   proposalGenerator,
+		policy:              policy,
 		broadcastChannel:    broadcastChannel,
 		membershipValidator: membershipValidator,
 		protocolLatch:       protocolLatch,
@@ -370,78 +457,125 @@ func (ce *coordinationExecutor) coordinate(
 
 	execLogger.Infof("coordination seed is: [0x%x]", seed)
 
-	leader := ce.getLeader(seed)
+	leaders := ce.getLeaders(window, seed)
+	leader := leaders[0]
 
-	execLogger.Infof("coordination leader is: [%s]", leader)
+	execLogger.Infof(
+		"coordination leader is: [%s]; backup leaders are: [%v]",
+		leader,
+		leaders[1:],
+	)
 
-	actionsChecklist := ce.getActionsChecklist(window.index(), seed)
+	actionsChecklist, err := ce.getActionsChecklist(window, seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get actions checklist: [%v]", err)
+	}
 
 	execLogger.Infof("actions checklist is: [%v]", actionsChecklist)
 
-	// Set up a context that is automatically cancelled when the active phase
-	// of the coordination window ends.
-	//
-	// The coordination leader keeps that context active for the lifetime of the
-	// active phase to provide retransmissions of the coordination message thus
-	// maximize the chance that all followers receive it on time. The only case
-	// when the leader cancels the context prematurely is when the leader's
-	// routine fails.
-	//
-	// The coordination follower cancels the context as soon as it receives
-	// the coordination message.
-	ctx, cancelCtx := withCancelOnBlock(
-		context.Background(),
-		window.activePhaseEndBlock(),
-		ce.waitForBlockFn,
-	)
+	// The slot of the active phase in which this operator proposes, if
+	// no valid proposal is received from the preceding leaders. The operator
+	// follows the preceding leaders until their slots end. Operators who are
+	// neither the leader nor a backup leader follow all the leaders.
+	slot := slices.Index(leaders, ce.operatorAddress)
+	followedLeaders := leaders
+	if slot >= 0 {
+		followedLeaders = leaders[:slot]
+	}
 
 	var proposal CoordinationProposal
+	var proposer chain.Address
 	var faults []*coordinationFault
 
-	if leader == ce.operatorAddress {
-		execLogger.Info("executing leader's routine")
+	if len(followedLeaders) > 0 {
+		execLogger.Info("executing follower's routine")
 
-		proposal, err = ce.executeLeaderRoutine(
+		// Set up a context that is automatically cancelled when the slot of
+		// the last followed leader ends. The follower cancels the context
+		// as soon as it receives the coordination message.
+		ctx, cancelCtx := withCancelOnBlock(
+			context.Background(),
+			window.leaderSlotEndBlock(len(followedLeaders)-1),
+			ce.waitForBlockFn,
+		)
+
+		proposal, proposer, faults, err = ce.executeFollowerRoutine(
 			ctx,
-			window.coordinationBlock,
-			actionsChecklist,
+			window,
+			followedLeaders,
+			append(actionsChecklist, ActionNoop),
 		)
+
+		cancelCtx()
+
 		if err != nil {
-			// Cancel the context upon leader's routine failure. There is
-			// no point to keep the context active as retransmissions do not
-			// occur anyway.
-			cancelCtx()
-			return nil, fmt.Errorf(
-				"failed to execute leader's routine: [%v]",
-				err,
+			// A backup leader proposes if the preceding leaders did not.
+			// Other followers end up with a no-op proposal but still
+			// account the idleness of the leaders.
+			if !errors.Is(err, errCoordinationMessageNotReceived) {
+				return nil, &coordinationFailedError{
+					err: fmt.Errorf(
+						"failed to execute follower's routine: [%v]",
+						err,
+					),
+					faults: faults,
+				}
+			}
+
+			execLogger.Infof(
+				"no proposal received from preceding leaders; "+
+					"observed faults: [%v]",
+				faults,
+			)
+		} else {
+			execLogger.Infof(
+				"received proposal: [%s] from [%s]; observed faults: [%v]",
+				proposal.ActionType(),
+				proposer,
+				faults,
 			)
 		}
+	}
 
-		execLogger.Infof("broadcasted proposal: [%s]", proposal.ActionType())
-	} else {
-		execLogger.Info("executing follower's routine")
-
-		// Cancel the context upon follower's routine completion.
-		defer cancelCtx()
+	if proposal == nil && slot >= 0 {
+		execLogger.Infof("executing leader's routine in slot [%v]", slot)
+
+		// Set up a context that is automatically cancelled when the slot of
+		// this operator ends.
+		//
+		// The leader keeps that context active for the lifetime of the slot
+		// to provide retransmissions of the coordination message thus
+		// maximize the chance that all followers receive it on time. The only
+		// case when the leader cancels the context prematurely is when the
+		// leader's routine fails.
+		ctx, cancelCtx := withCancelOnBlock(
+			context.Background(),
+			window.leaderSlotEndBlock(slot),
+			ce.waitForBlockFn,
+		)
 
-		proposal, faults, err = ce.executeFollowerRoutine(
+		proposal, err = ce.executeLeaderRoutine(
 			ctx,
-			leader,
 			window.coordinationBlock,
-			append(actionsChecklist, ActionNoop),
+			actionsChecklist,
 		)
 		if err != nil {
-			return nil, fmt.Errorf(
-				"failed to execute follower's routine: [%v]",
-				err,
-			)
+			// Cancel the context upon leader's routine failure. There is
+			// no point to keep the context active as retransmissions do not
+			// occur anyway.
+			cancelCtx()
+			return nil, &coordinationFailedError{
+				err: fmt.Errorf(
+					"failed to execute leader's routine: [%v]",
+					err,
+				),
+				faults: faults,
+			}
 		}
 
-		execLogger.Infof(
-			"received proposal: [%s]; observed faults: [%v]",
-			proposal.ActionType(),
-			faults,
-		)
+		proposer = ce.operatorAddress
+
+		execLogger.Infof("broadcasted proposal: [%s]", proposal.ActionType())
 	}
 
 	// Just in case, if the proposal is nil, set it to noop.
@@ -450,11 +584,14 @@ func (ce *coordinationExecutor) coordinate(
 	}
 
 	result := &coordinationResult{
-		wallet:   ce.coordinatedWallet,
-		window:   window,
-		leader:   leader,
-		proposal: proposal,
-		faults:   faults,
+		wallet:           ce.coordinatedWallet,
+		window:           window,
+		seed:             seed,
+		leader:           leader,
+		proposer:         proposer,
+		actionsChecklist: actionsChecklist,
+		proposal:         proposal,
+		faults:           faults,
 	}
 
 	execLogger.Infof("coordination completed with result: [%s]", result)
@@ -466,10 +603,18 @@ func (ce *coordinationExecutor) coordinate(
 func (ce *coordinationExecutor) getSeed(
 	coordinationBlock uint64,
 ) ([32]byte, error) {
-	walletPublicKeyHash := ce.walletPublicKeyHash()
+	return coordinationSeed(ce.chain, ce.walletPublicKeyHash(), coordinationBlock)
+}
 
+// coordinationSeed computes the coordination seed of the wallet with the
+// given public key hash for the given coordination window.
+func coordinationSeed(
+	chain Chain,
+	walletPublicKeyHash [20]byte,
+	coordinationBlock uint64,
+) ([32]byte, error) {
 	safeBlockNumber := coordinationBlock - coordinationSafeBlockShift
-	safeBlockHash, err := ce.chain.GetBlockHashByNumber(safeBlockNumber)
+	safeBlockHash, err := chain.GetBlockHashByNumber(safeBlockNumber)
 	if err != nil {
 		return [32]byte{}, fmt.Errorf(
 			"failed to get safe block hash: [%v]",
@@ -485,11 +630,43 @@ func (ce *coordinationExecutor) getSeed(
 	), nil
 }
 
-// getLeader returns the address of the coordination leader for the given
+// getLeader returns the address of the coordination leader of the given
+// coordination window for the given coordination seed.
+func (ce *coordinationExecutor) getLeader(
+	window *coordinationWindow,
+	seed [32]byte,
+) chain.Address {
+	return ce.getLeaders(window, seed)[0]
+}
+
+// getLeaders returns the addresses of the coordination leader followed by
+// the backup leaders of the given coordination window for the given
 // coordination seed.
-func (ce *coordinationExecutor) getLeader(seed [32]byte) chain.Address {
+func (ce *coordinationExecutor) getLeaders(
+	window *coordinationWindow,
+	seed [32]byte,
+) []chain.Address {
+	return coordinationLeaders(
+		window,
+		ce.coordinatedWallet.signingGroupOperators,
+		seed,
+	)
+}
+
+// coordinationLeaders returns the addresses of the coordination leader
+// followed by the backup leaders among the given signing group operators
+// for the given coordination window and seed. Clients not supporting backup
+// leaders consider their proposals as leader impersonation so windows
+// preceding the coordination upgrade have no backup leaders. The number of
+// backup leaders is lower than coordinationBackupLeadersCount if there are
+// not enough unique operators.
+func coordinationLeaders(
+	window *coordinationWindow,
+	signingGroupOperators []chain.Address,
+	seed [32]byte,
+) []chain.Address {
 	// First, take all operators backing the wallet.
-	allOperators := chain.Addresses(ce.coordinatedWallet.signingGroupOperators)
+	allOperators := chain.Addresses(signingGroupOperators)
 
 	// Determine a list of unique operators.
 	uniqueOperators := make([]chain.Address, 0)
@@ -519,53 +696,32 @@ func (ce *coordinationExecutor) getLeader(seed [32]byte) chain.Address {
 		},
 	)
 
-	// The first operator in the shuffled list is the leader.
-	return uniqueOperators[0]
+	// The first operator in the shuffled list is the leader, the next ones
+	// are the backup leaders.
+	leadersCount := 1
+	if window.isUpgraded() {
+		leadersCount += coordinationBackupLeadersCount
+	}
+	if len(uniqueOperators) < leadersCount {
+		leadersCount = len(uniqueOperators)
+	}
+
+	return uniqueOperators[:leadersCount]
 }
 
 // getActionsChecklist returns a list of wallet actions that should be checked
-// for the given coordination window. Returns nil for incorrect coordination
-// windows whose index is 0.
+// for the given coordination window, determined by the coordination policy.
+// Returns nil for incorrect coordination windows whose index is 0.
 func (ce *coordinationExecutor) getActionsChecklist(
-	windowIndex uint64,
+	window *coordinationWindow,
 	seed [32]byte,
-) []WalletActionType {
-	// Return nil checklist for incorrect coordination windows.
-	if windowIndex == 0 {
-		return nil
-	}
-
-	var actions []WalletActionType
-
-	// Redemption action is a priority action and should be checked on every
-	// coordination window.
-	actions = append(actions, ActionRedemption)
-
-	// Other actions should be checked with a lower frequency. The default
-	// frequency is every 4 coordination windows.
-	frequencyWindows := uint64(4)
-
-	if windowIndex%frequencyWindows == 0 {
-		actions = append(actions, ActionDepositSweep)
-	}
-
-	if windowIndex%frequencyWindows == 0 {
-		actions = append(actions, ActionMovedFundsSweep)
-	}
-
-	if windowIndex%frequencyWindows == 0 {
-		actions = append(actions, ActionMovingFunds)
-	}
-
-	// #nosec G404 (insecure random number source (rand))
-	// Drawing a decision about heartbeat does not require secure randomness.
-	// Use first 8 bytes of the seed to initialize the RNG.
-	rng := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(seed[:8]))))
-	if rng.Float64() < coordinationHeartbeatProbability {
-		actions = append(actions, ActionHeartbeat)
-	}
-
-	return actions
+) ([]WalletActionType, error) {
+	return ce.policy.ActionsChecklist(&CoordinationPolicyRequest{
+		WalletPublicKeyHash: ce.walletPublicKeyHash(),
+		WindowIndex:         window.index(),
+		SafeBlock:           window.coordinationBlock - coordinationSafeBlockShift,
+		Seed:                seed,
+	})
 }
 
 // executeLeaderRoutine executes the leader's routine for the given coordination
@@ -580,10 +736,11 @@ func (ce *coordinationExecutor) executeLeaderRoutine(
 
 	proposal, err := ce.generateProposal(
 		&CoordinationProposalRequest{
-			WalletPublicKeyHash: walletPublicKeyHash,
-			WalletOperators:     ce.coordinatedWallet.signingGroupOperators,
-			ExecutingOperator:   ce.operatorAddress,
-			ActionsChecklist:    actionsChecklist,
+			WalletPublicKeyHash:  walletPublicKeyHash,
+			WalletOperators:      ce.coordinatedWallet.signingGroupOperators,
+			ExecutingOperator:    ce.operatorAddress,
+			ActionsChecklist:     actionsChecklist,
+			UpgradedCoordination: newCoordinationWindow(coordinationBlock).isUpgraded(),
 		},
 		2,             // 2 attempts at most
 		1*time.Minute, // 1 minute between attempts
@@ -652,15 +809,22 @@ func (ce *coordinationExecutor) generateProposal(
 }
 
 // executeFollowerRoutine executes the follower's routine for the given coordination
-// window. The routine listens for the coordination message from the leader and
-// validates it. If the leader's proposal is valid, it returns the received
-// proposal. Returns an error if the routine failed.
+// window. The routine listens for the coordination message from the given
+// leaders, each in their slot of the active phase, and validates it. The
+// first leader is followed from the start of the active phase. If there are
+// more leaders, the routine moves on to the next one once the next slot
+// starts and ignores the messages of the preceding leaders from then on,
+// even if they were received before but not yet processed. The context
+// should be cancelled at the end of the last leader's slot. The first valid
+// proposal of the leader of the current slot is accepted and returned along
+// with the leader's address. Each operator is charged with at most one fault
+// in the window. Returns an error if the routine failed.
 func (ce *coordinationExecutor) executeFollowerRoutine(
 	ctx context.Context,
-	leader chain.Address,
-	coordinationBlock uint64,
+	window *coordinationWindow,
+	leaders []chain.Address,
 	actionsAllowed []WalletActionType,
-) (CoordinationProposal, []*coordinationFault, error) {
+) (CoordinationProposal, chain.Address, []*coordinationFault, error) {
 	// Cache wallet public key hash to not compute it on every message.
 	walletPublicKeyHash := ce.walletPublicKeyHash()
 	// Leader ID is the index of the first (index-wise) member controlled by
@@ -669,10 +833,68 @@ func (ce *coordinationExecutor) executeFollowerRoutine(
 	// It is enough to take the first member from the list. No need
 	// to check for list length as it is guaranteed that the leader operator
 	// is one of the operators backing the wallet.
-	leaderID := ce.coordinatedWallet.membersByOperator(leader)[0]
+	leadersIDs := make([]group.MemberIndex, len(leaders))
+	for i, leader := range leaders {
+		leadersIDs[i] = ce.coordinatedWallet.membersByOperator(leader)[0]
+	}
 
 	var faults []*coordinationFault
 
+	// addFault records the fault unless the culprit has already been
+	// charged with a fault in this window. For example, a leader who
+	// proposed an invalid action and did not correct it by the end of their
+	// slot is charged with the mistake only.
+	addFault := func(culprit chain.Address, faultType CoordinationFaultType) {
+		for _, fault := range faults {
+			if fault.culprit == culprit {
+				return
+			}
+		}
+
+		faults = append(
+			faults, &coordinationFault{
+				culprit:   culprit,
+				faultType: faultType,
+			},
+		)
+	}
+
+	// idlenessFaults records the idleness of the leaders of the given
+	// number of the first slots.
+	idlenessFaults := func(slotsCount int) {
+		for _, leader := range leaders[:slotsCount] {
+			addFault(leader, FaultLeaderIdleness)
+		}
+	}
+
+	currentSlot := 0
+	slotsChan := make(chan int, len(leaders))
+
+	// updateSlot moves on to the latest started slot. It is called before
+	// processing every message so the slot in progress does not depend on
+	// the order in which the ready channels are selected.
+	updateSlot := func() {
+		for {
+			select {
+			case slot := <-slotsChan:
+				currentSlot = slot
+			default:
+				return
+			}
+		}
+	}
+
+	go func() {
+		for slot := 1; slot < len(leaders); slot++ {
+			err := ce.waitForBlockFn(ctx, window.leaderSlotStartBlock(slot))
+			if err != nil || ctx.Err() != nil {
+				return
+			}
+
+			slotsChan <- slot
+		}
+	}()
+
 	messagesChan := make(chan net.Message, coordinationMessageReceiveBuffer)
 
 	ce.broadcastChannel.Recv(ctx, func(message net.Message) {
@@ -682,7 +904,11 @@ func (ce *coordinationExecutor) executeFollowerRoutine(
 loop:
 	for {
 		select {
+		case slot := <-slotsChan:
+			currentSlot = slot
 		case netMessage := <-messagesChan:
+			updateSlot()
+
 			// Filter out messages of wrong type.
 			message, ok := netMessage.Payload().(*coordinationMessage)
 			if !ok {
@@ -703,7 +929,7 @@ loop:
 			}
 
 			// Filter out messages with wrong coordination block.
-			if coordinationBlock != message.coordinationBlock {
+			if window.coordinationBlock != message.coordinationBlock {
 				continue
 			}
 
@@ -713,43 +939,39 @@ loop:
 			}
 
 			// Filter out messages from leader's impersonators.
-			if leaderID != message.senderID {
+			senderSlot := slices.Index(leadersIDs, message.senderID)
+			if senderSlot < 0 {
 				sender := ce.chain.Signing().PublicKeyBytesToAddress(
 					netMessage.SenderPublicKey(),
 				)
-				faults = append(
-					faults, &coordinationFault{
-						culprit:   sender,
-						faultType: FaultLeaderImpersonation,
-					},
-				)
+				addFault(sender, FaultLeaderImpersonation)
+				continue
+			}
+
+			// Filter out messages from leaders whose slot is not in progress.
+			// Those may be late retransmissions of the preceding leaders or
+			// messages of the next leaders observing the chain slightly
+			// ahead of this follower so they are not considered faults.
+			if senderSlot != currentSlot {
 				continue
 			}
 
 			// Filter out messages that propose an action that is not allowed
 			// for the given coordination window.
 			if !slices.Contains(actionsAllowed, message.proposal.ActionType()) {
-				faults = append(
-					faults, &coordinationFault{
-						culprit:   leader,
-						faultType: FaultLeaderMistake,
-					},
-				)
+				addFault(leaders[currentSlot], FaultLeaderMistake)
 				continue
 			}
 
-			return message.proposal, faults, nil
+			idlenessFaults(currentSlot)
+
+			return message.proposal, leaders[currentSlot], faults, nil
 		case <-ctx.Done():
 			break loop
 		}
 	}
 
-	faults = append(
-		faults, &coordinationFault{
-			culprit:   leader,
-			faultType: FaultLeaderIdleness,
-		},
-	)
+	idlenessFaults(len(leaders))
 
-	return nil, faults, fmt.Errorf("coordination message not received on time")
+	return nil, "", faults, errCoordinationMessageNotReceived
 }
@@ -0,0 +1,370 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+)
+
+const (
+	// coordinationFaultsDirectory is the name of the work persistence
+	// directory holding the coordination faults accounting.
+	coordinationFaultsDirectory = "coordination_faults"
+	// coordinationFaultyLeaderThreshold is the number of consecutive
+	// coordination windows in which the leader of the given wallet has been
+	// faulty after which the leader is considered repeatedly faulty.
+	coordinationFaultyLeaderThreshold = 3
+)
+
+// LeaderFaults aggregates the coordination faults of a single operator
+// across the coordination windows of a single wallet.
+type LeaderFaults struct {
+	// Faults holds the number of faults per fault type.
+	Faults map[string]uint `json:"faults"`
+	// ConsecutiveFaultyWindows is the number of the most recent coordination
+	// windows led by the operator that were faulty. Reset once the operator
+	// leads a window without faults.
+	ConsecutiveFaultyWindows uint `json:"consecutive_faulty_windows"`
+	// LastFaultBlock is the coordination block of the last faulty window.
+	LastFaultBlock uint64 `json:"last_fault_block"`
+}
+
+// WalletCoordinationFaults aggregates the coordination faults observed in
+// the coordination windows of a single wallet.
+type WalletCoordinationFaults struct {
+	WalletPublicKeyHash string `json:"wallet_public_key_hash"`
+	// Leaders holds the faults of the operators, keyed by the operator
+	// address.
+	Leaders map[string]*LeaderFaults `json:"leaders"`
+}
+
+// coordinationFaultsAccounting aggregates the coordination faults observed
+// by the node per wallet and per operator responsible for them, and keeps
+// them in the work persistence so they survive client restarts.
+type coordinationFaultsAccounting struct {
+	mutex       sync.Mutex
+	persistence persistence.BasicHandle
+	// wallets holds the aggregated faults, keyed by the wallet public key
+	// hash hex.
+	wallets map[string]*WalletCoordinationFaults
+}
+
+// newCoordinationFaultsAccounting creates the coordination faults accounting
+// and loads the faults persisted by the previous client runs.
+func newCoordinationFaultsAccounting(
+	persistence persistence.BasicHandle,
+) *coordinationFaultsAccounting {
+	cfa := &coordinationFaultsAccounting{
+		persistence: persistence,
+		wallets:     make(map[string]*WalletCoordinationFaults),
+	}
+
+	cfa.load()
+
+	return cfa
+}
+
+func (cfa *coordinationFaultsAccounting) load() {
+	descriptorsChan, errorsChan := cfa.persistence.ReadAll()
+
+	// Read descriptors and errors in separate goroutines as the channels
+	// are not buffered and we do not know the order they are written in.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != coordinationFaultsDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read coordination faults from file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			walletFaults := &WalletCoordinationFaults{}
+			if err := json.Unmarshal(content, walletFaults); err != nil {
+				logger.Errorf(
+					"could not unmarshal coordination faults from file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			cfa.wallets[walletFaults.WalletPublicKeyHash] = walletFaults
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			logger.Errorf("could not load coordination faults: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+}
+
+// record accounts the faults of the given coordination result. Faults are
+// attributed to the operators responsible for them. The result's leader
+// consecutive faulty windows counter is reset if the result has no faults
+// of the leader.
+func (cfa *coordinationFaultsAccounting) record(result *coordinationResult) {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(result.wallet.publicKey)
+	walletKey := hex.EncodeToString(walletPublicKeyHash[:])
+
+	cfa.mutex.Lock()
+	defer cfa.mutex.Unlock()
+
+	walletFaults, ok := cfa.wallets[walletKey]
+	if !ok {
+		if len(result.faults) == 0 {
+			// Nothing to account.
+			return
+		}
+
+		walletFaults = &WalletCoordinationFaults{
+			WalletPublicKeyHash: walletKey,
+			Leaders:             make(map[string]*LeaderFaults),
+		}
+		cfa.wallets[walletKey] = walletFaults
+	}
+
+	changed := false
+
+	// A single window may have several faults of the same operator, e.g.
+	// the leader was idle and another operator impersonated them. The
+	// window counts once for each operator.
+	faultyOperators := make(map[chain.Address]bool)
+	for _, fault := range result.faults {
+		leaderFaults, ok := walletFaults.Leaders[fault.culprit.String()]
+		if !ok {
+			leaderFaults = &LeaderFaults{Faults: make(map[string]uint)}
+			walletFaults.Leaders[fault.culprit.String()] = leaderFaults
+		}
+
+		leaderFaults.Faults[fault.faultType.String()]++
+		leaderFaults.LastFaultBlock = result.window.coordinationBlock
+
+		if !faultyOperators[fault.culprit] {
+			faultyOperators[fault.culprit] = true
+			leaderFaults.ConsecutiveFaultyWindows++
+
+			if leaderFaults.ConsecutiveFaultyWindows ==
+				coordinationFaultyLeaderThreshold {
+				logger.Warnf(
+					"operator [%s] has been faulty in [%v] consecutive "+
+						"coordination windows of wallet [0x%s]",
+					fault.culprit,
+					leaderFaults.ConsecutiveFaultyWindows,
+					walletKey,
+				)
+			}
+		}
+
+		changed = true
+	}
+
+	// The leader and the backup leader whose proposal was accepted, if any,
+	// led the window. Reset their consecutive faulty windows if they were
+	// not faulty.
+	for _, operator := range []chain.Address{result.leader, result.proposer} {
+		if operator == "" || faultyOperators[operator] {
+			continue
+		}
+
+		if leaderFaults, ok := walletFaults.Leaders[operator.String()]; ok &&
+			leaderFaults.ConsecutiveFaultyWindows > 0 {
+			leaderFaults.ConsecutiveFaultyWindows = 0
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := cfa.save(walletFaults); err != nil {
+			logger.Errorf(
+				"could not persist coordination faults of wallet [0x%s]: [%v]",
+				walletKey,
+				err,
+			)
+		}
+	}
+}
+
+// save must be called with the mutex held.
+func (cfa *coordinationFaultsAccounting) save(
+	walletFaults *WalletCoordinationFaults,
+) error {
+	content, err := json.Marshal(walletFaults)
+	if err != nil {
+		return fmt.Errorf("could not marshal coordination faults: [%w]", err)
+	}
+
+	return cfa.persistence.Save(
+		content,
+		coordinationFaultsDirectory,
+		walletFaults.WalletPublicKeyHash,
+	)
+}
+
+// isRepeatedlyFaulty returns true if the given operator has been faulty in
+// at least coordinationFaultyLeaderThreshold consecutive coordination windows
+// of the wallet with the given public key hash. The result can be used as
+// an input for inactivity claims or leader exclusion heuristics.
+func (cfa *coordinationFaultsAccounting) isRepeatedlyFaulty(
+	walletPublicKeyHash [20]byte,
+	operator chain.Address,
+) bool {
+	cfa.mutex.Lock()
+	defer cfa.mutex.Unlock()
+
+	walletFaults, ok := cfa.wallets[hex.EncodeToString(walletPublicKeyHash[:])]
+	if !ok {
+		return false
+	}
+
+	leaderFaults, ok := walletFaults.Leaders[operator.String()]
+	if !ok {
+		return false
+	}
+
+	return leaderFaults.ConsecutiveFaultyWindows >=
+		coordinationFaultyLeaderThreshold
+}
+
+// totalFaults returns the number of all the accounted faults.
+func (cfa *coordinationFaultsAccounting) totalFaults() uint {
+	cfa.mutex.Lock()
+	defer cfa.mutex.Unlock()
+
+	total := uint(0)
+	for _, walletFaults := range cfa.wallets {
+		for _, leaderFaults := range walletFaults.Leaders {
+			for _, count := range leaderFaults.Faults {
+				total += count
+			}
+		}
+	}
+
+	return total
+}
+
+// repeatedlyFaultyOperatorsCount returns the number of wallet operators
+// that are repeatedly faulty.
+func (cfa *coordinationFaultsAccounting) repeatedlyFaultyOperatorsCount() int {
+	cfa.mutex.Lock()
+	defer cfa.mutex.Unlock()
+
+	count := 0
+	for _, walletFaults := range cfa.wallets {
+		for _, leaderFaults := range walletFaults.Leaders {
+			if leaderFaults.ConsecutiveFaultyWindows >=
+				coordinationFaultyLeaderThreshold {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// recentFaults returns the accounted faults of at most the given number of
+// operators who were faulty most recently, ordered by their last faulty
+// window, oldest first.
+func (cfa *coordinationFaultsAccounting) recentFaults(
+	limit int,
+) []*CoordinationFaultRecord {
+	cfa.mutex.Lock()
+	defer cfa.mutex.Unlock()
+
+	records := make([]*CoordinationFaultRecord, 0)
+	for _, walletFaults := range cfa.wallets {
+		for operator, leaderFaults := range walletFaults.Leaders {
+			faults := make(map[string]uint)
+			for faultType, count := range leaderFaults.Faults {
+				faults[faultType] = count
+			}
+
+			records = append(records, &CoordinationFaultRecord{
+				WalletPublicKeyHash:      walletFaults.WalletPublicKeyHash,
+				Culprit:                  operator,
+				Faults:                   faults,
+				ConsecutiveFaultyWindows: leaderFaults.ConsecutiveFaultyWindows,
+				LastFaultBlock:           leaderFaults.LastFaultBlock,
+			})
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].LastFaultBlock != records[j].LastFaultBlock {
+			return records[i].LastFaultBlock < records[j].LastFaultBlock
+		}
+		if records[i].WalletPublicKeyHash != records[j].WalletPublicKeyHash {
+			return records[i].WalletPublicKeyHash < records[j].WalletPublicKeyHash
+		}
+		return records[i].Culprit < records[j].Culprit
+	})
+
+	if excess := len(records) - limit; excess > 0 {
+		records = records[excess:]
+	}
+
+	return records
+}
+
+// diagnostics returns the accounted faults of all the wallets.
+func (cfa *coordinationFaultsAccounting) diagnostics() clientinfo.ApplicationInfo {
+	cfa.mutex.Lock()
+	defer cfa.mutex.Unlock()
+
+	// Copy the faults so they are not modified during serialization.
+	wallets := make([]*WalletCoordinationFaults, 0, len(cfa.wallets))
+	for _, walletFaults := range cfa.wallets {
+		walletFaultsCopy := &WalletCoordinationFaults{
+			WalletPublicKeyHash: walletFaults.WalletPublicKeyHash,
+			Leaders:             make(map[string]*LeaderFaults),
+		}
+
+		for operator, leaderFaults := range walletFaults.Leaders {
+			faults := make(map[string]uint)
+			for faultType, count := range leaderFaults.Faults {
+				faults[faultType] = count
+			}
+
+			walletFaultsCopy.Leaders[operator] = &LeaderFaults{
+				Faults:                   faults,
+				ConsecutiveFaultyWindows: leaderFaults.ConsecutiveFaultyWindows,
+				LastFaultBlock:           leaderFaults.LastFaultBlock,
+			}
+		}
+
+		wallets = append(wallets, walletFaultsCopy)
+	}
+
+	sort.Slice(wallets, func(i, j int) bool {
+		return wallets[i].WalletPublicKeyHash < wallets[j].WalletPublicKeyHash
+	})
+
+	return clientinfo.ApplicationInfo{
+		"repeatedly_faulty_threshold": coordinationFaultyLeaderThreshold,
+		"wallets":                     wallets,
+	}
+}
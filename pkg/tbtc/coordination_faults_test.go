@@ -0,0 +1,122 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestCoordinationFaultsAccounting(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+	accounting := newCoordinationFaultsAccounting(persistenceHandle)
+
+	walletPublicKey := createMockSigner(t).wallet.publicKey
+	walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+	idleLeader := chain.Address("idle-leader")
+	impersonator := chain.Address("impersonator")
+
+	newResult := func(
+		windowIndex uint64,
+		leader chain.Address,
+		faults ...*coordinationFault,
+	) *coordinationResult {
+		return &coordinationResult{
+			wallet:   wallet{publicKey: walletPublicKey},
+			window:   newCoordinationWindow(windowIndex * coordinationFrequencyBlocks),
+			leader:   leader,
+			proposal: &NoopProposal{},
+			faults:   faults,
+		}
+	}
+
+	// A window without faults of a leader with no accounted faults should
+	// not be persisted.
+	accounting.record(newResult(1, "honest-leader"))
+	testutils.AssertIntsEqual(t, "saved count", 0, len(persistenceHandle.saved))
+
+	for i := uint64(2); i < 2+coordinationFaultyLeaderThreshold; i++ {
+		accounting.record(newResult(
+			i,
+			idleLeader,
+			&coordinationFault{
+				culprit:   idleLeader,
+				faultType: FaultLeaderIdleness,
+			},
+			&coordinationFault{
+				culprit:   impersonator,
+				faultType: FaultLeaderImpersonation,
+			},
+		))
+	}
+
+	testutils.AssertBoolsEqual(
+		t,
+		"idle leader repeatedly faulty",
+		true,
+		accounting.isRepeatedlyFaulty(walletPublicKeyHash, idleLeader),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"impersonator repeatedly faulty",
+		true,
+		accounting.isRepeatedlyFaulty(walletPublicKeyHash, impersonator),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"total faults",
+		2*coordinationFaultyLeaderThreshold,
+		uint64(accounting.totalFaults()),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"repeatedly faulty operators",
+		2,
+		accounting.repeatedlyFaultyOperatorsCount(),
+	)
+
+	// The idle leader leads a window without faults.
+	accounting.record(newResult(10, idleLeader))
+
+	testutils.AssertBoolsEqual(
+		t,
+		"idle leader repeatedly faulty",
+		false,
+		accounting.isRepeatedlyFaulty(walletPublicKeyHash, idleLeader),
+	)
+
+	// The faults should be restored from the persistence.
+	restored := newCoordinationFaultsAccounting(persistenceHandle)
+
+	if !reflect.DeepEqual(accounting.wallets, restored.wallets) {
+		t.Errorf(
+			"unexpected restored faults\nexpected: %v\nactual:   %v",
+			accounting.wallets,
+			restored.wallets,
+		)
+	}
+
+	leaderFaults := restored.wallets[hex.EncodeToString(walletPublicKeyHash[:])].
+		Leaders[idleLeader.String()]
+
+	expectedFaults := map[string]uint{
+		"LeaderIdleness": coordinationFaultyLeaderThreshold,
+	}
+	if !reflect.DeepEqual(expectedFaults, leaderFaults.Faults) {
+		t.Errorf(
+			"unexpected faults\nexpected: %v\nactual:   %v",
+			expectedFaults,
+			leaderFaults.Faults,
+		)
+	}
+	testutils.AssertUintsEqual(
+		t,
+		"last fault block",
+		(1+coordinationFaultyLeaderThreshold)*coordinationFrequencyBlocks,
+		leaderFaults.LastFaultBlock,
+	)
+}
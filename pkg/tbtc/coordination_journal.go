@@ -0,0 +1,505 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-common/pkg/persistence"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+)
+
+const (
+	// coordinationJournalDirectory is the name of the work persistence
+	// directory holding the coordination journal.
+	coordinationJournalDirectory = "coordination_journal"
+	// coordinationJournalOutcomeSuffix is the suffix of the names of the
+	// journal files holding the outcomes of the dispatched actions.
+	coordinationJournalOutcomeSuffix = "_outcome"
+
+	// DefaultCoordinationJournalRetention is the default number of the most
+	// recent coordination windows kept in the journal for each wallet. With
+	// a window every 900 blocks, it covers about two weeks.
+	DefaultCoordinationJournalRetention = 112
+)
+
+const (
+	// ActionOutcomeNotDispatched denotes the proposed action was not
+	// dispatched, e.g. because the wallet was busy.
+	ActionOutcomeNotDispatched = "NotDispatched"
+	// ActionOutcomeSucceeded denotes the dispatched action succeeded.
+	ActionOutcomeSucceeded = "Succeeded"
+	// ActionOutcomeFailed denotes the dispatched action failed.
+	ActionOutcomeFailed = "Failed"
+)
+
+// CoordinationJournalFault describes a fault observed in a coordination
+// window.
+type CoordinationJournalFault struct {
+	Culprit string `json:"culprit"`
+	Fault   string `json:"fault"`
+}
+
+// CoordinationActionOutcome describes the outcome of the action proposed in
+// a coordination window.
+type CoordinationActionOutcome struct {
+	// Status is one of: NotDispatched, Succeeded, Failed.
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// CoordinationJournalEntry describes what happened in a coordination window
+// of a single wallet.
+type CoordinationJournalEntry struct {
+	WalletPublicKeyHash string `json:"wallet_public_key_hash"`
+	CoordinationBlock   uint64 `json:"coordination_block"`
+	// Error is the error the coordination failed with; empty if the
+	// coordination completed. Failed windows hold no seed, leader, actions
+	// checklist and proposed action.
+	Error            string   `json:"error,omitempty"`
+	Seed             string   `json:"seed"`
+	Leader           string   `json:"leader"`
+	ActionsChecklist []string `json:"actions_checklist"`
+	ProposedAction   string   `json:"proposed_action"`
+	// Proposer is the leader or the backup leader whose proposal was
+	// accepted; empty if no proposal was received.
+	Proposer string `json:"proposer,omitempty"`
+	// Proposal is the proposal of the leader; empty for no-op proposals.
+	Proposal   json.RawMessage             `json:"proposal,omitempty"`
+	Faults     []*CoordinationJournalFault `json:"faults"`
+	RecordedAt time.Time                   `json:"recorded_at"`
+	// Outcome is the outcome of the proposed action; empty for no-op
+	// proposals and for actions still in progress.
+	Outcome *CoordinationActionOutcome `json:"outcome,omitempty"`
+}
+
+// coordinationJournalOutcomeRecord is the persisted outcome of the action
+// proposed in a coordination window. Outcomes are persisted separately from
+// the window entries so the journal files are never modified once written.
+type coordinationJournalOutcomeRecord struct {
+	WalletPublicKeyHash string                     `json:"wallet_public_key_hash"`
+	CoordinationBlock   uint64                     `json:"coordination_block"`
+	Outcome             *CoordinationActionOutcome `json:"outcome"`
+}
+
+// coordinationJournal is an append-only journal of the coordination windows
+// of the wallets controlled by the node. The journal is kept in the work
+// persistence and holds the given number of the most recent windows of each
+// wallet.
+type coordinationJournal struct {
+	mutex       sync.Mutex
+	persistence persistence.BasicHandle
+	retention   int
+	// entries holds the journal entries sorted by the coordination block,
+	// keyed by the wallet public key hash hex.
+	entries map[string][]*CoordinationJournalEntry
+}
+
+// newCoordinationJournal creates the coordination journal keeping the given
+// number of windows per wallet and loads the entries persisted by the
+// previous client runs. Non-positive retention means the default one.
+func newCoordinationJournal(
+	persistence persistence.BasicHandle,
+	retention int,
+) *coordinationJournal {
+	if retention <= 0 {
+		retention = DefaultCoordinationJournalRetention
+	}
+
+	cj := &coordinationJournal{
+		persistence: persistence,
+		retention:   retention,
+		entries:     make(map[string][]*CoordinationJournalEntry),
+	}
+
+	cj.load()
+
+	return cj
+}
+
+func (cj *coordinationJournal) load() {
+	descriptorsChan, errorsChan := cj.persistence.ReadAll()
+
+	outcomes := make([]*coordinationJournalOutcomeRecord, 0)
+
+	// Read descriptors and errors in separate goroutines as the channels
+	// are not buffered and we do not know the order they are written in.
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		for descriptor := range descriptorsChan {
+			if descriptor.Directory() != coordinationJournalDirectory {
+				continue
+			}
+
+			content, err := descriptor.Content()
+			if err != nil {
+				logger.Errorf(
+					"could not read coordination journal file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			if strings.HasSuffix(
+				descriptor.Name(),
+				coordinationJournalOutcomeSuffix,
+			) {
+				outcome := &coordinationJournalOutcomeRecord{}
+				if err := json.Unmarshal(content, outcome); err != nil {
+					logger.Errorf(
+						"could not unmarshal coordination journal file [%s]: [%v]",
+						descriptor.Name(),
+						err,
+					)
+					continue
+				}
+
+				outcomes = append(outcomes, outcome)
+				continue
+			}
+
+			entry := &CoordinationJournalEntry{}
+			if err := json.Unmarshal(content, entry); err != nil {
+				logger.Errorf(
+					"could not unmarshal coordination journal file [%s]: [%v]",
+					descriptor.Name(),
+					err,
+				)
+				continue
+			}
+
+			cj.entries[entry.WalletPublicKeyHash] = append(
+				cj.entries[entry.WalletPublicKeyHash],
+				entry,
+			)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for err := range errorsChan {
+			logger.Errorf("could not load coordination journal: [%v]", err)
+		}
+	}()
+
+	wg.Wait()
+
+	for _, outcome := range outcomes {
+		if entry := cj.findEntry(
+			outcome.WalletPublicKeyHash,
+			outcome.CoordinationBlock,
+		); entry != nil {
+			entry.Outcome = outcome.Outcome
+		}
+	}
+
+	for walletKey, entries := range cj.entries {
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].CoordinationBlock < entries[j].CoordinationBlock
+		})
+
+		// The retention may have been lowered since the previous run.
+		cj.prune(walletKey)
+	}
+}
+
+// recordWindow appends the given coordination result to the journal.
+func (cj *coordinationJournal) recordWindow(result *coordinationResult) {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(result.wallet.publicKey)
+
+	actionsChecklist := make([]string, len(result.actionsChecklist))
+	for i, action := range result.actionsChecklist {
+		actionsChecklist[i] = action.String()
+	}
+
+	entry := &CoordinationJournalEntry{
+		WalletPublicKeyHash: hex.EncodeToString(walletPublicKeyHash[:]),
+		CoordinationBlock:   result.window.coordinationBlock,
+		Seed:                hex.EncodeToString(result.seed[:]),
+		Leader:              result.leader.String(),
+		Proposer:            result.proposer.String(),
+		ActionsChecklist:    actionsChecklist,
+		ProposedAction:      result.proposal.ActionType().String(),
+		Faults:              journalFaults(result.faults),
+		RecordedAt:          time.Now(),
+	}
+
+	if result.proposal.ActionType() != ActionNoop {
+		proposal, err := json.Marshal(result.proposal)
+		if err != nil {
+			logger.Errorf(
+				"could not marshal proposal of coordination result [%s]: [%v]",
+				result,
+				err,
+			)
+		} else {
+			entry.Proposal = proposal
+		}
+	}
+
+	cj.appendEntry(entry)
+}
+
+// recordFailedWindow appends the coordination window of the given wallet
+// that failed with the given error to the journal, along with the faults
+// observed before the failure.
+func (cj *coordinationJournal) recordFailedWindow(
+	wallet wallet,
+	window *coordinationWindow,
+	err error,
+	faults []*coordinationFault,
+) {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+	cj.appendEntry(&CoordinationJournalEntry{
+		WalletPublicKeyHash: hex.EncodeToString(walletPublicKeyHash[:]),
+		CoordinationBlock:   window.coordinationBlock,
+		Error:               err.Error(),
+		Faults:              journalFaults(faults),
+		RecordedAt:          time.Now(),
+	})
+}
+
+// appendEntry persists the given entry and appends it to the journal unless
+// the entry's window is already in the journal.
+func (cj *coordinationJournal) appendEntry(entry *CoordinationJournalEntry) {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		logger.Errorf("could not marshal coordination journal entry: [%v]", err)
+		return
+	}
+
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	if cj.findEntry(
+		entry.WalletPublicKeyHash,
+		entry.CoordinationBlock,
+	) != nil {
+		// The journal is append-only.
+		logger.Warnf(
+			"coordination window at block [%v] of wallet [0x%s] is already "+
+				"in the journal",
+			entry.CoordinationBlock,
+			entry.WalletPublicKeyHash,
+		)
+		return
+	}
+
+	if err := cj.persistence.Save(
+		content,
+		coordinationJournalDirectory,
+		coordinationJournalFileName(entry.WalletPublicKeyHash, entry.CoordinationBlock),
+	); err != nil {
+		logger.Errorf(
+			"could not persist coordination journal entry for window at "+
+				"block [%v] of wallet [0x%s]: [%v]",
+			entry.CoordinationBlock,
+			entry.WalletPublicKeyHash,
+			err,
+		)
+		return
+	}
+
+	entries := append(cj.entries[entry.WalletPublicKeyHash], entry)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CoordinationBlock < entries[j].CoordinationBlock
+	})
+	cj.entries[entry.WalletPublicKeyHash] = entries
+
+	cj.prune(entry.WalletPublicKeyHash)
+}
+
+// recordNotDispatched records that the action proposed in the given
+// coordination result was not dispatched due to the given error.
+func (cj *coordinationJournal) recordNotDispatched(
+	result *coordinationResult,
+	err error,
+) {
+	cj.recordOutcome(result, &CoordinationActionOutcome{
+		Status:     ActionOutcomeNotDispatched,
+		Error:      err.Error(),
+		RecordedAt: time.Now(),
+	})
+}
+
+// outcomeFn returns a function recording the execution outcome of the action
+// proposed in the given coordination result.
+func (cj *coordinationJournal) outcomeFn(
+	result *coordinationResult,
+) walletActionOutcomeFn {
+	return func(err error) {
+		outcome := &CoordinationActionOutcome{
+			Status:     ActionOutcomeSucceeded,
+			RecordedAt: time.Now(),
+		}
+		if err != nil {
+			outcome.Status = ActionOutcomeFailed
+			outcome.Error = err.Error()
+		}
+
+		cj.recordOutcome(result, outcome)
+	}
+}
+
+func (cj *coordinationJournal) recordOutcome(
+	result *coordinationResult,
+	outcome *CoordinationActionOutcome,
+) {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(result.wallet.publicKey)
+
+	record := &coordinationJournalOutcomeRecord{
+		WalletPublicKeyHash: hex.EncodeToString(walletPublicKeyHash[:]),
+		CoordinationBlock:   result.window.coordinationBlock,
+		Outcome:             outcome,
+	}
+
+	content, err := json.Marshal(record)
+	if err != nil {
+		logger.Errorf("could not marshal coordination action outcome: [%v]", err)
+		return
+	}
+
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	entry := cj.findEntry(record.WalletPublicKeyHash, record.CoordinationBlock)
+	if entry == nil {
+		// The entry could not be persisted or was already pruned.
+		return
+	}
+
+	if err := cj.persistence.Save(
+		content,
+		coordinationJournalDirectory,
+		coordinationJournalFileName(record.WalletPublicKeyHash, record.CoordinationBlock)+
+			coordinationJournalOutcomeSuffix,
+	); err != nil {
+		logger.Errorf(
+			"could not persist outcome of coordination window at block [%v] "+
+				"of wallet [0x%s]: [%v]",
+			record.CoordinationBlock,
+			record.WalletPublicKeyHash,
+			err,
+		)
+		return
+	}
+
+	entry.Outcome = outcome
+}
+
+// findEntry must be called with the mutex held or before the journal is
+// shared.
+func (cj *coordinationJournal) findEntry(
+	walletKey string,
+	coordinationBlock uint64,
+) *CoordinationJournalEntry {
+	for _, entry := range cj.entries[walletKey] {
+		if entry.CoordinationBlock == coordinationBlock {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// prune removes the entries of the given wallet exceeding the retention.
+// Must be called with the mutex held or before the journal is shared.
+func (cj *coordinationJournal) prune(walletKey string) {
+	entries := cj.entries[walletKey]
+
+	excess := len(entries) - cj.retention
+	if excess <= 0 {
+		return
+	}
+
+	for _, entry := range entries[:excess] {
+		name := coordinationJournalFileName(walletKey, entry.CoordinationBlock)
+
+		if err := cj.persistence.Delete(
+			coordinationJournalDirectory,
+			name,
+		); err != nil {
+			logger.Errorf(
+				"could not delete coordination journal file [%s]: [%v]",
+				name,
+				err,
+			)
+		}
+
+		if entry.Outcome != nil {
+			if err := cj.persistence.Delete(
+				coordinationJournalDirectory,
+				name+coordinationJournalOutcomeSuffix,
+			); err != nil {
+				logger.Errorf(
+					"could not delete coordination journal file [%s]: [%v]",
+					name+coordinationJournalOutcomeSuffix,
+					err,
+				)
+			}
+		}
+	}
+
+	cj.entries[walletKey] = append(
+		[]*CoordinationJournalEntry{},
+		entries[excess:]...,
+	)
+}
+
+// diagnostics returns the journal entries of all the wallets, sorted by the
+// coordination block.
+func (cj *coordinationJournal) diagnostics() clientinfo.ApplicationInfo {
+	cj.mutex.Lock()
+	defer cj.mutex.Unlock()
+
+	entries := make([]CoordinationJournalEntry, 0)
+	for _, walletEntries := range cj.entries {
+		for _, entry := range walletEntries {
+			// Copy the entry as its outcome may be set during serialization.
+			entries = append(entries, *entry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].CoordinationBlock != entries[j].CoordinationBlock {
+			return entries[i].CoordinationBlock < entries[j].CoordinationBlock
+		}
+
+		return entries[i].WalletPublicKeyHash < entries[j].WalletPublicKeyHash
+	})
+
+	return clientinfo.ApplicationInfo{
+		"retention": cj.retention,
+		"entries":   entries,
+	}
+}
+
+func journalFaults(faults []*coordinationFault) []*CoordinationJournalFault {
+	records := make([]*CoordinationJournalFault, len(faults))
+	for i, fault := range faults {
+		records[i] = &CoordinationJournalFault{
+			Culprit: fault.culprit.String(),
+			Fault:   fault.faultType.String(),
+		}
+	}
+
+	return records
+}
+
+func coordinationJournalFileName(walletKey string, coordinationBlock uint64) string {
+	return fmt.Sprintf("%s_%v", walletKey, coordinationBlock)
+}
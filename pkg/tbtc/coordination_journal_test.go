@@ -0,0 +1,205 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestCoordinationJournal(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+	journal := newCoordinationJournal(persistenceHandle, 2)
+
+	walletPublicKey := createMockSigner(t).wallet.publicKey
+	walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+	newResult := func(
+		windowIndex uint64,
+		proposal CoordinationProposal,
+	) *coordinationResult {
+		return &coordinationResult{
+			wallet: wallet{publicKey: walletPublicKey},
+			window: newCoordinationWindow(
+				windowIndex * coordinationFrequencyBlocks,
+			),
+			seed:             [32]byte{byte(windowIndex)},
+			leader:           chain.Address("leader"),
+			actionsChecklist: []WalletActionType{ActionRedemption},
+			proposal:         proposal,
+			faults: []*coordinationFault{
+				{
+					culprit:   chain.Address("leader"),
+					faultType: FaultLeaderMistake,
+				},
+			},
+		}
+	}
+
+	redemptionProposal := &RedemptionProposal{
+		RedeemersOutputScripts: []bitcoin.Script{{0x00, 0x14}},
+		RedemptionTxFee:        big.NewInt(10000),
+	}
+
+	journal.recordWindow(newResult(1, &NoopProposal{}))
+	journal.recordWindow(newResult(2, redemptionProposal))
+	journal.recordWindow(newResult(3, redemptionProposal))
+
+	// Windows are recorded once.
+	journal.recordWindow(newResult(3, &NoopProposal{}))
+
+	journal.outcomeFn(newResult(2, redemptionProposal))(nil)
+	journal.recordNotDispatched(
+		newResult(3, redemptionProposal),
+		errWalletBusy,
+	)
+
+	// The first window should be pruned due to the retention.
+	assertEntries := func(journal *coordinationJournal) {
+		entries := journal.entries[hex.EncodeToString(walletPublicKeyHash[:])]
+
+		testutils.AssertIntsEqual(t, "entries count", 2, len(entries))
+
+		for i, expectedOutcome := range []string{
+			ActionOutcomeSucceeded,
+			ActionOutcomeNotDispatched,
+		} {
+			entry := entries[i]
+			description := fmt.Sprintf("entry [%v]", i)
+
+			testutils.AssertUintsEqual(
+				t,
+				description+" coordination block",
+				uint64(i+2)*coordinationFrequencyBlocks,
+				entry.CoordinationBlock,
+			)
+			testutils.AssertStringsEqual(
+				t,
+				description+" seed",
+				hex.EncodeToString([]byte{byte(i + 2)})+
+					hex.EncodeToString(make([]byte, 31)),
+				entry.Seed,
+			)
+			testutils.AssertStringsEqual(
+				t,
+				description+" proposed action",
+				ActionRedemption.String(),
+				entry.ProposedAction,
+			)
+			if !reflect.DeepEqual(
+				[]string{ActionRedemption.String()},
+				entry.ActionsChecklist,
+			) {
+				t.Errorf(
+					"unexpected %s actions checklist: %v",
+					description,
+					entry.ActionsChecklist,
+				)
+			}
+			if !reflect.DeepEqual(
+				[]*CoordinationJournalFault{
+					{Culprit: "leader", Fault: FaultLeaderMistake.String()},
+				},
+				entry.Faults,
+			) {
+				t.Errorf("unexpected %s faults: %v", description, entry.Faults)
+			}
+			if len(entry.Proposal) == 0 {
+				t.Errorf("expected %s proposal", description)
+			}
+			if entry.Outcome == nil {
+				t.Fatalf("expected %s outcome", description)
+			}
+			testutils.AssertStringsEqual(
+				t,
+				description+" outcome",
+				expectedOutcome,
+				entry.Outcome.Status,
+			)
+		}
+
+		testutils.AssertStringsEqual(
+			t,
+			"not dispatched error",
+			errWalletBusy.Error(),
+			entries[1].Outcome.Error,
+		)
+	}
+
+	assertEntries(journal)
+
+	// Two windows along with their outcomes.
+	testutils.AssertIntsEqual(
+		t,
+		"persisted files count",
+		4,
+		len(persistenceHandle.saved),
+	)
+
+	// The journal should be restored from the persistence.
+	assertEntries(newCoordinationJournal(persistenceHandle, 2))
+}
+
+func TestCoordinationJournal_FailedWindow(t *testing.T) {
+	persistenceHandle := &mockPersistenceHandle{}
+	journal := newCoordinationJournal(persistenceHandle, 2)
+
+	walletPublicKey := createMockSigner(t).wallet.publicKey
+	walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+	journal.recordFailedWindow(
+		wallet{publicKey: walletPublicKey},
+		newCoordinationWindow(coordinationFrequencyBlocks),
+		&coordinationFailedError{
+			err: fmt.Errorf("failed to execute leader's routine"),
+		},
+		[]*coordinationFault{
+			{
+				culprit:   chain.Address("leader"),
+				faultType: FaultLeaderIdleness,
+			},
+		},
+	)
+
+	assertEntries := func(journal *coordinationJournal) {
+		entries := journal.entries[hex.EncodeToString(walletPublicKeyHash[:])]
+
+		testutils.AssertIntsEqual(t, "entries count", 1, len(entries))
+
+		entry := entries[0]
+
+		testutils.AssertUintsEqual(
+			t,
+			"coordination block",
+			coordinationFrequencyBlocks,
+			entry.CoordinationBlock,
+		)
+		testutils.AssertStringsEqual(
+			t,
+			"error",
+			"failed to execute leader's routine",
+			entry.Error,
+		)
+		if !reflect.DeepEqual(
+			[]*CoordinationJournalFault{
+				{Culprit: "leader", Fault: FaultLeaderIdleness.String()},
+			},
+			entry.Faults,
+		) {
+			t.Errorf("unexpected faults: %v", entry.Faults)
+		}
+		if entry.Outcome != nil {
+			t.Errorf("unexpected outcome: %v", entry.Outcome)
+		}
+	}
+
+	assertEntries(journal)
+
+	// The journal should be restored from the persistence.
+	assertEntries(newCoordinationJournal(persistenceHandle, 2))
+}
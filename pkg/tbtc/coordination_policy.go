@@ -0,0 +1,409 @@
+package tbtc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// coordinationLowPriorityActionsFrequency is the default frequency, in
+	// coordination windows, of checking actions other than redemptions and
+	// heartbeats.
+	coordinationLowPriorityActionsFrequency = 4
+	// walletStateDepositsLookBackBlocks is the look-back period in blocks
+	// used by the wallet state coordination policy when looking for pending
+	// deposits of the wallet. The period covers the refund locktime of the
+	// deposits, 270 days, assuming 12-second blocks. Deposits revealed
+	// earlier are not considered pending.
+	walletStateDepositsLookBackBlocks = uint64(1944000)
+)
+
+// CoordinationPolicyRequest represents a request for the actions checklist
+// of the given coordination window of the given wallet.
+type CoordinationPolicyRequest struct {
+	WalletPublicKeyHash [20]byte
+	WindowIndex         uint64
+	// SafeBlock is the block whose hash is an ingredient of the coordination
+	// seed. Policies reading the chain state must read it as of this block.
+	SafeBlock uint64
+	Seed      [32]byte
+}
+
+// CoordinationPolicy determines the wallet actions checked in the given
+// coordination window. The checklist is computed by both the leader and
+// the followers, and the followers consider proposals of actions not on
+// their checklist as the leader's mistake. That means all operators of
+// a wallet must compute the same checklist: implementations must be
+// deterministic and may depend only on the request and the chain state as
+// of the request's safe block. It also means all operators of a wallet must
+// use the same policy so the policy is not a local choice of the operator.
+type CoordinationPolicy interface {
+	// ActionsChecklist returns the list of wallet actions that should be
+	// checked in the given coordination window, in the order of priority.
+	// Returns nil for incorrect coordination windows whose index is 0.
+	ActionsChecklist(request *CoordinationPolicyRequest) ([]WalletActionType, error)
+}
+
+// newCoordinationPolicy creates the coordination policy used by all
+// operators: the default policy in coordination windows preceding the
+// coordination upgrade and the wallet state policy in the upgraded windows.
+func newCoordinationPolicy(chain Chain) CoordinationPolicy {
+	defaultPolicy := newDefaultCoordinationPolicy()
+
+	return &upgradeCoordinationPolicy{
+		legacy:   defaultPolicy,
+		upgraded: newWalletStateCoordinationPolicy(chain, defaultPolicy),
+	}
+}
+
+// upgradeCoordinationPolicy is a coordination policy switching from the
+// legacy policy to the upgraded one at the coordination upgrade block.
+// Operators not supporting the upgraded policy consider proposals of actions
+// it adds as the leader's mistake, so the switch happens at the same block
+// for all operators instead of being configured locally.
+type upgradeCoordinationPolicy struct {
+	legacy   CoordinationPolicy
+	upgraded CoordinationPolicy
+}
+
+func (ucp *upgradeCoordinationPolicy) ActionsChecklist(
+	request *CoordinationPolicyRequest,
+) ([]WalletActionType, error) {
+	window := newCoordinationWindow(
+		request.WindowIndex * coordinationFrequencyBlocks,
+	)
+
+	if window.isUpgraded() {
+		return ucp.upgraded.ActionsChecklist(request)
+	}
+
+	return ucp.legacy.ActionsChecklist(request)
+}
+
+// frequencyCoordinationPolicy is a coordination policy checking the wallet
+// actions with fixed frequencies, in coordination windows, and heartbeats
+// with a fixed probability drawn from the coordination seed.
+type frequencyCoordinationPolicy struct {
+	redemptionFrequency      uint64
+	depositSweepFrequency    uint64
+	movedFundsSweepFrequency uint64
+	movingFundsFrequency     uint64
+	heartbeatProbability     float64
+}
+
+// newDefaultCoordinationPolicy creates the default coordination policy.
+func newDefaultCoordinationPolicy() *frequencyCoordinationPolicy {
+	return &frequencyCoordinationPolicy{
+		// Redemption action is a priority action and should be checked on
+		// every coordination window.
+		redemptionFrequency:      1,
+		depositSweepFrequency:    coordinationLowPriorityActionsFrequency,
+		movedFundsSweepFrequency: coordinationLowPriorityActionsFrequency,
+		movingFundsFrequency:     coordinationLowPriorityActionsFrequency,
+		heartbeatProbability:     coordinationHeartbeatProbability,
+	}
+}
+
+func (fcp *frequencyCoordinationPolicy) ActionsChecklist(
+	request *CoordinationPolicyRequest,
+) ([]WalletActionType, error) {
+	windowIndex := request.WindowIndex
+
+	// Return nil checklist for incorrect coordination windows.
+	if windowIndex == 0 {
+		return nil, nil
+	}
+
+	var actions []WalletActionType
+
+	if windowIndex%fcp.redemptionFrequency == 0 {
+		actions = append(actions, ActionRedemption)
+	}
+
+	if windowIndex%fcp.depositSweepFrequency == 0 {
+		actions = append(actions, ActionDepositSweep)
+	}
+
+	if windowIndex%fcp.movedFundsSweepFrequency == 0 {
+		actions = append(actions, ActionMovedFundsSweep)
+	}
+
+	if windowIndex%fcp.movingFundsFrequency == 0 {
+		actions = append(actions, ActionMovingFunds)
+	}
+
+	// #nosec G404 (insecure random number source (rand))
+	// Drawing a decision about heartbeat does not require secure randomness.
+	// Use first 8 bytes of the seed to initialize the RNG.
+	rng := rand.New(rand.NewSource(
+		int64(binary.BigEndian.Uint64(request.Seed[:8])),
+	))
+	if rng.Float64() < fcp.heartbeatProbability {
+		actions = append(actions, ActionHeartbeat)
+	}
+
+	return actions, nil
+}
+
+// walletStateCoordinationPolicy is a coordination policy adjusting the
+// checklist of the base policy to the wallet state as of the safe blocks of
+// the coordination window and the previous window.
+type walletStateCoordinationPolicy struct {
+	chain Chain
+	base  CoordinationPolicy
+
+	depositsMutex sync.Mutex
+	// deposits holds the revealed deposits of the wallets, keyed by the
+	// wallet public key hash.
+	deposits map[[20]byte]*revealedDeposits
+}
+
+// revealedDeposits holds the deposits revealed to a wallet up to the given
+// block, excluding deposits already known to be swept.
+type revealedDeposits struct {
+	scannedToBlock uint64
+	events         []*DepositRevealedEvent
+}
+
+func newWalletStateCoordinationPolicy(
+	chain Chain,
+	base CoordinationPolicy,
+) *walletStateCoordinationPolicy {
+	return &walletStateCoordinationPolicy{
+		chain:    chain,
+		base:     base,
+		deposits: make(map[[20]byte]*revealedDeposits),
+	}
+}
+
+func (wscp *walletStateCoordinationPolicy) ActionsChecklist(
+	request *CoordinationPolicyRequest,
+) ([]WalletActionType, error) {
+	checklist, err := wscp.base.ActionsChecklist(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.WindowIndex == 0 {
+		return checklist, nil
+	}
+
+	walletChainData, err := wscp.chain.GetWalletAtBlock(
+		request.WalletPublicKeyHash,
+		request.SafeBlock,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get wallet chain data: [%v]", err)
+	}
+
+	included := make(map[WalletActionType]bool)
+	for _, action := range checklist {
+		included[action] = true
+	}
+
+	if walletChainData.State == StateMovingFunds {
+		included[ActionMovingFunds] = true
+	}
+
+	if walletChainData.PendingMovedFundsSweepRequestsCount == 0 {
+		included[ActionMovedFundsSweep] = false
+	}
+
+	depositSweepFirst, err := wscp.isDepositSweepDeferred(
+		request,
+		walletChainData,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot check deferred deposit sweep: [%v]",
+			err,
+		)
+	}
+
+	if depositSweepFirst {
+		included[ActionDepositSweep] = true
+	}
+
+	if included[ActionDepositSweep] {
+		hasPendingDeposits, err := wscp.hasPendingDeposits(
+			request.WalletPublicKeyHash,
+			request.SafeBlock,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot check pending deposits: [%v]",
+				err,
+			)
+		}
+
+		included[ActionDepositSweep] = hasPendingDeposits
+	}
+
+	// Keep the order of priority of the default policy unless the deposit
+	// sweep was deferred by the previous window.
+	priorities := []WalletActionType{
+		ActionRedemption,
+		ActionDepositSweep,
+		ActionMovedFundsSweep,
+		ActionMovingFunds,
+		ActionHeartbeat,
+	}
+	if depositSweepFirst {
+		priorities[0], priorities[1] = ActionDepositSweep, ActionRedemption
+	}
+
+	var actions []WalletActionType
+	for _, action := range priorities {
+		if included[action] {
+			actions = append(actions, action)
+		}
+	}
+
+	return actions, nil
+}
+
+// isDepositSweepDeferred returns true if the deposit sweep was checked in
+// the previous coordination window and the wallet has completed a transaction
+// since the safe block of that window, for example a redemption taking
+// priority over the deposit sweep. The deposit sweep is checked first in the
+// given window then, right after the wallet is free again, instead of waiting
+// for the next deposit sweep window. A single transaction cannot both sweep
+// deposits and pay redemptions as the Bridge does not accept proofs of such
+// transactions, and the deposit sweep cannot follow the redemption in the
+// same window as it spends the main UTXO the Bridge learns only from the
+// redemption proof.
+func (wscp *walletStateCoordinationPolicy) isDepositSweepDeferred(
+	request *CoordinationPolicyRequest,
+	walletChainData *WalletChainData,
+) (bool, error) {
+	if request.WindowIndex <= 1 ||
+		request.SafeBlock < coordinationFrequencyBlocks {
+		return false, nil
+	}
+
+	previousRequest := &CoordinationPolicyRequest{
+		WalletPublicKeyHash: request.WalletPublicKeyHash,
+		WindowIndex:         request.WindowIndex - 1,
+		SafeBlock:           request.SafeBlock - coordinationFrequencyBlocks,
+		Seed:                request.Seed,
+	}
+
+	previousChecklist, err := wscp.base.ActionsChecklist(previousRequest)
+	if err != nil {
+		return false, err
+	}
+
+	if !slices.Contains(previousChecklist, ActionDepositSweep) {
+		return false, nil
+	}
+
+	previousWalletChainData, err := wscp.chain.GetWalletAtBlock(
+		previousRequest.WalletPublicKeyHash,
+		previousRequest.SafeBlock,
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"cannot get wallet chain data of the previous window: [%v]",
+			err,
+		)
+	}
+
+	return previousWalletChainData.MainUtxoHash !=
+		walletChainData.MainUtxoHash, nil
+}
+
+// hasPendingDeposits returns true if any deposit revealed to the given wallet
+// within the look-back period ending at the given block was not swept as of
+// that block. Revealed deposits are scanned incrementally so the blocks of
+// subsequent calls are expected to not decrease.
+func (wscp *walletStateCoordinationPolicy) hasPendingDeposits(
+	walletPublicKeyHash [20]byte,
+	blockNumber uint64,
+) (bool, error) {
+	wscp.depositsMutex.Lock()
+	defer wscp.depositsMutex.Unlock()
+
+	deposits, ok := wscp.deposits[walletPublicKeyHash]
+	if !ok {
+		deposits = &revealedDeposits{}
+		wscp.deposits[walletPublicKeyHash] = deposits
+	}
+
+	// The result must not depend on the deposits scanned by the previous
+	// calls so the deposits revealed before the look-back period are
+	// ignored even if they were scanned already.
+	lookBackStartBlock := uint64(0)
+	if blockNumber > walletStateDepositsLookBackBlocks {
+		lookBackStartBlock = blockNumber - walletStateDepositsLookBackBlocks
+	}
+
+	if !ok || blockNumber > deposits.scannedToBlock {
+		startBlock := lookBackStartBlock
+		if ok && deposits.scannedToBlock+1 > startBlock {
+			startBlock = deposits.scannedToBlock + 1
+		}
+
+		events, err := wscp.chain.PastDepositRevealedEvents(
+			&DepositRevealedEventFilter{
+				StartBlock:          startBlock,
+				EndBlock:            &blockNumber,
+				WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+			},
+		)
+		if err != nil {
+			return false, fmt.Errorf(
+				"cannot get past deposit revealed events: [%v]",
+				err,
+			)
+		}
+
+		deposits.events = append(deposits.events, events...)
+		deposits.scannedToBlock = blockNumber
+	}
+
+	// Check the newest deposits first as the older ones are more likely to
+	// be swept already.
+	pending := false
+	for i := len(deposits.events) - 1; i >= 0; i-- {
+		event := deposits.events[i]
+
+		if event.BlockNumber > blockNumber {
+			continue
+		}
+
+		// The deposit was revealed before the look-back period of the
+		// given block so it is out of the period of all the subsequent
+		// blocks as well.
+		if event.BlockNumber < lookBackStartBlock {
+			deposits.events = append(deposits.events[:i], deposits.events[i+1:]...)
+			continue
+		}
+
+		request, found, err := wscp.chain.GetDepositRequestAtBlock(
+			event.FundingTxHash,
+			event.FundingOutputIndex,
+			blockNumber,
+		)
+		if err != nil {
+			return false, fmt.Errorf(
+				"cannot get deposit request: [%v]",
+				err,
+			)
+		}
+
+		if found && request.SweptAt.Unix() == 0 {
+			pending = true
+			break
+		}
+
+		// The deposit is swept as of the given block so it will be swept
+		// as of all the subsequent blocks as well.
+		deposits.events = append(deposits.events[:i], deposits.events[i+1:]...)
+	}
+
+	return pending, nil
+}
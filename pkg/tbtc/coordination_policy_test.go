@@ -0,0 +1,567 @@
+package tbtc
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+func TestDefaultCoordinationPolicy_ActionsChecklist(t *testing.T) {
+	tests := map[string]struct {
+		coordinationBlock uint64
+		expectedChecklist []WalletActionType
+	}{
+		// Incorrect coordination window.
+		"block 0": {
+			coordinationBlock: 0,
+			expectedChecklist: nil,
+		},
+		"block 900": {
+			coordinationBlock: 900,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		// Incorrect coordination window.
+		"block 901": {
+			coordinationBlock: 901,
+			expectedChecklist: nil,
+		},
+		"block 1800": {
+			coordinationBlock: 1800,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"block 2700": {
+			coordinationBlock: 2700,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		// Heartbeat randomly selected for the 4th coordination window.
+		"block 3600": {
+			coordinationBlock: 3600,
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+				ActionDepositSweep,
+				ActionMovedFundsSweep,
+				ActionMovingFunds,
+				ActionHeartbeat,
+			},
+		},
+		"block 4500": {
+			coordinationBlock: 4500,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"block 5400": {
+			coordinationBlock: 5400,
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+			},
+		},
+		"block 6300": {
+			coordinationBlock: 6300,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"block 7200": {
+			coordinationBlock: 7200,
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+				ActionDepositSweep,
+				ActionMovedFundsSweep,
+				ActionMovingFunds,
+			},
+		},
+		"block 8100": {
+			coordinationBlock: 8100,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"block 9000": {
+			coordinationBlock: 9000,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"block 9900": {
+			coordinationBlock: 9900,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"block 10800": {
+			coordinationBlock: 10800,
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+				ActionDepositSweep,
+				ActionMovedFundsSweep,
+				ActionMovingFunds,
+			},
+		},
+		"block 11700": {
+			coordinationBlock: 11700,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"block 12600": {
+			coordinationBlock: 12600,
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+			},
+		},
+		"block 13500": {
+			coordinationBlock: 13500,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"block 14400": {
+			coordinationBlock: 14400,
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+				ActionDepositSweep,
+				ActionMovedFundsSweep,
+				ActionMovingFunds,
+			},
+		},
+	}
+
+	policy := newDefaultCoordinationPolicy()
+
+	for testName, test := range tests {
+		t.Run(
+			testName, func(t *testing.T) {
+				window := newCoordinationWindow(test.coordinationBlock)
+
+				// Build an arbitrary seed based on the coordination block number.
+				seed := sha256.Sum256(
+					big.NewInt(int64(window.coordinationBlock) + 2).Bytes(),
+				)
+
+				checklist, err := policy.ActionsChecklist(
+					&CoordinationPolicyRequest{
+						WindowIndex: window.index(),
+						Seed:        seed,
+					},
+				)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := deep.Equal(
+					checklist,
+					test.expectedChecklist,
+				); diff != nil {
+					t.Errorf(
+						"compare failed: %v\nactual: %s\nexpected: %s",
+						diff,
+						checklist,
+						test.expectedChecklist,
+					)
+				}
+			},
+		)
+	}
+}
+
+func TestWalletStateCoordinationPolicy_ActionsChecklist(t *testing.T) {
+	localChain := Connect()
+
+	walletPublicKeyHash := [20]byte{1}
+	fundingTxHash := bitcoin.Hash{2}
+	fundingOutputIndex := uint32(1)
+
+	policy := newWalletStateCoordinationPolicy(
+		localChain,
+		newDefaultCoordinationPolicy(),
+	)
+
+	setDepositRevealedEvents := func(startBlock uint64, endBlock uint64) {
+		err := localChain.setPastDepositRevealedEvents(
+			&DepositRevealedEventFilter{
+				StartBlock:          startBlock,
+				EndBlock:            &endBlock,
+				WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+			},
+			[]*DepositRevealedEvent{
+				{
+					FundingTxHash:       fundingTxHash,
+					FundingOutputIndex:  fundingOutputIndex,
+					WalletPublicKeyHash: walletPublicKeyHash,
+					BlockNumber:         startBlock + 1,
+				},
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setSweptAt := func(sweptAt time.Time) {
+		localChain.setDepositRequest(
+			fundingTxHash,
+			fundingOutputIndex,
+			&DepositChainRequest{SweptAt: sweptAt},
+		)
+	}
+
+	assertChecklist := func(
+		coordinationBlock uint64,
+		expectedChecklist []WalletActionType,
+	) {
+		window := newCoordinationWindow(coordinationBlock)
+
+		checklist, err := policy.ActionsChecklist(
+			&CoordinationPolicyRequest{
+				WalletPublicKeyHash: walletPublicKeyHash,
+				WindowIndex:         window.index(),
+				SafeBlock: window.coordinationBlock -
+					coordinationSafeBlockShift,
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := deep.Equal(checklist, expectedChecklist); diff != nil {
+			t.Errorf(
+				"unexpected checklist for block [%v]: %v\n"+
+					"actual: %s\nexpected: %s",
+				coordinationBlock,
+				diff,
+				checklist,
+				expectedChecklist,
+			)
+		}
+	}
+
+	// Live wallet with a pending deposit and no moved funds sweep requests.
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		State: StateLive,
+	})
+	setDepositRevealedEvents(0, 3568)
+	setSweptAt(time.Unix(0, 0))
+
+	assertChecklist(3600, []WalletActionType{
+		ActionRedemption,
+		ActionDepositSweep,
+		ActionMovingFunds,
+	})
+
+	// Wallet moving funds should check moving funds in every window.
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		State: StateMovingFunds,
+	})
+
+	assertChecklist(4500, []WalletActionType{
+		ActionRedemption,
+		ActionMovingFunds,
+	})
+
+	// All deposits swept and a pending moved funds sweep request.
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		State:                               StateLive,
+		PendingMovedFundsSweepRequestsCount: 1,
+	})
+	setDepositRevealedEvents(3569, 7168)
+	setSweptAt(time.Unix(1000, 0))
+
+	assertChecklist(7200, []WalletActionType{
+		ActionRedemption,
+		ActionMovedFundsSweep,
+		ActionMovingFunds,
+	})
+
+	if len(policy.deposits[walletPublicKeyHash].events) != 0 {
+		t.Errorf("expected swept deposits to be removed from the cache")
+	}
+}
+
+func TestWalletStateCoordinationPolicy_DepositsLookBack(t *testing.T) {
+	localChain := Connect()
+
+	walletPublicKeyHash := [20]byte{1}
+	fundingTxHash := bitcoin.Hash{2}
+	fundingOutputIndex := uint32(1)
+
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		State: StateLive,
+	})
+	localChain.setDepositRequest(
+		fundingTxHash,
+		fundingOutputIndex,
+		&DepositChainRequest{SweptAt: time.Unix(0, 0)},
+	)
+
+	setDepositRevealedEvents := func(
+		startBlock uint64,
+		endBlock uint64,
+		events []*DepositRevealedEvent,
+	) {
+		err := localChain.setPastDepositRevealedEvents(
+			&DepositRevealedEventFilter{
+				StartBlock:          startBlock,
+				EndBlock:            &endBlock,
+				WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+			},
+			events,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The deposit is pending but revealed before the look-back period of
+	// the later window.
+	setDepositRevealedEvents(0, 3568, []*DepositRevealedEvent{
+		{
+			FundingTxHash:       fundingTxHash,
+			FundingOutputIndex:  fundingOutputIndex,
+			WalletPublicKeyHash: walletPublicKeyHash,
+			BlockNumber:         1,
+		},
+	})
+
+	laterCoordinationBlock := walletStateDepositsLookBackBlocks + 3600
+	laterSafeBlock := laterCoordinationBlock - coordinationSafeBlockShift
+	lookBackStartBlock := laterSafeBlock - walletStateDepositsLookBackBlocks
+
+	setDepositRevealedEvents(3569, laterSafeBlock, nil)
+	setDepositRevealedEvents(lookBackStartBlock, laterSafeBlock, nil)
+
+	checklist := func(
+		policy CoordinationPolicy,
+		coordinationBlock uint64,
+	) []WalletActionType {
+		window := newCoordinationWindow(coordinationBlock)
+
+		checklist, err := policy.ActionsChecklist(
+			&CoordinationPolicyRequest{
+				WalletPublicKeyHash: walletPublicKeyHash,
+				WindowIndex:         window.index(),
+				SafeBlock: window.coordinationBlock -
+					coordinationSafeBlockShift,
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return checklist
+	}
+
+	expectedChecklist := []WalletActionType{
+		ActionRedemption,
+		ActionMovingFunds,
+	}
+
+	// The policy that scanned the deposit in an earlier window must compute
+	// the same checklist as the policy that did not.
+	cachingPolicy := newWalletStateCoordinationPolicy(
+		localChain,
+		newDefaultCoordinationPolicy(),
+	)
+
+	if diff := deep.Equal(
+		checklist(cachingPolicy, 3600),
+		[]WalletActionType{
+			ActionRedemption,
+			ActionDepositSweep,
+			ActionMovingFunds,
+		},
+	); diff != nil {
+		t.Errorf("unexpected checklist of the earlier window: %v", diff)
+	}
+
+	if diff := deep.Equal(
+		checklist(cachingPolicy, laterCoordinationBlock),
+		expectedChecklist,
+	); diff != nil {
+		t.Errorf("unexpected checklist of the caching policy: %v", diff)
+	}
+
+	freshPolicy := newWalletStateCoordinationPolicy(
+		localChain,
+		newDefaultCoordinationPolicy(),
+	)
+
+	if diff := deep.Equal(
+		checklist(freshPolicy, laterCoordinationBlock),
+		expectedChecklist,
+	); diff != nil {
+		t.Errorf("unexpected checklist of the fresh policy: %v", diff)
+	}
+}
+
+func TestCoordinationPolicy_Upgrade(t *testing.T) {
+	defer func(upgradeBlock uint64) {
+		coordinationUpgradeBlock = upgradeBlock
+	}(coordinationUpgradeBlock)
+	coordinationUpgradeBlock = 7200
+
+	localChain := Connect()
+
+	walletPublicKeyHash := [20]byte{1}
+
+	// The wallet is moving funds so the wallet state policy checks moving
+	// funds in every window.
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		State: StateMovingFunds,
+	})
+
+	policy := newCoordinationPolicy(localChain)
+
+	var tests = map[string]struct {
+		coordinationBlock uint64
+		expectedChecklist []WalletActionType
+	}{
+		"window before the upgrade": {
+			coordinationBlock: 6300,
+			expectedChecklist: []WalletActionType{ActionRedemption},
+		},
+		"window after the upgrade": {
+			coordinationBlock: 8100,
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+				ActionMovingFunds,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			window := newCoordinationWindow(test.coordinationBlock)
+
+			checklist, err := policy.ActionsChecklist(
+				&CoordinationPolicyRequest{
+					WalletPublicKeyHash: walletPublicKeyHash,
+					WindowIndex:         window.index(),
+					SafeBlock: window.coordinationBlock -
+						coordinationSafeBlockShift,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(checklist, test.expectedChecklist); diff != nil {
+				t.Errorf("unexpected checklist: %v", diff)
+			}
+		})
+	}
+}
+
+func TestWalletStateCoordinationPolicy_DeferredDepositSweep(t *testing.T) {
+	walletPublicKeyHash := [20]byte{1}
+	fundingTxHash := bitcoin.Hash{2}
+	fundingOutputIndex := uint32(1)
+
+	var tests = map[string]struct {
+		coordinationBlock uint64
+		mainUtxoChanged   bool
+		sweptAt           time.Time
+		expectedChecklist []WalletActionType
+	}{
+		"wallet transaction completed after deposit sweep window": {
+			coordinationBlock: 4500,
+			mainUtxoChanged:   true,
+			sweptAt:           time.Unix(0, 0),
+			expectedChecklist: []WalletActionType{
+				ActionDepositSweep,
+				ActionRedemption,
+			},
+		},
+		"no wallet transaction completed after deposit sweep window": {
+			coordinationBlock: 4500,
+			mainUtxoChanged:   false,
+			sweptAt:           time.Unix(0, 0),
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+			},
+		},
+		"deposits swept after deposit sweep window": {
+			coordinationBlock: 4500,
+			mainUtxoChanged:   true,
+			sweptAt:           time.Unix(1000, 0),
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+			},
+		},
+		"wallet transaction completed after other window": {
+			coordinationBlock: 5400,
+			mainUtxoChanged:   true,
+			sweptAt:           time.Unix(0, 0),
+			expectedChecklist: []WalletActionType{
+				ActionRedemption,
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			localChain := Connect()
+
+			window := newCoordinationWindow(test.coordinationBlock)
+			safeBlock := window.coordinationBlock - coordinationSafeBlockShift
+			previousSafeBlock := safeBlock - coordinationFrequencyBlocks
+
+			localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+				State:        StateLive,
+				MainUtxoHash: [32]byte{1},
+			})
+
+			if test.mainUtxoChanged {
+				localChain.setWalletAtBlock(
+					walletPublicKeyHash,
+					previousSafeBlock,
+					&WalletChainData{
+						State:        StateLive,
+						MainUtxoHash: [32]byte{2},
+					},
+				)
+			}
+
+			err := localChain.setPastDepositRevealedEvents(
+				&DepositRevealedEventFilter{
+					StartBlock:          0,
+					EndBlock:            &safeBlock,
+					WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+				},
+				[]*DepositRevealedEvent{
+					{
+						FundingTxHash:       fundingTxHash,
+						FundingOutputIndex:  fundingOutputIndex,
+						WalletPublicKeyHash: walletPublicKeyHash,
+						BlockNumber:         1,
+					},
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			localChain.setDepositRequest(
+				fundingTxHash,
+				fundingOutputIndex,
+				&DepositChainRequest{SweptAt: test.sweptAt},
+			)
+
+			policy := newWalletStateCoordinationPolicy(
+				localChain,
+				newDefaultCoordinationPolicy(),
+			)
+
+			checklist, err := policy.ActionsChecklist(
+				&CoordinationPolicyRequest{
+					WalletPublicKeyHash: walletPublicKeyHash,
+					WindowIndex:         window.index(),
+					SafeBlock:           safeBlock,
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal(checklist, test.expectedChecklist); diff != nil {
+				t.Errorf(
+					"unexpected checklist: %v\nactual: %s\nexpected: %s",
+					diff,
+					checklist,
+					test.expectedChecklist,
+				)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package tbtc
 
 import (
 	"context"
-	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -10,7 +9,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/go-test/deep"
 	"github.com/keep-network/keep-core/pkg/bitcoin"
 	"github.com/keep-network/keep-core/pkg/chain"
 	"github.com/keep-network/keep-core/pkg/chain/local_v1"
@@ -47,6 +45,50 @@ func TestCoordinationWindow_EndBlock(t *testing.T) {
 	)
 }
 
+func TestCoordinationWindow_LeaderSlots(t *testing.T) {
+	defer func(upgradeBlock uint64) {
+		coordinationUpgradeBlock = upgradeBlock
+	}(coordinationUpgradeBlock)
+	coordinationUpgradeBlock = 900
+
+	window := newCoordinationWindow(900)
+
+	expectedSlots := []struct {
+		startBlock uint64
+		endBlock   uint64
+	}{
+		{startBlock: 900, endBlock: 940},
+		{startBlock: 940, endBlock: 960},
+		{startBlock: 960, endBlock: 980},
+	}
+
+	for slot, expectedSlot := range expectedSlots {
+		testutils.AssertIntsEqual(
+			t,
+			fmt.Sprintf("slot [%v] start block", slot),
+			int(expectedSlot.startBlock),
+			int(window.leaderSlotStartBlock(slot)),
+		)
+		testutils.AssertIntsEqual(
+			t,
+			fmt.Sprintf("slot [%v] end block", slot),
+			int(expectedSlot.endBlock),
+			int(window.leaderSlotEndBlock(slot)),
+		)
+	}
+
+	// Windows preceding the upgrade have no backup leaders so the leader's
+	// slot is the entire active phase.
+	coordinationUpgradeBlock = 1800
+
+	testutils.AssertIntsEqual(
+		t,
+		"leader's slot end block before the upgrade",
+		980,
+		int(window.leaderSlotEndBlock(0)),
+	)
+}
+
 func TestCoordinationWindow_IsAfter(t *testing.T) {
 	window := newCoordinationWindow(1800)
 
@@ -339,6 +381,7 @@ func TestCoordinationExecutor_Coordinate(t *testing.T) {
 			coordinatedWallet.membersByOperator(operator.address),
 			operator.address,
 			proposalGenerator,
+			newDefaultCoordinationPolicy(),
 			operator.channel,
 			membershipValidator,
 			protocolLatch,
@@ -392,10 +435,24 @@ loop:
 
 	testutils.AssertIntsEqual(t, "reports count", 3, len(reports))
 
+	expectedSeed, err := generateExecutor(operator1).getSeed(coordinationBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedActionsChecklist, err := generateExecutor(operator1).
+		getActionsChecklist(window, expectedSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	expectedResult := &coordinationResult{
-		wallet: coordinatedWallet,
-		window: window,
-		leader: operator2.address,
+		wallet:           coordinatedWallet,
+		window:           window,
+		seed:             expectedSeed,
+		leader:           operator2.address,
+		proposer:         operator2.address,
+		actionsChecklist: expectedActionsChecklist,
 		proposal: &RedemptionProposal{
 			RedeemersOutputScripts: []bitcoin.Script{
 				parseScript("00148db50eb52063ea9d98b3eac91489a90f738986f6"),
@@ -442,6 +499,267 @@ loop:
 	)
 }
 
+func TestCoordinationExecutor_Coordinate_WithOfflineLeader(t *testing.T) {
+	// Uncompressed public key corresponding to the 20-byte public key hash:
+	// aa768412ceed10bd423c025542ca90071f9fb62d.
+	publicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coordinationBlock := uint64(900)
+	window := newCoordinationWindow(coordinationBlock)
+
+	var tests = map[string]struct {
+		upgradeBlock uint64
+		// expectBackupProposal determines whether the backup leader is
+		// expected to propose in place of the offline leader.
+		expectBackupProposal bool
+	}{
+		// Clients not supporting backup leaders consider the backup
+		// leader's proposal as leader impersonation so the backup leader
+		// must not propose before the upgrade.
+		"window before the upgrade": {
+			upgradeBlock:         1800,
+			expectBackupProposal: false,
+		},
+		"window after the upgrade": {
+			upgradeBlock:         900,
+			expectBackupProposal: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			defer func(upgradeBlock uint64) {
+				coordinationUpgradeBlock = upgradeBlock
+			}(coordinationUpgradeBlock)
+			coordinationUpgradeBlock = test.upgradeBlock
+
+			// Each block of the window takes 20 milliseconds.
+			windowStart := time.Now()
+			waitForBlockFn := func(ctx context.Context, block uint64) error {
+				blockTime := windowStart.Add(
+					time.Duration(block-coordinationBlock) * 20 * time.Millisecond,
+				)
+
+				select {
+				case <-time.After(time.Until(blockTime)):
+				case <-ctx.Done():
+				}
+
+				return nil
+			}
+
+			type operatorFixture struct {
+				chain   Chain
+				address chain.Address
+				channel net.BroadcastChannel
+			}
+
+			generateOperator := func(privateKey int64) *operatorFixture {
+				privateKeyBigInt := big.NewInt(privateKey)
+				x, y := local_v1.DefaultCurve.ScalarBaseMult(
+					privateKeyBigInt.Bytes(),
+				)
+
+				localChain := ConnectWithKey(
+					&operator.PrivateKey{
+						PublicKey: operator.PublicKey{
+							Curve: operator.Secp256k1,
+							X:     x,
+							Y:     y,
+						},
+						D: privateKeyBigInt,
+					},
+				)
+
+				localChain.setBlockHashByNumber(
+					coordinationBlock-32,
+					"1422996cbcbc38fc924a46f4df5f9064279d3ab43396e58386dac9b87440d64f",
+				)
+
+				operatorAddress, err := localChain.operatorAddress()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				_, operatorPublicKey, err := localChain.OperatorKeyPair()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				broadcastChannel, err := netlocal.ConnectWithKey(operatorPublicKey).
+					BroadcastChannelFor(fmt.Sprintf("test-offline-%s", testName))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+					return &coordinationMessage{}
+				})
+
+				return &operatorFixture{
+					chain:   localChain,
+					address: operatorAddress,
+					channel: broadcastChannel,
+				}
+			}
+
+			operators := []*operatorFixture{
+				generateOperator(1),
+				generateOperator(2),
+				generateOperator(3),
+			}
+
+			coordinatedWallet := wallet{
+				publicKey: unmarshalPublicKey(publicKeyHex),
+				signingGroupOperators: []chain.Address{
+					operators[0].address,
+					operators[1].address,
+					operators[2].address,
+					operators[0].address,
+					operators[1].address,
+					operators[2].address,
+				},
+			}
+
+			proposalGenerator := newMockCoordinationProposalGenerator(
+				func(
+					walletPublicKeyHash [20]byte,
+					actionsChecklist []WalletActionType,
+					_ uint,
+				) (CoordinationProposal, error) {
+					return &NoopProposal{}, nil
+				},
+			)
+
+			membershipValidator := group.NewMembershipValidator(
+				&testutils.MockLogger{},
+				coordinatedWallet.signingGroupOperators,
+				Connect().Signing(),
+			)
+
+			generateExecutor := func(
+				operator *operatorFixture,
+			) *coordinationExecutor {
+				return newCoordinationExecutor(
+					operator.chain,
+					coordinatedWallet,
+					coordinatedWallet.membersByOperator(operator.address),
+					operator.address,
+					proposalGenerator,
+					newDefaultCoordinationPolicy(),
+					operator.channel,
+					membershipValidator,
+					generator.NewProtocolLatch(),
+					waitForBlockFn,
+				)
+			}
+
+			seed, err := generateExecutor(operators[0]).getSeed(coordinationBlock)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Compute the leaders as of the upgrade to find the operator that
+			// is the backup leader once backup leaders are supported.
+			upgradedLeaders := coordinationLeaders(
+				newCoordinationWindow(coordinationUpgradeBlock),
+				coordinatedWallet.signingGroupOperators,
+				seed,
+			)
+			leader := upgradedLeaders[0]
+			backupLeader := upgradedLeaders[1]
+
+			type report struct {
+				operator chain.Address
+				result   *coordinationResult
+				err      error
+			}
+
+			// The leader is offline so only the other operators coordinate.
+			reportChan := make(chan *report, len(operators)-1)
+			for _, currentOperator := range operators {
+				if currentOperator.address == leader {
+					continue
+				}
+
+				go func(operator *operatorFixture) {
+					result, err := generateExecutor(operator).coordinate(window)
+
+					reportChan <- &report{
+						operator: operator.address,
+						result:   result,
+						err:      err,
+					}
+				}(currentOperator)
+			}
+
+			for i := 0; i < len(operators)-1; i++ {
+				r := <-reportChan
+
+				if r.err != nil {
+					t.Fatalf("operator [%s] failed: [%v]", r.operator, r.err)
+				}
+
+				// Operators who are not backup leaders end up with
+				// a no-op proposal.
+				expectedProposer := chain.Address("")
+				if test.expectBackupProposal {
+					expectedProposer = backupLeader
+				}
+
+				testutils.AssertStringsEqual(
+					t,
+					fmt.Sprintf("proposer seen by operator [%s]", r.operator),
+					expectedProposer.String(),
+					r.result.proposer.String(),
+				)
+				testutils.AssertStringsEqual(
+					t,
+					fmt.Sprintf("action seen by operator [%s]", r.operator),
+					ActionNoop.String(),
+					r.result.proposal.ActionType().String(),
+				)
+
+				expectedFaults := []*coordinationFault{
+					{
+						culprit:   leader,
+						faultType: FaultLeaderIdleness,
+					},
+				}
+				if !reflect.DeepEqual(expectedFaults, r.result.faults) {
+					t.Errorf(
+						"unexpected faults seen by operator [%s]:\n"+
+							"expected: %v\n"+
+							"actual:   %v",
+						r.operator,
+						expectedFaults,
+						r.result.faults,
+					)
+				}
+
+				// The idleness of the leader should be persisted by the
+				// faults accounting of the operator.
+				persistenceHandle := &mockPersistenceHandle{}
+				newCoordinationFaultsAccounting(persistenceHandle).record(r.result)
+
+				restored := newCoordinationFaultsAccounting(persistenceHandle)
+				testutils.AssertUintsEqual(
+					t,
+					fmt.Sprintf("faults persisted by operator [%s]", r.operator),
+					1,
+					uint64(restored.totalFaults()),
+				)
+			}
+		})
+	}
+}
+
 func TestCoordinationExecutor_GetSeed(t *testing.T) {
 	coordinationBlock := uint64(900)
 
@@ -521,150 +839,54 @@ func TestCoordinationExecutor_GetLeader(t *testing.T) {
 		coordinatedWallet: coordinatedWallet,
 	}
 
-	leader := executor.getLeader(seed)
-
-	testutils.AssertStringsEqual(
-		t,
-		"coordination leader",
-		"D2662604f8b4540336fBd3c1F48d7e9cdFbD079c",
-		leader.String(),
-	)
-}
+	defer func(upgradeBlock uint64) {
+		coordinationUpgradeBlock = upgradeBlock
+	}(coordinationUpgradeBlock)
+	coordinationUpgradeBlock = 1800
 
-func TestCoordinationExecutor_GetActionsChecklist(t *testing.T) {
-	tests := map[string]struct {
-		coordinationBlock uint64
-		expectedChecklist []WalletActionType
+	var tests = map[string]struct {
+		window          *coordinationWindow
+		expectedLeaders []chain.Address
 	}{
-		// Incorrect coordination window.
-		"block 0": {
-			coordinationBlock: 0,
-			expectedChecklist: nil,
-		},
-		"block 900": {
-			coordinationBlock: 900,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		// Incorrect coordination window.
-		"block 901": {
-			coordinationBlock: 901,
-			expectedChecklist: nil,
-		},
-		"block 1800": {
-			coordinationBlock: 1800,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		"block 2700": {
-			coordinationBlock: 2700,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		// Heartbeat randomly selected for the 4th coordination window.
-		"block 3600": {
-			coordinationBlock: 3600,
-			expectedChecklist: []WalletActionType{
-				ActionRedemption,
-				ActionDepositSweep,
-				ActionMovedFundsSweep,
-				ActionMovingFunds,
-				ActionHeartbeat,
-			},
-		},
-		"block 4500": {
-			coordinationBlock: 4500,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		"block 5400": {
-			coordinationBlock: 5400,
-			expectedChecklist: []WalletActionType{
-				ActionRedemption,
-			},
-		},
-		"block 6300": {
-			coordinationBlock: 6300,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		"block 7200": {
-			coordinationBlock: 7200,
-			expectedChecklist: []WalletActionType{
-				ActionRedemption,
-				ActionDepositSweep,
-				ActionMovedFundsSweep,
-				ActionMovingFunds,
+		"window before the upgrade": {
+			window: newCoordinationWindow(900),
+			expectedLeaders: []chain.Address{
+				"D2662604f8b4540336fBd3c1F48d7e9cdFbD079c",
 			},
 		},
-		"block 8100": {
-			coordinationBlock: 8100,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		"block 9000": {
-			coordinationBlock: 9000,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		"block 9900": {
-			coordinationBlock: 9900,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		"block 10800": {
-			coordinationBlock: 10800,
-			expectedChecklist: []WalletActionType{
-				ActionRedemption,
-				ActionDepositSweep,
-				ActionMovedFundsSweep,
-				ActionMovingFunds,
-			},
-		},
-		"block 11700": {
-			coordinationBlock: 11700,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		"block 12600": {
-			coordinationBlock: 12600,
-			expectedChecklist: []WalletActionType{
-				ActionRedemption,
-			},
-		},
-		"block 13500": {
-			coordinationBlock: 13500,
-			expectedChecklist: []WalletActionType{ActionRedemption},
-		},
-		"block 14400": {
-			coordinationBlock: 14400,
-			expectedChecklist: []WalletActionType{
-				ActionRedemption,
-				ActionDepositSweep,
-				ActionMovedFundsSweep,
-				ActionMovingFunds,
+		"window after the upgrade": {
+			window: newCoordinationWindow(1800),
+			expectedLeaders: []chain.Address{
+				"D2662604f8b4540336fBd3c1F48d7e9cdFbD079c",
+				"405ad1f632b49A0617fbdc1fD427aF54BA9Bb3dd",
+				"705C76445651530fe0D25eeE287b6164cE2c7216",
 			},
 		},
 	}
 
-	executor := &coordinationExecutor{}
-
 	for testName, test := range tests {
-		t.Run(
-			testName, func(t *testing.T) {
-				window := newCoordinationWindow(test.coordinationBlock)
+		t.Run(testName, func(t *testing.T) {
+			leader := executor.getLeader(test.window, seed)
 
-				// Build an arbitrary seed based on the coordination block number.
-				seed := sha256.Sum256(
-					big.NewInt(int64(window.coordinationBlock) + 2).Bytes(),
-				)
+			testutils.AssertStringsEqual(
+				t,
+				"coordination leader",
+				"D2662604f8b4540336fBd3c1F48d7e9cdFbD079c",
+				leader.String(),
+			)
 
-				checklist := executor.getActionsChecklist(window.index(), seed)
+			leaders := executor.getLeaders(test.window, seed)
 
-				if diff := deep.Equal(
-					checklist,
-					test.expectedChecklist,
-				); diff != nil {
-					t.Errorf(
-						"compare failed: %v\nactual: %s\nexpected: %s",
-						diff,
-						checklist,
-						test.expectedChecklist,
-					)
-				}
-			},
-		)
+			if !reflect.DeepEqual(test.expectedLeaders, leaders) {
+				t.Errorf(
+					"unexpected leaders:\n"+
+						"expected: %v\n"+
+						"actual:   %v",
+					test.expectedLeaders,
+					leaders,
+				)
+			}
+		})
 	}
 }
 
@@ -1114,16 +1336,23 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine(t *testing.T) {
 		}
 	}()
 
-	proposal, faults, err := executor.executeFollowerRoutine(
+	proposal, proposer, faults, err := executor.executeFollowerRoutine(
 		ctx,
-		leader.address,
-		900,
+		newCoordinationWindow(900),
+		[]chain.Address{leader.address},
 		[]WalletActionType{ActionRedemption, ActionNoop},
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	testutils.AssertStringsEqual(
+		t,
+		"proposer",
+		leader.address.String(),
+		proposer.String(),
+	)
+
 	expectedProposal := &RedemptionProposal{
 		RedeemersOutputScripts: []bitcoin.Script{
 			parseScript("00148db50eb52063ea9d98b3eac91489a90f738986f6"),
@@ -1228,10 +1457,10 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine_WithIdleLeader(t *testing.T
 	ctx, cancelCtx := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancelCtx()
 
-	_, faults, err := executor.executeFollowerRoutine(
+	_, _, faults, err := executor.executeFollowerRoutine(
 		ctx,
-		leader,
-		900,
+		newCoordinationWindow(900),
+		[]chain.Address{leader},
 		[]WalletActionType{ActionRedemption, ActionNoop},
 	)
 
@@ -1263,6 +1492,366 @@ func TestCoordinationExecutor_ExecuteFollowerRoutine_WithIdleLeader(t *testing.T
 	}
 }
 
+func TestCoordinationExecutor_ExecuteFollowerRoutine_WithBackupLeader(t *testing.T) {
+	// Uncompressed public key corresponding to the 20-byte public key hash:
+	// aa768412ceed10bd423c025542ca90071f9fb62d.
+	publicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generateOperator := func() struct {
+		address chain.Address
+		channel net.BroadcastChannel
+	} {
+		localChain := Connect()
+
+		operatorAddress, err := localChain.operatorAddress()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, operatorPublicKey, err := localChain.OperatorKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel, err := netlocal.ConnectWithKey(operatorPublicKey).
+			BroadcastChannelFor("test-backup")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &coordinationMessage{}
+		})
+
+		return struct {
+			address chain.Address
+			channel net.BroadcastChannel
+		}{
+			address: operatorAddress,
+			channel: broadcastChannel,
+		}
+	}
+
+	leader := generateOperator()
+	backupLeader := generateOperator()
+	follower := generateOperator()
+
+	coordinatedWallet := wallet{
+		publicKey: unmarshalPublicKey(publicKeyHex),
+		signingGroupOperators: []chain.Address{
+			follower.address,
+			backupLeader.address,
+			leader.address,
+			leader.address,
+			backupLeader.address,
+			follower.address,
+		},
+	}
+
+	backupLeaderID := coordinatedWallet.membersByOperator(backupLeader.address)[0]
+
+	localChain := Connect()
+
+	window := newCoordinationWindow(900)
+
+	executor := &coordinationExecutor{
+		// Set only relevant fields.
+		chain:             localChain,
+		coordinatedWallet: coordinatedWallet,
+		membersIndexes:    coordinatedWallet.membersByOperator(follower.address),
+		operatorAddress:   follower.address,
+		broadcastChannel:  follower.channel,
+		membershipValidator: group.NewMembershipValidator(
+			&testutils.MockLogger{},
+			coordinatedWallet.signingGroupOperators,
+			localChain.Signing(),
+		),
+		// The backup leader's slot starts 2 seconds after the routine starts.
+		waitForBlockFn: func(ctx context.Context, block uint64) error {
+			if block != window.leaderSlotStartBlock(1) {
+				return fmt.Errorf("unexpected block [%v]", block)
+			}
+
+			select {
+			case <-time.After(2 * time.Second):
+			case <-ctx.Done():
+			}
+
+			return nil
+		},
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	sendProposal := func(heartbeatMessage byte) {
+		err := backupLeader.channel.Send(ctx, &coordinationMessage{
+			senderID:            backupLeaderID,
+			coordinationBlock:   900,
+			walletPublicKeyHash: executor.walletPublicKeyHash(),
+			proposal: &HeartbeatProposal{
+				Message: [16]byte{heartbeatMessage},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	go func() {
+		// Send a proposal before the backup leader's slot starts. It should
+		// be ignored.
+		time.Sleep(1 * time.Second)
+		sendProposal(0x01)
+
+		// Send a proposal in the backup leader's slot.
+		time.Sleep(2 * time.Second)
+		sendProposal(0x02)
+	}()
+
+	proposal, proposer, faults, err := executor.executeFollowerRoutine(
+		ctx,
+		window,
+		[]chain.Address{leader.address, backupLeader.address},
+		[]WalletActionType{ActionHeartbeat, ActionNoop},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedProposal := &HeartbeatProposal{
+		Message: [16]byte{0x02},
+	}
+	if !reflect.DeepEqual(expectedProposal, proposal) {
+		t.Errorf(
+			"unexpected proposal: \n"+
+				"expected: %v\n"+
+				"actual:   %v",
+			expectedProposal,
+			proposal,
+		)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"proposer",
+		backupLeader.address.String(),
+		proposer.String(),
+	)
+
+	expectedFaults := []*coordinationFault{
+		{
+			culprit:   leader.address,
+			faultType: FaultLeaderIdleness,
+		},
+	}
+	if !reflect.DeepEqual(expectedFaults, faults) {
+		t.Errorf(
+			"unexpected faults: \n"+
+				"expected: %v\n"+
+				"actual:   %v",
+			expectedFaults,
+			faults,
+		)
+	}
+}
+
+func TestCoordinationExecutor_ExecuteFollowerRoutine_WithLateLeader(t *testing.T) {
+	// Uncompressed public key corresponding to the 20-byte public key hash:
+	// aa768412ceed10bd423c025542ca90071f9fb62d.
+	publicKeyHex, err := hex.DecodeString(
+		"0471e30bca60f6548d7b42582a478ea37ada63b402af7b3ddd57f0c95bb6843175" +
+			"aa0d2053a91a050a6797d85c38f2909cb7027f2344a01986aa2f9f8ca7a0c289",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generateOperator := func() struct {
+		address chain.Address
+		channel net.BroadcastChannel
+	} {
+		localChain := Connect()
+
+		operatorAddress, err := localChain.operatorAddress()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, operatorPublicKey, err := localChain.OperatorKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel, err := netlocal.ConnectWithKey(operatorPublicKey).
+			BroadcastChannelFor("test-late-leader")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		broadcastChannel.SetUnmarshaler(func() net.TaggedUnmarshaler {
+			return &coordinationMessage{}
+		})
+
+		return struct {
+			address chain.Address
+			channel net.BroadcastChannel
+		}{
+			address: operatorAddress,
+			channel: broadcastChannel,
+		}
+	}
+
+	leader := generateOperator()
+	backupLeader := generateOperator()
+	follower := generateOperator()
+
+	coordinatedWallet := wallet{
+		publicKey: unmarshalPublicKey(publicKeyHex),
+		signingGroupOperators: []chain.Address{
+			follower.address,
+			backupLeader.address,
+			leader.address,
+			leader.address,
+			backupLeader.address,
+			follower.address,
+		},
+	}
+
+	leaderID := coordinatedWallet.membersByOperator(leader.address)[0]
+	backupLeaderID := coordinatedWallet.membersByOperator(backupLeader.address)[0]
+
+	localChain := Connect()
+
+	window := newCoordinationWindow(900)
+
+	executor := &coordinationExecutor{
+		// Set only relevant fields.
+		chain:             localChain,
+		coordinatedWallet: coordinatedWallet,
+		membersIndexes:    coordinatedWallet.membersByOperator(follower.address),
+		operatorAddress:   follower.address,
+		broadcastChannel:  follower.channel,
+		membershipValidator: group.NewMembershipValidator(
+			&testutils.MockLogger{},
+			coordinatedWallet.signingGroupOperators,
+			localChain.Signing(),
+		),
+		// The backup leader's slot starts 2 seconds after the routine starts.
+		waitForBlockFn: func(ctx context.Context, block uint64) error {
+			if block != window.leaderSlotStartBlock(1) {
+				return fmt.Errorf("unexpected block [%v]", block)
+			}
+
+			select {
+			case <-time.After(2 * time.Second):
+			case <-ctx.Done():
+			}
+
+			return nil
+		},
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	sendProposal := func(
+		channel net.BroadcastChannel,
+		senderID group.MemberIndex,
+		proposal CoordinationProposal,
+	) {
+		err := channel.Send(ctx, &coordinationMessage{
+			senderID:            senderID,
+			coordinationBlock:   900,
+			walletPublicKeyHash: executor.walletPublicKeyHash(),
+			proposal:            proposal,
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	}
+
+	go func() {
+		// The leader proposes an action that is not allowed.
+		time.Sleep(500 * time.Millisecond)
+		sendProposal(
+			leader.channel,
+			leaderID,
+			&RedemptionProposal{RedemptionTxFee: big.NewInt(10000)},
+		)
+
+		// The leader proposes a valid action once the backup leader's slot
+		// started. It should be ignored.
+		time.Sleep(2 * time.Second)
+		sendProposal(
+			leader.channel,
+			leaderID,
+			&HeartbeatProposal{Message: [16]byte{0x01}},
+		)
+
+		// The backup leader proposes in their slot.
+		time.Sleep(1 * time.Second)
+		sendProposal(
+			backupLeader.channel,
+			backupLeaderID,
+			&HeartbeatProposal{Message: [16]byte{0x02}},
+		)
+	}()
+
+	proposal, proposer, faults, err := executor.executeFollowerRoutine(
+		ctx,
+		window,
+		[]chain.Address{leader.address, backupLeader.address},
+		[]WalletActionType{ActionHeartbeat, ActionNoop},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedProposal := &HeartbeatProposal{
+		Message: [16]byte{0x02},
+	}
+	if !reflect.DeepEqual(expectedProposal, proposal) {
+		t.Errorf(
+			"unexpected proposal: \n"+
+				"expected: %v\n"+
+				"actual:   %v",
+			expectedProposal,
+			proposal,
+		)
+	}
+
+	testutils.AssertStringsEqual(
+		t,
+		"proposer",
+		backupLeader.address.String(),
+		proposer.String(),
+	)
+
+	// The leader is charged with the mistake only, not with the idleness.
+	expectedFaults := []*coordinationFault{
+		{
+			culprit:   leader.address,
+			faultType: FaultLeaderMistake,
+		},
+	}
+	if !reflect.DeepEqual(expectedFaults, faults) {
+		t.Errorf(
+			"unexpected faults: \n"+
+				"expected: %v\n"+
+				"actual:   %v",
+			expectedFaults,
+			faults,
+		)
+	}
+}
+
 type mockCoordinationProposalGenerator struct {
 	calls    uint
 	delegate func(
@@ -0,0 +1,168 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+)
+
+// WalletNetworkDiagnostics describes the network connectivity of the node
+// with other signing group members of the given wallet.
+type WalletNetworkDiagnostics struct {
+	WalletPublicKeyHash string                        `json:"wallet_public_key_hash"`
+	BroadcastChannels   []BroadcastChannelDiagnostics `json:"broadcast_channels"`
+}
+
+// BroadcastChannelDiagnostics describes the state of a single wallet
+// broadcast channel, as seen by the node.
+type BroadcastChannelDiagnostics struct {
+	Name string `json:"name"`
+	// Peers are the remote peers subscribed to the channel.
+	Peers []BroadcastChannelPeer `json:"peers"`
+	// UnreachableOperators are the chain addresses of signing group
+	// operators, other than the node's operator, that are not subscribed
+	// to the channel from the node's point of view. Messages published
+	// by the node will not reach them directly.
+	UnreachableOperators []string `json:"unreachable_operators"`
+}
+
+// BroadcastChannelPeer describes a remote peer subscribed to a broadcast
+// channel.
+type BroadcastChannelPeer struct {
+	NetworkID    string `json:"network_id"`
+	ChainAddress string `json:"chain_address"`
+}
+
+// walletBroadcastChannelNames returns names of all broadcast channels used
+// by the wallet with the given public key bytes.
+func walletBroadcastChannelNames(walletPublicKeyBytes []byte) []string {
+	return []string{
+		signingChannelName(walletPublicKeyBytes),
+		coordinationChannelName(walletPublicKeyBytes),
+		inactivityChannelName(walletPublicKeyBytes),
+	}
+}
+
+// networkDiagnostics returns the network diagnostics of all wallets
+// controlled by the node.
+func (n *node) networkDiagnostics() clientinfo.ApplicationInfo {
+	operatorAddress, err := n.operatorAddress()
+	if err != nil {
+		logger.Errorf("cannot get node's operator address: [%v]", err)
+		return nil
+	}
+
+	wallets := make([]*WalletNetworkDiagnostics, 0)
+
+	for _, walletPublicKey := range n.walletRegistry.getWalletsPublicKeys() {
+		signers := n.walletRegistry.getSigners(walletPublicKey)
+		if len(signers) == 0 {
+			continue
+		}
+
+		walletPublicKeyBytes, err := marshalPublicKey(walletPublicKey)
+		if err != nil {
+			logger.Errorf("cannot marshal wallet public key: [%v]", err)
+			continue
+		}
+
+		walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+		walletDiagnostics := &WalletNetworkDiagnostics{
+			WalletPublicKeyHash: hex.EncodeToString(walletPublicKeyHash[:]),
+			BroadcastChannels:   make([]BroadcastChannelDiagnostics, 0),
+		}
+
+		for _, channelName := range walletBroadcastChannelNames(
+			walletPublicKeyBytes,
+		) {
+			peers := n.broadcastChannelPeers(channelName)
+
+			peersAddresses := make([]chain.Address, len(peers))
+			for i, peer := range peers {
+				peersAddresses[i] = chain.Address(peer.ChainAddress)
+			}
+
+			walletDiagnostics.BroadcastChannels = append(
+				walletDiagnostics.BroadcastChannels,
+				BroadcastChannelDiagnostics{
+					Name:  channelName,
+					Peers: peers,
+					UnreachableOperators: unreachableOperators(
+						signers[0].wallet.signingGroupOperators,
+						peersAddresses,
+						operatorAddress,
+					),
+				},
+			)
+		}
+
+		wallets = append(wallets, walletDiagnostics)
+	}
+
+	return clientinfo.ApplicationInfo{
+		"wallets": wallets,
+	}
+}
+
+// broadcastChannelPeers returns peers subscribed to the broadcast channel
+// with the given name along with their chain addresses. Peers whose chain
+// address cannot be determined are skipped.
+func (n *node) broadcastChannelPeers(channelName string) []BroadcastChannelPeer {
+	connectionManager := n.netProvider.ConnectionManager()
+
+	peers := make([]BroadcastChannelPeer, 0)
+	for _, peerNetworkID := range n.netProvider.BroadcastChannelPeers(
+		channelName,
+	) {
+		peerPublicKey, err := connectionManager.GetPeerPublicKey(peerNetworkID)
+		if err != nil {
+			logger.Errorf("error on getting peer public key: [%v]", err)
+			continue
+		}
+
+		peerChainAddress, err := n.chain.Signing().PublicKeyToAddress(
+			peerPublicKey,
+		)
+		if err != nil {
+			logger.Errorf("error on getting peer chain address: [%v]", err)
+			continue
+		}
+
+		peers = append(peers, BroadcastChannelPeer{
+			NetworkID:    peerNetworkID,
+			ChainAddress: peerChainAddress.String(),
+		})
+	}
+
+	return peers
+}
+
+// unreachableOperators returns a sorted and deduplicated list of signing
+// group operators that are neither among the given reachable addresses nor
+// equal to the given own operator address.
+func unreachableOperators(
+	signingGroupOperators chain.Addresses,
+	reachable []chain.Address,
+	operatorAddress chain.Address,
+) []string {
+	reachableSet := make(map[chain.Address]bool, len(reachable)+1)
+	for _, address := range reachable {
+		reachableSet[address] = true
+	}
+	reachableSet[operatorAddress] = true
+
+	unreachable := make([]string, 0)
+	for address := range signingGroupOperators.Set() {
+		if !reachableSet[address] {
+			unreachable = append(unreachable, address.String())
+		}
+	}
+
+	sort.Strings(unreachable)
+
+	return unreachable
+}
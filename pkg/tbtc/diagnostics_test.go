@@ -0,0 +1,96 @@
+package tbtc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestWalletBroadcastChannelNames(t *testing.T) {
+	names := walletBroadcastChannelNames([]byte{0x04, 0xab, 0xcd})
+
+	expectedNames := []string{
+		"tbtc-04abcd",
+		"tbtc-04abcd-coordination",
+		"tbtc-04abcd-inactivity",
+	}
+
+	if !reflect.DeepEqual(expectedNames, names) {
+		t.Errorf(
+			"unexpected channel names\nexpected: %v\nactual:   %v\n",
+			expectedNames,
+			names,
+		)
+	}
+}
+
+func TestUnreachableOperators(t *testing.T) {
+	signingGroupOperators := chain.Addresses{
+		"address-1",
+		"address-2",
+		"address-3",
+		"address-3",
+		"address-5",
+		"address-4",
+	}
+
+	var tests = map[string]struct {
+		reachable           []chain.Address
+		operatorAddress     chain.Address
+		expectedUnreachable []string
+	}{
+		"all operators reachable": {
+			reachable: []chain.Address{
+				"address-2",
+				"address-3",
+				"address-4",
+				"address-5",
+			},
+			operatorAddress:     "address-1",
+			expectedUnreachable: []string{},
+		},
+		"no operators reachable": {
+			reachable:       []chain.Address{},
+			operatorAddress: "address-1",
+			expectedUnreachable: []string{
+				"address-2",
+				"address-3",
+				"address-4",
+				"address-5",
+			},
+		},
+		"some operators reachable": {
+			reachable: []chain.Address{
+				"address-2",
+				"address-5",
+				"address-9",
+			},
+			operatorAddress: "address-3",
+			expectedUnreachable: []string{
+				"address-1",
+				"address-4",
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			unreachable := unreachableOperators(
+				signingGroupOperators,
+				test.reachable,
+				test.operatorAddress,
+			)
+
+			if !reflect.DeepEqual(test.expectedUnreachable, unreachable) {
+				t.Errorf(
+					"unexpected unreachable operators\n"+
+						"expected: %v\n"+
+						"actual:   %v\n",
+					test.expectedUnreachable,
+					unreachable,
+				)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"golang.org/x/exp/maps"
 	"math/big"
 	"sort"
+	"sync"
 
 	"go.uber.org/zap"
 
@@ -63,7 +64,15 @@ type dkgExecutor struct {
 	// waitForBlockFn is a function used to wait for the given block.
 	waitForBlockFn waitForBlockFn
 
+	// shutdownCoordinator tracks the DKG executions of the members
+	// controlled by the node so they can complete before the node is
+	// stopped.
+	shutdownCoordinator *shutdownCoordinator
+
 	tecdsaExecutor *dkg.Executor
+
+	configMutex sync.Mutex
+	config      Config
 }
 
 // newDkgExecutor creates a new instance of dkgExecutor struct. There should
@@ -79,6 +88,7 @@ func newDkgExecutor(
 	config Config,
 	workPersistence persistence.BasicHandle,
 	scheduler *generator.Scheduler,
+	shutdownCoordinator *shutdownCoordinator,
 	waitForBlockFn waitForBlockFn,
 ) *dkgExecutor {
 	tecdsaExecutor := dkg.NewExecutor(
@@ -93,18 +103,65 @@ func newDkgExecutor(
 	)
 
 	return &dkgExecutor{
-		groupParameters: groupParameters,
-		operatorIDFn:    operatorIDFn,
-		operatorAddress: operatorAddress,
-		chain:           chain,
-		netProvider:     netProvider,
-		walletRegistry:  walletRegistry,
-		protocolLatch:   protocolLatch,
-		tecdsaExecutor:  tecdsaExecutor,
-		waitForBlockFn:  waitForBlockFn,
+		groupParameters:     groupParameters,
+		operatorIDFn:        operatorIDFn,
+		operatorAddress:     operatorAddress,
+		chain:               chain,
+		netProvider:         netProvider,
+		walletRegistry:      walletRegistry,
+		protocolLatch:       protocolLatch,
+		tecdsaExecutor:      tecdsaExecutor,
+		waitForBlockFn:      waitForBlockFn,
+		config:              config,
+		shutdownCoordinator: shutdownCoordinator,
 	}
 }
 
+// updateConfig applies changes of the pre-parameters generation and key
+// generation settings. Returns names of Config fields whose changes were
+// applied. The pre-parameters pool size cannot be changed at runtime.
+func (de *dkgExecutor) updateConfig(config Config) []string {
+	de.configMutex.Lock()
+	defer de.configMutex.Unlock()
+
+	applied := make([]string, 0)
+
+	if config.PreParamsGenerationTimeout != de.config.PreParamsGenerationTimeout ||
+		config.PreParamsGenerationDelay != de.config.PreParamsGenerationDelay ||
+		config.PreParamsGenerationConcurrency != de.config.PreParamsGenerationConcurrency {
+		de.tecdsaExecutor.UpdatePreParamsGeneration(
+			config.PreParamsGenerationTimeout,
+			config.PreParamsGenerationDelay,
+			config.PreParamsGenerationConcurrency,
+		)
+
+		if config.PreParamsGenerationTimeout != de.config.PreParamsGenerationTimeout {
+			applied = append(applied, "PreParamsGenerationTimeout")
+		}
+		if config.PreParamsGenerationDelay != de.config.PreParamsGenerationDelay {
+			applied = append(applied, "PreParamsGenerationDelay")
+		}
+		if config.PreParamsGenerationConcurrency != de.config.PreParamsGenerationConcurrency {
+			applied = append(applied, "PreParamsGenerationConcurrency")
+		}
+
+		de.config.PreParamsGenerationTimeout = config.PreParamsGenerationTimeout
+		de.config.PreParamsGenerationDelay = config.PreParamsGenerationDelay
+		de.config.PreParamsGenerationConcurrency = config.PreParamsGenerationConcurrency
+	}
+
+	if config.KeyGenerationConcurrency != de.config.KeyGenerationConcurrency {
+		de.tecdsaExecutor.UpdateKeyGenerationConcurrency(
+			config.KeyGenerationConcurrency,
+		)
+		applied = append(applied, "KeyGenerationConcurrency")
+
+		de.config.KeyGenerationConcurrency = config.KeyGenerationConcurrency
+	}
+
+	return applied
+}
+
 // preParamsCount returns the current count of the ECDSA DKG pre-parameters.
 func (de *dkgExecutor) preParamsCount() int {
 	return de.tecdsaExecutor.PreParamsCount()
@@ -282,7 +339,16 @@ func (de *dkgExecutor) generateSigningGroup(
 		// Capture the member index for the goroutine.
 		memberIndex := index
 
+		// The DKG execution was accepted when the DKG started event was
+		// handled, so the members are tracked even if the node is shutting
+		// down in the meantime.
+		memberDone := de.shutdownCoordinator.track(
+			fmt.Sprintf("DKG of member [%v] for seed [0x%x]", memberIndex, seed),
+		)
+
 		go func() {
+			defer memberDone()
+
 			de.protocolLatch.Lock()
 			defer de.protocolLatch.Unlock()
 
@@ -103,6 +103,8 @@ func TestDkgExecutor_RegisterSigner(t *testing.T) {
 				groupParameters: groupParameters,
 				chain:           localChain,
 				walletRegistry:  walletRegistry,
+
+				shutdownCoordinator: newShutdownCoordinator(),
 			}
 
 			group := group.NewGroup(groupParameters.DishonestThreshold(), groupParameters.GroupSize)
@@ -332,6 +334,8 @@ func TestDkgExecutor_ExecuteDkgValidation(t *testing.T) {
 				operatorAddress: operatorAddress,
 				chain:           localChain,
 				waitForBlockFn:  testWaitForBlockFn(localChain),
+
+				shutdownCoordinator: newShutdownCoordinator(),
 			}
 
 			eventChan := make(chan interface{}, 1)
@@ -41,6 +41,30 @@ const (
 	heartbeatConsecutiveFailureThreshold = 3
 )
 
+// HeartbeatOutcome is the outcome of a heartbeat executed by the node.
+type HeartbeatOutcome string
+
+const (
+	// HeartbeatSucceeded is the outcome of a heartbeat signed with enough
+	// active members.
+	HeartbeatSucceeded HeartbeatOutcome = "succeeded"
+	// HeartbeatInactivityFailure is the outcome of a heartbeat signed with
+	// too few active members.
+	HeartbeatInactivityFailure HeartbeatOutcome = "inactivity_failure"
+	// HeartbeatErrored is the outcome of a heartbeat that errored out.
+	HeartbeatErrored HeartbeatOutcome = "errored"
+)
+
+// HeartbeatResult describes the result of a heartbeat executed by the node.
+type HeartbeatResult struct {
+	StartBlock    uint64           `json:"start_block"`
+	Outcome       HeartbeatOutcome `json:"outcome"`
+	ActiveMembers int              `json:"active_members"`
+	// InactivityClaimed is true if the node took part in the inactivity
+	// claim following the heartbeat.
+	InactivityClaimed bool `json:"inactivity_claimed"`
+}
+
 type HeartbeatProposal struct {
 	Message [16]byte
 }
@@ -148,6 +172,12 @@ func (ha *heartbeatAction) execute() error {
 
 	walletKey := hex.EncodeToString(walletPublicKeyBytes)
 
+	result := &HeartbeatResult{
+		StartBlock: ha.startBlock,
+		Outcome:    HeartbeatErrored,
+	}
+	defer ha.failureCounter.recordResult(walletKey, result)
+
 	err = ha.chain.ValidateHeartbeatProposal(walletPublicKeyHash, ha.proposal)
 	if err != nil {
 		return fmt.Errorf("heartbeat proposal is invalid: [%v]", err)
@@ -185,6 +215,8 @@ func (ha *heartbeatAction) execute() error {
 	// If the number of active members during signing was enough, we can
 	// consider the heartbeat procedure as successful.
 	activeMembersCount := len(activityReport.activeMembers)
+	result.ActiveMembers = activeMembersCount
+
 	if activeMembersCount >= heartbeatSigningMinimumActiveMembers {
 		ha.logger.Infof(
 			"heartbeat generated signature [%s] for message [0x%x]",
@@ -194,6 +226,7 @@ func (ha *heartbeatAction) execute() error {
 
 		// Reset the counter of consecutive heartbeat inactivity failures.
 		ha.failureCounter.reset(walletKey)
+		result.Outcome = HeartbeatSucceeded
 
 		return nil
 	}
@@ -210,6 +243,7 @@ func (ha *heartbeatAction) execute() error {
 
 	// Increment the heartbeat inactivity failure counter.
 	ha.failureCounter.increment(walletKey)
+	result.Outcome = HeartbeatInactivityFailure
 
 	// If the number of consecutive heartbeat inactivity failures does not
 	// exceed the threshold, do not issue an inactivity claim yet.
@@ -256,6 +290,8 @@ func (ha *heartbeatAction) execute() error {
 		)
 	}
 
+	result.InactivityClaimed = true
+
 	return nil
 }
 
@@ -295,16 +331,19 @@ func (ha *heartbeatAction) isOperatorUnstaking() (bool, error) {
 }
 
 // heartbeatFailureCounter holds counters keeping track of consecutive
-// heartbeat failures. Each wallet has a separate counter. The key used in
-// the map is the uncompressed public key (with 04 prefix) of the wallet.
+// heartbeat failures along with the results of the last heartbeats. Each
+// wallet has a separate counter. The key used in the maps is the uncompressed
+// public key (with 04 prefix) of the wallet.
 type heartbeatFailureCounter struct {
-	mutex    sync.Mutex
-	counters map[string]uint
+	mutex       sync.Mutex
+	counters    map[string]uint
+	lastResults map[string]*HeartbeatResult
 }
 
 func newHeartbeatFailureCounter() *heartbeatFailureCounter {
 	return &heartbeatFailureCounter{
-		counters: make(map[string]uint),
+		counters:    make(map[string]uint),
+		lastResults: make(map[string]*HeartbeatResult),
 	}
 }
 
@@ -329,3 +368,24 @@ func (hfc *heartbeatFailureCounter) get(walletPublicKey string) uint {
 
 	return hfc.counters[walletPublicKey]
 }
+
+func (hfc *heartbeatFailureCounter) recordResult(
+	walletPublicKey string,
+	result *HeartbeatResult,
+) {
+	hfc.mutex.Lock()
+	defer hfc.mutex.Unlock()
+
+	hfc.lastResults[walletPublicKey] = result
+}
+
+// lastResult returns the result of the last heartbeat of the given wallet
+// or nil if the node executed no heartbeat of the wallet.
+func (hfc *heartbeatFailureCounter) lastResult(
+	walletPublicKey string,
+) *HeartbeatResult {
+	hfc.mutex.Lock()
+	defer hfc.mutex.Unlock()
+
+	return hfc.lastResults[walletPublicKey]
+}
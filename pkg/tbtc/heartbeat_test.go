@@ -84,6 +84,23 @@ func TestHeartbeatAction_HappyPath(t *testing.T) {
 		0,
 		uint64(heartbeatFailureCounter.get(walletPublicKeyStr)),
 	)
+
+	lastResult := heartbeatFailureCounter.lastResult(walletPublicKeyStr)
+	if lastResult == nil {
+		t.Fatal("expected the heartbeat result to be recorded")
+	}
+	testutils.AssertStringsEqual(
+		t,
+		"heartbeat outcome",
+		string(HeartbeatSucceeded),
+		string(lastResult.Outcome),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"inactivity claimed",
+		false,
+		lastResult.InactivityClaimed,
+	)
 	testutils.AssertBigIntsEqual(
 		t,
 		"message to sign",
@@ -236,6 +253,23 @@ func TestHeartbeatAction_Failure_SigningError(t *testing.T) {
 		0,
 		uint64(heartbeatFailureCounter.get(walletPublicKeyStr)),
 	)
+
+	lastResult := heartbeatFailureCounter.lastResult(walletPublicKeyStr)
+	if lastResult == nil {
+		t.Fatal("expected the heartbeat result to be recorded")
+	}
+	testutils.AssertStringsEqual(
+		t,
+		"heartbeat outcome",
+		string(HeartbeatErrored),
+		string(lastResult.Outcome),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"inactivity claimed",
+		false,
+		lastResult.InactivityClaimed,
+	)
 	testutils.AssertBigIntsEqual(
 		t,
 		"inactivity claim executor session ID",
@@ -307,6 +341,23 @@ func TestHeartbeatAction_Failure_TooFewActiveOperators(t *testing.T) {
 		1,
 		uint64(heartbeatFailureCounter.get(walletPublicKeyStr)),
 	)
+
+	lastResult := heartbeatFailureCounter.lastResult(walletPublicKeyStr)
+	if lastResult == nil {
+		t.Fatal("expected the heartbeat result to be recorded")
+	}
+	testutils.AssertStringsEqual(
+		t,
+		"heartbeat outcome",
+		string(HeartbeatInactivityFailure),
+		string(lastResult.Outcome),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"inactivity claimed",
+		false,
+		lastResult.InactivityClaimed,
+	)
 	testutils.AssertBigIntsEqual(
 		t,
 		"inactivity claim executor session ID",
@@ -387,6 +438,23 @@ func TestHeartbeatAction_Failure_CounterExceeded(t *testing.T) {
 		3,
 		uint64(heartbeatFailureCounter.get(walletPublicKeyStr)),
 	)
+
+	lastResult := heartbeatFailureCounter.lastResult(walletPublicKeyStr)
+	if lastResult == nil {
+		t.Fatal("expected the heartbeat result to be recorded")
+	}
+	testutils.AssertStringsEqual(
+		t,
+		"heartbeat outcome",
+		string(HeartbeatInactivityFailure),
+		string(lastResult.Outcome),
+	)
+	testutils.AssertBoolsEqual(
+		t,
+		"inactivity claimed",
+		true,
+		lastResult.InactivityClaimed,
+	)
 	testutils.AssertBigIntsEqual(
 		t,
 		"inactivity claim executor session ID",
@@ -0,0 +1,674 @@
+package tbtc
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+// KeyStoreChain is the subset of the TBTC chain interface required to verify
+// signers stored in the key store against the chain.
+type KeyStoreChain interface {
+	// CalculateWalletID calculates the ECDSA wallet ID based on the provided
+	// wallet public key.
+	CalculateWalletID(walletPublicKey *ecdsa.PublicKey) ([32]byte, error)
+
+	// IsWalletRegistered checks whether the given wallet is registered in the
+	// ECDSA wallet registry.
+	IsWalletRegistered(EcdsaWalletID [32]byte) (bool, error)
+
+	// GetWallet gets the on-chain data for the given wallet. Returns an error
+	// if the wallet was not found.
+	GetWallet(walletPublicKeyHash [20]byte) (*WalletChainData, error)
+
+	// IsWalletSigningGroup checks whether the given operators, ordered by
+	// signing group member index, are the signing group members of the
+	// given wallet registered in the ECDSA wallet registry.
+	IsWalletSigningGroup(
+		EcdsaWalletID [32]byte,
+		signingGroupOperators chain.Addresses,
+	) (bool, error)
+}
+
+// StoredSigner holds information about a signer stored in the key store.
+type StoredSigner struct {
+	// WalletStorageKey identifies the wallet's directory in the key store.
+	WalletStorageKey string
+	// WalletPublicKey is the wallet public key.
+	WalletPublicKey *ecdsa.PublicKey
+	// WalletPublicKeyHash is the 20-byte wallet public key hash.
+	WalletPublicKeyHash [20]byte
+	// WalletID is the ECDSA wallet ID. Set only if the chain was used for
+	// the verification.
+	WalletID [32]byte
+	// MemberIndex is the signing group member index of the signer.
+	MemberIndex group.MemberIndex
+	// GroupSize is the size of the wallet signing group.
+	GroupSize int
+	// HonestThreshold is the minimum number of signing group members
+	// needed to produce a signature, as determined from the private key
+	// share. Zero if the private key share is invalid.
+	HonestThreshold int
+	// WalletState is the on-chain state of the wallet. Set only if the chain
+	// was used for the verification.
+	WalletState WalletState
+}
+
+// VerifyStoredSigner unmarshals the given decrypted content of a signer file
+// from the key store and verifies the private key share it holds corresponds
+// to the wallet public key recomputed from the group data of the share. If
+// the chain is given, it is additionally verified that the wallet with the
+// given public key exists on-chain. The verification does not depend on the
+// wallet state so archived wallets are verified the same way as current ones. The returned stored signer is set
+// if the content could be unmarshaled, even if the verification failed.
+func VerifyStoredSigner(
+	content []byte,
+	keyStoreChain KeyStoreChain,
+) (*StoredSigner, error) {
+	signer := &signer{}
+	if err := signer.Unmarshal(content); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal signer: [%w]", err)
+	}
+
+	walletPublicKey := signer.wallet.publicKey
+
+	storedSigner := &StoredSigner{
+		WalletStorageKey:    getWalletStorageKey(walletPublicKey),
+		WalletPublicKey:     walletPublicKey,
+		WalletPublicKeyHash: bitcoin.PublicKeyHash(walletPublicKey),
+		MemberIndex:         signer.signingGroupMemberIndex,
+		GroupSize:           len(signer.wallet.signingGroupOperators),
+	}
+
+	if err := verifySignerShare(signer); err != nil {
+		return storedSigner, err
+	}
+
+	honestThreshold, err := signer.privateKeyShare.HonestThreshold()
+	if err != nil {
+		return storedSigner, fmt.Errorf(
+			"cannot determine honest threshold: [%v]",
+			err,
+		)
+	}
+	storedSigner.HonestThreshold = honestThreshold
+
+	if keyStoreChain == nil {
+		return storedSigner, nil
+	}
+
+	walletID, err := keyStoreChain.CalculateWalletID(walletPublicKey)
+	if err != nil {
+		return storedSigner, fmt.Errorf(
+			"cannot calculate wallet ID: [%v]",
+			err,
+		)
+	}
+	storedSigner.WalletID = walletID
+
+	walletChainData, err := keyStoreChain.GetWallet(storedSigner.WalletPublicKeyHash)
+	if err != nil {
+		return storedSigner, fmt.Errorf(
+			"cannot get on-chain wallet data: [%v]",
+			err,
+		)
+	}
+	storedSigner.WalletState = walletChainData.State
+
+	// The Bridge identifies wallets by public key hashes while the ECDSA
+	// wallet ID is derived from the full public key so, a match confirms
+	// the on-chain wallet has the same public key.
+	if walletChainData.EcdsaWalletID != walletID {
+		return storedSigner, fmt.Errorf(
+			"on-chain ECDSA wallet ID [0x%x] does not match the wallet "+
+				"public key; expected [0x%x]",
+			walletChainData.EcdsaWalletID,
+			walletID,
+		)
+	}
+
+	return storedSigner, nil
+}
+
+// verifySignerShare recomputes the wallet public key from the group data
+// of the signer's private key share and checks it is the signer's wallet
+// public key.
+func verifySignerShare(signer *signer) error {
+	recoveredPublicKey, err := signer.privateKeyShare.RecoverPublicKey()
+	if err != nil {
+		return fmt.Errorf("invalid private key share: [%v]", err)
+	}
+
+	if !recoveredPublicKey.Equal(signer.wallet.publicKey) ||
+		!recoveredPublicKey.Equal(signer.privateKeyShare.PublicKey()) {
+		return fmt.Errorf(
+			"private key share does not correspond to the wallet public key",
+		)
+	}
+
+	return nil
+}
+
+// KeyStoreSignerFile is a decrypted signer file read from the key store.
+type KeyStoreSignerFile struct {
+	// Path identifies the file in the key store.
+	Path string
+	// Archived tells whether the file belongs to an archived wallet.
+	Archived bool
+	// Content is the decrypted file content.
+	Content []byte
+}
+
+// StoredWallet describes a wallet the key store holds signers of.
+type StoredWallet struct {
+	// WalletPublicKey is the wallet public key.
+	WalletPublicKey *ecdsa.PublicKey
+	// WalletPublicKeyHash is the 20-byte wallet public key hash.
+	WalletPublicKeyHash [20]byte
+	// WalletID is the ECDSA wallet ID. Set only if the chain was given.
+	WalletID [32]byte
+	// MemberIndexes are the signing group member indexes of the signers
+	// held in the key store, in ascending order.
+	MemberIndexes []group.MemberIndex
+	// GroupSize is the size of the wallet signing group.
+	GroupSize int
+	// HonestThreshold is the minimum number of signing group members
+	// needed to produce a signature, as determined from the signers'
+	// private key shares. Zero if none of the signers is valid.
+	HonestThreshold int
+	// Archived tells whether the wallet's signers are archived.
+	Archived bool
+	// ChainData is the on-chain wallet data. Set only if the chain was given
+	// and the wallet was found on-chain.
+	ChainData *WalletChainData
+	// Issues lists the problems found with the wallet's signers or the
+	// on-chain wallet data.
+	Issues []string
+}
+
+// ReadStoredWallets groups the signers held in the given key store files by
+// wallets. Current and archived signers of the same wallet are reported as
+// separate wallets. If the chain is given, the on-chain data of each wallet
+// is read as well. Returns an error if any of the files does not hold
+// a signer. The returned wallets are in the order of the files they were
+// first found in.
+func ReadStoredWallets(
+	files []*KeyStoreSignerFile,
+	keyStoreChain KeyStoreChain,
+) ([]*StoredWallet, error) {
+	type walletKey struct {
+		storageKey string
+		archived   bool
+	}
+
+	wallets := make([]*StoredWallet, 0)
+	walletsByKey := make(map[walletKey]*StoredWallet)
+
+	for _, file := range files {
+		storedSigner, err := VerifyStoredSigner(file.Content, nil)
+		if storedSigner == nil {
+			return nil, fmt.Errorf(
+				"cannot read signer from [%s]: [%w]",
+				file.Path,
+				err,
+			)
+		}
+
+		key := walletKey{storedSigner.WalletStorageKey, file.Archived}
+
+		wallet, ok := walletsByKey[key]
+		if !ok {
+			wallet = &StoredWallet{
+				WalletPublicKey:     storedSigner.WalletPublicKey,
+				WalletPublicKeyHash: storedSigner.WalletPublicKeyHash,
+				GroupSize:           storedSigner.GroupSize,
+				Archived:            file.Archived,
+			}
+
+			walletsByKey[key] = wallet
+			wallets = append(wallets, wallet)
+		}
+
+		wallet.MemberIndexes = append(
+			wallet.MemberIndexes,
+			storedSigner.MemberIndex,
+		)
+
+		if wallet.HonestThreshold == 0 {
+			wallet.HonestThreshold = storedSigner.HonestThreshold
+		}
+
+		if err != nil {
+			wallet.Issues = append(wallet.Issues, fmt.Sprintf(
+				"signer [%s] is invalid: %v",
+				file.Path,
+				err,
+			))
+		}
+	}
+
+	for _, wallet := range wallets {
+		sort.Slice(wallet.MemberIndexes, func(i, j int) bool {
+			return wallet.MemberIndexes[i] < wallet.MemberIndexes[j]
+		})
+
+		if keyStoreChain == nil {
+			continue
+		}
+
+		walletID, err := keyStoreChain.CalculateWalletID(wallet.WalletPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot calculate wallet ID: [%w]", err)
+		}
+		wallet.WalletID = walletID
+
+		chainData, err := keyStoreChain.GetWallet(wallet.WalletPublicKeyHash)
+		if err != nil {
+			wallet.Issues = append(wallet.Issues, fmt.Sprintf(
+				"cannot get on-chain wallet data: %v",
+				err,
+			))
+			continue
+		}
+		wallet.ChainData = chainData
+	}
+
+	return wallets, nil
+}
+
+// KeyStoreHealthSeverity is the severity of a key store health issue.
+type KeyStoreHealthSeverity int
+
+const (
+	// KeyStoreHealthy means no issues were found.
+	KeyStoreHealthy KeyStoreHealthSeverity = iota
+	// KeyStoreWarning means the key store is usable but requires attention,
+	// e.g. holds a wallet that should have been archived.
+	KeyStoreWarning
+	// KeyStoreError means some signers are unusable or missing and the node
+	// would not be able to take part in signing for the affected wallets.
+	KeyStoreError
+)
+
+func (khs KeyStoreHealthSeverity) String() string {
+	switch khs {
+	case KeyStoreHealthy:
+		return "healthy"
+	case KeyStoreWarning:
+		return "warning"
+	case KeyStoreError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler so that the severity is
+// reported by its name.
+func (khs KeyStoreHealthSeverity) MarshalText() ([]byte, error) {
+	return []byte(khs.String()), nil
+}
+
+const (
+	// DefaultKeyStoreCheckFailOn is the default minimum severity of key store
+	// health issues that prevents the client from starting.
+	DefaultKeyStoreCheckFailOn = "error"
+
+	// keyStoreCheckNeverFail is the KeyStoreCheckFailOn value for which key
+	// store health issues are only reported.
+	keyStoreCheckNeverFail = "never"
+)
+
+// parseKeyStoreCheckFailOn parses the KeyStoreCheckFailOn config value. The
+// returned boolean is false if health issues should never prevent the client
+// from starting.
+func parseKeyStoreCheckFailOn(value string) (KeyStoreHealthSeverity, bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return parseKeyStoreCheckFailOn(DefaultKeyStoreCheckFailOn)
+	case KeyStoreWarning.String():
+		return KeyStoreWarning, true, nil
+	case KeyStoreError.String():
+		return KeyStoreError, true, nil
+	case keyStoreCheckNeverFail:
+		return KeyStoreHealthy, false, nil
+	default:
+		return KeyStoreHealthy, false, fmt.Errorf(
+			"invalid key store check severity [%v]; expected one of: "+
+				"warning, error, never",
+			value,
+		)
+	}
+}
+
+// KeyStoreIssue describes a single key store health issue.
+type KeyStoreIssue struct {
+	// WalletPublicKeyHash is the hex-encoded public key hash of the affected
+	// wallet. Empty if the issue is not related to a specific wallet.
+	WalletPublicKeyHash string `json:"wallet_public_key_hash,omitempty"`
+	// MemberIndex is the affected signing group member index. Zero if the
+	// issue is not related to a specific member.
+	MemberIndex group.MemberIndex      `json:"member_index,omitempty"`
+	Severity    KeyStoreHealthSeverity `json:"severity"`
+	Description string                 `json:"description"`
+}
+
+// KeyStoreHealth is the result of the key store integrity check.
+type KeyStoreHealth struct {
+	Wallets int             `json:"wallets"`
+	Signers int             `json:"signers"`
+	Issues  []KeyStoreIssue `json:"issues"`
+}
+
+// Severity returns the highest severity of the found issues.
+func (ksh *KeyStoreHealth) Severity() KeyStoreHealthSeverity {
+	severity := KeyStoreHealthy
+	for _, issue := range ksh.Issues {
+		if issue.Severity > severity {
+			severity = issue.Severity
+		}
+	}
+
+	return severity
+}
+
+func (ksh *KeyStoreHealth) addIssue(
+	walletPublicKeyHash [20]byte,
+	memberIndex group.MemberIndex,
+	severity KeyStoreHealthSeverity,
+	format string,
+	args ...interface{},
+) {
+	issue := KeyStoreIssue{
+		MemberIndex: memberIndex,
+		Severity:    severity,
+		Description: fmt.Sprintf(format, args...),
+	}
+
+	if walletPublicKeyHash != [20]byte{} {
+		issue.WalletPublicKeyHash = hex.EncodeToString(walletPublicKeyHash[:])
+	}
+
+	ksh.Issues = append(ksh.Issues, issue)
+}
+
+// checkKeyStoreHealth verifies all signers loaded into the wallet registry.
+// For each signer, the wallet public key is recomputed from the group data of
+// the private key share. For each wallet, it is checked whether the wallet
+// exists on-chain and is still registered, whether the stored signing group
+// operators are the on-chain wallet members, and whether the registry holds
+// signers for all the signing group members controlled by the operator with
+// the given address. Chain calls that fail do not make the wallet faulty but
+// unverified, which is reported as a warning.
+func checkKeyStoreHealth(
+	walletRegistry *walletRegistry,
+	keyStoreChain KeyStoreChain,
+	operatorAddress chain.Address,
+) *KeyStoreHealth {
+	health := &KeyStoreHealth{Issues: make([]KeyStoreIssue, 0)}
+
+	if walletRegistry.loadFailures > 0 {
+		health.addIssue(
+			[20]byte{},
+			0,
+			KeyStoreError,
+			"[%v] signer files could not be loaded from the key store",
+			walletRegistry.loadFailures,
+		)
+	}
+
+	for _, walletPublicKey := range walletRegistry.getWalletsPublicKeys() {
+		signers := walletRegistry.getSigners(walletPublicKey)
+		walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+		health.Wallets++
+		health.Signers += len(signers)
+
+		loadedMembers := make(map[group.MemberIndex]bool)
+		for _, signer := range signers {
+			memberIndex := signer.signingGroupMemberIndex
+			loadedMembers[memberIndex] = true
+
+			if err := verifySignerShare(signer); err != nil {
+				health.addIssue(
+					walletPublicKeyHash,
+					memberIndex,
+					KeyStoreError,
+					"%v",
+					err,
+				)
+			}
+
+			operators := signer.wallet.signingGroupOperators
+			if int(memberIndex) < 1 || int(memberIndex) > len(operators) ||
+				operators[memberIndex-1] != operatorAddress {
+				health.addIssue(
+					walletPublicKeyHash,
+					memberIndex,
+					KeyStoreError,
+					"signing group member is not controlled by the operator",
+				)
+			}
+		}
+
+		// All signers of a wallet hold the same signing group operators.
+		signingGroupOperators := signers[0].wallet.signingGroupOperators
+
+		membersMatch := checkWalletOnChain(
+			health,
+			keyStoreChain,
+			walletPublicKey,
+			walletPublicKeyHash,
+			signingGroupOperators,
+		)
+		if !membersMatch {
+			// The stored operators are not the on-chain wallet members so
+			// they cannot tell which members' signers are missing.
+			continue
+		}
+
+		for i, operator := range signingGroupOperators {
+			memberIndex := group.MemberIndex(i + 1)
+			if operator == operatorAddress && !loadedMembers[memberIndex] {
+				health.addIssue(
+					walletPublicKeyHash,
+					memberIndex,
+					KeyStoreError,
+					"signer of the operator's signing group member is missing",
+				)
+			}
+		}
+	}
+
+	return health
+}
+
+// checkWalletOnChain checks whether the given wallet exists on-chain, is
+// still registered, and has the given signing group operators as its
+// on-chain members. Failed chain calls are reported as warnings so that an
+// unavailable chain endpoint does not prevent the client from starting.
+// Returns false if the on-chain wallet members are known to differ from the
+// given operators.
+func checkWalletOnChain(
+	health *KeyStoreHealth,
+	keyStoreChain KeyStoreChain,
+	walletPublicKey *ecdsa.PublicKey,
+	walletPublicKeyHash [20]byte,
+	signingGroupOperators chain.Addresses,
+) bool {
+	unverified := func(format string, args ...interface{}) {
+		health.addIssue(
+			walletPublicKeyHash,
+			0,
+			KeyStoreWarning,
+			"wallet could not be verified on-chain; "+format,
+			args...,
+		)
+	}
+
+	walletID, err := keyStoreChain.CalculateWalletID(walletPublicKey)
+	if err != nil {
+		unverified("cannot calculate wallet ID: [%v]", err)
+		return true
+	}
+
+	walletChainData, err := keyStoreChain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		// The Bridge does not tell a missing wallet apart from a failed
+		// call. A wallet registered in the wallet registry is always known
+		// to the Bridge so the registry decides.
+		registered, registeredErr := keyStoreChain.IsWalletRegistered(walletID)
+		if registeredErr == nil && !registered {
+			health.addIssue(
+				walletPublicKeyHash,
+				0,
+				KeyStoreError,
+				"wallet does not exist on-chain: [%v]",
+				err,
+			)
+			return true
+		}
+
+		unverified("cannot get on-chain wallet data: [%v]", err)
+		return true
+	}
+
+	if walletChainData.EcdsaWalletID != walletID {
+		health.addIssue(
+			walletPublicKeyHash,
+			0,
+			KeyStoreError,
+			"on-chain ECDSA wallet ID [0x%x] does not match the wallet "+
+				"public key",
+			walletChainData.EcdsaWalletID,
+		)
+		return true
+	}
+
+	registered, err := keyStoreChain.IsWalletRegistered(walletID)
+	if err != nil {
+		unverified("cannot check wallet registration: [%v]", err)
+		return true
+	}
+
+	if !registered {
+		// The wallet registry forgets members of unregistered wallets.
+		health.addIssue(
+			walletPublicKeyHash,
+			0,
+			KeyStoreWarning,
+			"wallet is no longer registered on-chain but was not archived; "+
+				"wallet state is [%v]",
+			walletChainData.State,
+		)
+		return true
+	}
+
+	membersMatch, err := keyStoreChain.IsWalletSigningGroup(
+		walletID,
+		signingGroupOperators,
+	)
+	if err != nil {
+		unverified("cannot check wallet members: [%v]", err)
+		return true
+	}
+
+	if !membersMatch {
+		health.addIssue(
+			walletPublicKeyHash,
+			0,
+			KeyStoreError,
+			"stored signing group operators are not the on-chain wallet "+
+				"members",
+		)
+		return false
+	}
+
+	return true
+}
+
+// checkKeyStore runs the integrity check of signers loaded from the key store
+// and logs its result. Returns an error if the found issues are at least as
+// severe as configured by the KeyStoreCheckFailOn config property.
+func (n *node) checkKeyStore(config Config) (*KeyStoreHealth, error) {
+	failOn, failEnabled, err := parseKeyStoreCheckFailOn(
+		config.KeyStoreCheckFailOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	operatorAddress, err := n.operatorAddress()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get node's operator address: [%v]", err)
+	}
+
+	health := checkKeyStoreHealth(n.walletRegistry, n.chain, operatorAddress)
+
+	logKeyStoreHealth(health)
+
+	if failEnabled && health.Severity() >= failOn {
+		return nil, fmt.Errorf(
+			"key store check failed with status [%v]; inspect the reported "+
+				"issues or set tbtc.KeyStoreCheckFailOn to start anyway",
+			health.Severity(),
+		)
+	}
+
+	return health, nil
+}
+
+// logKeyStoreHealth logs the result of the key store integrity check.
+func logKeyStoreHealth(health *KeyStoreHealth) {
+	for _, issue := range health.Issues {
+		logFn := logger.Errorf
+		if issue.Severity == KeyStoreWarning {
+			logFn = logger.Warnf
+		}
+
+		switch {
+		case issue.WalletPublicKeyHash == "":
+			logFn("key store issue: %s", issue.Description)
+		case issue.MemberIndex == 0:
+			logFn(
+				"key store issue of wallet [0x%s]: %s",
+				issue.WalletPublicKeyHash,
+				issue.Description,
+			)
+		default:
+			logFn(
+				"key store issue of wallet [0x%s] member [%v]: %s",
+				issue.WalletPublicKeyHash,
+				issue.MemberIndex,
+				issue.Description,
+			)
+		}
+	}
+
+	logger.Infof(
+		"key store check completed with status [%v]; checked [%v] wallets "+
+			"with [%v] signers and found [%v] issues",
+		health.Severity(),
+		health.Wallets,
+		health.Signers,
+		len(health.Issues),
+	)
+}
+
+// Diagnostics returns the key store health in the form of the client info
+// diagnostics.
+func (ksh *KeyStoreHealth) Diagnostics() clientinfo.ApplicationInfo {
+	return clientinfo.ApplicationInfo{
+		"status":  ksh.Severity(),
+		"wallets": ksh.Wallets,
+		"signers": ksh.Signers,
+		"issues":  ksh.Issues,
+	}
+}
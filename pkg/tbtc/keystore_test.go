@@ -0,0 +1,512 @@
+package tbtc
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/protocol/group"
+)
+
+func TestVerifyStoredSigner(t *testing.T) {
+	signer := createMockSigner(t)
+
+	content, err := signer.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signer.wallet.publicKey)
+
+	localChain := Connect()
+	walletID, err := localChain.CalculateWalletID(signer.wallet.publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = map[string]struct {
+		chainFn       func() KeyStoreChain
+		expectedError bool
+	}{
+		"without chain": {
+			chainFn: func() KeyStoreChain { return nil },
+		},
+		"wallet registered on-chain": {
+			chainFn: func() KeyStoreChain {
+				chain := Connect()
+				chain.setWallet(walletPublicKeyHash, &WalletChainData{
+					EcdsaWalletID: walletID,
+					State:         StateLive,
+				})
+				return chain
+			},
+		},
+		"archived wallet closed on-chain": {
+			chainFn: func() KeyStoreChain {
+				chain := Connect()
+				chain.setWallet(walletPublicKeyHash, &WalletChainData{
+					EcdsaWalletID: walletID,
+					State:         StateClosed,
+				})
+				return chain
+			},
+		},
+		"wallet not found on-chain": {
+			chainFn:       func() KeyStoreChain { return Connect() },
+			expectedError: true,
+		},
+		"on-chain wallet ID mismatch": {
+			chainFn: func() KeyStoreChain {
+				chain := Connect()
+				chain.setWallet(walletPublicKeyHash, &WalletChainData{
+					EcdsaWalletID: [32]byte{0x01},
+					State:         StateLive,
+				})
+				return chain
+			},
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			storedSigner, err := VerifyStoredSigner(content, test.chainFn())
+
+			if test.expectedError != (err != nil) {
+				t.Fatalf("unexpected verification result: [%v]", err)
+			}
+
+			testutils.AssertBytesEqual(
+				t,
+				walletPublicKeyHash[:],
+				storedSigner.WalletPublicKeyHash[:],
+			)
+			testutils.AssertIntsEqual(
+				t,
+				"member index",
+				int(signer.signingGroupMemberIndex),
+				int(storedSigner.MemberIndex),
+			)
+		})
+	}
+}
+
+func TestVerifyStoredSigner_ShareMismatch(t *testing.T) {
+	signer := createMockSigner(t)
+
+	// Replace the wallet public key with a key that does not correspond to
+	// the private key share.
+	otherPublicKey := *signer.privateKeyShare.PublicKey()
+	otherPublicKey.X, otherPublicKey.Y = otherPublicKey.Curve.Double(
+		otherPublicKey.X,
+		otherPublicKey.Y,
+	)
+	signer.wallet.publicKey = &otherPublicKey
+
+	content, err := signer.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := VerifyStoredSigner(content, nil); err == nil {
+		t.Fatal("expected verification error")
+	}
+}
+
+func TestReadStoredWallets(t *testing.T) {
+	signer := createMockSigner(t)
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signer.wallet.publicKey)
+
+	walletID, err := Connect().CalculateWalletID(signer.wallet.publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signerFile := func(
+		memberIndex group.MemberIndex,
+		archived bool,
+	) *KeyStoreSignerFile {
+		memberSigner := *signer
+		memberSigner.signingGroupMemberIndex = memberIndex
+
+		content, err := memberSigner.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &KeyStoreSignerFile{
+			Path:     fmt.Sprintf("membership_%v", memberIndex),
+			Archived: archived,
+			Content:  content,
+		}
+	}
+
+	files := []*KeyStoreSignerFile{
+		signerFile(3, false),
+		signerFile(2, true),
+		signerFile(1, false),
+	}
+
+	var tests = map[string]struct {
+		setupChainFn      func() KeyStoreChain
+		expectedIssues    int
+		expectedWalletID  [32]byte
+		expectedChainData bool
+	}{
+		"without chain": {
+			setupChainFn: func() KeyStoreChain { return nil },
+		},
+		"wallet registered on-chain": {
+			setupChainFn: func() KeyStoreChain {
+				chain := Connect()
+				chain.setWallet(walletPublicKeyHash, &WalletChainData{
+					EcdsaWalletID: walletID,
+					State:         StateLive,
+				})
+				return chain
+			},
+			expectedWalletID:  walletID,
+			expectedChainData: true,
+		},
+		"wallet not found on-chain": {
+			setupChainFn:     func() KeyStoreChain { return Connect() },
+			expectedIssues:   1,
+			expectedWalletID: walletID,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			wallets, err := ReadStoredWallets(files, test.setupChainFn())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertIntsEqual(t, "wallets count", 2, len(wallets))
+
+			for i, expected := range []struct {
+				archived      bool
+				memberIndexes []group.MemberIndex
+			}{
+				{false, []group.MemberIndex{1, 3}},
+				{true, []group.MemberIndex{2}},
+			} {
+				wallet := wallets[i]
+
+				testutils.AssertBytesEqual(
+					t,
+					walletPublicKeyHash[:],
+					wallet.WalletPublicKeyHash[:],
+				)
+				testutils.AssertBytesEqual(
+					t,
+					test.expectedWalletID[:],
+					wallet.WalletID[:],
+				)
+				testutils.AssertBoolsEqual(
+					t,
+					"archived",
+					expected.archived,
+					wallet.Archived,
+				)
+				if !reflect.DeepEqual(
+					expected.memberIndexes,
+					wallet.MemberIndexes,
+				) {
+					t.Errorf(
+						"unexpected member indexes\n"+
+							"expected: %v\nactual:   %v",
+						expected.memberIndexes,
+						wallet.MemberIndexes,
+					)
+				}
+				testutils.AssertIntsEqual(
+					t,
+					"group size",
+					len(signer.wallet.signingGroupOperators),
+					wallet.GroupSize,
+				)
+				// The mock signer's private key share belongs to a 3-of-5
+				// signing group.
+				testutils.AssertIntsEqual(
+					t,
+					"honest threshold",
+					3,
+					wallet.HonestThreshold,
+				)
+				testutils.AssertBoolsEqual(
+					t,
+					"chain data",
+					test.expectedChainData,
+					wallet.ChainData != nil,
+				)
+				testutils.AssertIntsEqual(
+					t,
+					"issues count",
+					test.expectedIssues,
+					len(wallet.Issues),
+				)
+			}
+		})
+	}
+}
+
+func TestReadStoredWallets_InvalidFile(t *testing.T) {
+	_, err := ReadStoredWallets(
+		[]*KeyStoreSignerFile{{Path: "membership_1", Content: []byte{0x01}}},
+		nil,
+	)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCheckKeyStoreHealth(t *testing.T) {
+	signer := createMockSigner(t)
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signer.wallet.publicKey)
+
+	walletID, err := Connect().CalculateWalletID(signer.wallet.publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registeredWallet := func(chain *localChain) {
+		chain.setWallet(walletPublicKeyHash, &WalletChainData{
+			EcdsaWalletID: walletID,
+			State:         StateLive,
+		})
+		chain.setWalletSigningGroup(walletID, signer.wallet.signingGroupOperators)
+	}
+
+	type expectedIssue struct {
+		memberIndex group.MemberIndex
+		severity    KeyStoreHealthSeverity
+	}
+
+	var tests = map[string]struct {
+		operatorAddress chain.Address
+		memberIndexes   []group.MemberIndex
+		corruptedFiles  int
+		setupChainFn    func(chain *localChain)
+		failChainCalls  bool
+		expectedIssues  []expectedIssue
+	}{
+		"healthy": {
+			operatorAddress: "address-1",
+			memberIndexes:   []group.MemberIndex{1},
+			setupChainFn:    registeredWallet,
+			expectedIssues:  []expectedIssue{},
+		},
+		"missing member": {
+			operatorAddress: "address-3",
+			memberIndexes:   []group.MemberIndex{3},
+			setupChainFn:    registeredWallet,
+			expectedIssues:  []expectedIssue{{4, KeyStoreError}},
+		},
+		"member of another operator": {
+			operatorAddress: "address-1",
+			memberIndexes:   []group.MemberIndex{1, 2},
+			setupChainFn:    registeredWallet,
+			expectedIssues:  []expectedIssue{{2, KeyStoreError}},
+		},
+		"corrupted signer file": {
+			operatorAddress: "address-1",
+			memberIndexes:   []group.MemberIndex{1},
+			corruptedFiles:  1,
+			setupChainFn:    registeredWallet,
+			expectedIssues:  []expectedIssue{{0, KeyStoreError}},
+		},
+		"wallet closed but not archived": {
+			operatorAddress: "address-1",
+			memberIndexes:   []group.MemberIndex{1},
+			setupChainFn: func(chain *localChain) {
+				chain.setWallet(walletPublicKeyHash, &WalletChainData{
+					EcdsaWalletID: walletID,
+					State:         StateClosed,
+				})
+			},
+			expectedIssues: []expectedIssue{{0, KeyStoreWarning}},
+		},
+		"wallet not found on-chain": {
+			operatorAddress: "address-1",
+			memberIndexes:   []group.MemberIndex{1},
+			setupChainFn:    func(chain *localChain) {},
+			expectedIssues:  []expectedIssue{{0, KeyStoreError}},
+		},
+		"chain calls failing": {
+			operatorAddress: "address-3",
+			memberIndexes:   []group.MemberIndex{3},
+			setupChainFn:    registeredWallet,
+			failChainCalls:  true,
+			// The wallet is unverified but the stored operators still tell
+			// which signers are missing.
+			expectedIssues: []expectedIssue{
+				{0, KeyStoreWarning},
+				{4, KeyStoreError},
+			},
+		},
+		"stored operators are not the on-chain members": {
+			operatorAddress: "address-3",
+			memberIndexes:   []group.MemberIndex{3},
+			setupChainFn: func(testChain *localChain) {
+				registeredWallet(testChain)
+				testChain.setWalletSigningGroup(
+					walletID,
+					chain.Addresses{"address-1", "address-2"},
+				)
+			},
+			// Missing members are not reported against stored operators
+			// that differ from the on-chain members.
+			expectedIssues: []expectedIssue{{0, KeyStoreError}},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			persistenceHandle := &mockPersistenceHandle{}
+
+			for _, memberIndex := range test.memberIndexes {
+				memberSigner := createMockSigner(t)
+				memberSigner.signingGroupMemberIndex = memberIndex
+
+				signerBytes, err := memberSigner.Marshal()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				persistenceHandle.saved = append(
+					persistenceHandle.saved,
+					&mockDescriptor{
+						name:      fmt.Sprintf("membership_%v", memberIndex),
+						directory: "wallet_1",
+						content:   signerBytes,
+					},
+				)
+			}
+
+			for i := 0; i < test.corruptedFiles; i++ {
+				persistenceHandle.saved = append(
+					persistenceHandle.saved,
+					&mockDescriptor{
+						name:      "membership_corrupted",
+						directory: "wallet_1",
+						content:   []byte{0x01, 0x02, 0x03},
+					},
+				)
+			}
+
+			testChain := Connect()
+			test.setupChainFn(testChain)
+
+			walletRegistry, err := newWalletRegistry(
+				persistenceHandle,
+				testChain.CalculateWalletID,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var keyStoreChain KeyStoreChain = testChain
+			if test.failChainCalls {
+				keyStoreChain = &failingKeyStoreChain{testChain}
+			}
+
+			health := checkKeyStoreHealth(
+				walletRegistry,
+				keyStoreChain,
+				test.operatorAddress,
+			)
+
+			testutils.AssertIntsEqual(t, "wallets", 1, health.Wallets)
+			testutils.AssertIntsEqual(
+				t,
+				"signers",
+				len(test.memberIndexes),
+				health.Signers,
+			)
+
+			actualIssues := make([]expectedIssue, 0)
+			for _, issue := range health.Issues {
+				actualIssues = append(
+					actualIssues,
+					expectedIssue{issue.MemberIndex, issue.Severity},
+				)
+			}
+
+			if !reflect.DeepEqual(test.expectedIssues, actualIssues) {
+				t.Errorf(
+					"unexpected issues\nexpected: %v\nactual:   %v\n%+v",
+					test.expectedIssues,
+					actualIssues,
+					health.Issues,
+				)
+			}
+		})
+	}
+}
+
+// failingKeyStoreChain is a key store chain whose calls reading the on-chain
+// wallet fail, as with an unavailable chain endpoint.
+type failingKeyStoreChain struct {
+	*localChain
+}
+
+func (fksc *failingKeyStoreChain) GetWallet(
+	walletPublicKeyHash [20]byte,
+) (*WalletChainData, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func (fksc *failingKeyStoreChain) IsWalletRegistered(
+	EcdsaWalletID [32]byte,
+) (bool, error) {
+	return false, fmt.Errorf("connection refused")
+}
+
+func TestParseKeyStoreCheckFailOn(t *testing.T) {
+	var tests = map[string]struct {
+		value               string
+		expectedSeverity    KeyStoreHealthSeverity
+		expectedFailEnabled bool
+		expectedError       bool
+	}{
+		"default": {
+			value:               "",
+			expectedSeverity:    KeyStoreError,
+			expectedFailEnabled: true,
+		},
+		"warning": {
+			value:               "Warning",
+			expectedSeverity:    KeyStoreWarning,
+			expectedFailEnabled: true,
+		},
+		"never": {
+			value:               "never",
+			expectedFailEnabled: false,
+		},
+		"invalid": {
+			value:         "critical",
+			expectedError: true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			severity, failEnabled, err := parseKeyStoreCheckFailOn(test.value)
+			if test.expectedError != (err != nil) {
+				t.Fatalf("unexpected error: [%v]", err)
+			}
+
+			if severity != test.expectedSeverity {
+				t.Errorf("unexpected severity: [%v]", severity)
+			}
+
+			if failEnabled != test.expectedFailEnabled {
+				t.Errorf("unexpected fail enabled: [%v]", failEnabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+)
+
+// dutiesPredictedWindows is the number of upcoming coordination windows
+// included in the duties report.
+const dutiesPredictedWindows = 3
+
+// maintenanceMode determines whether the node accepts new duties. In the
+// maintenance mode, the node does not take part in new coordination windows,
+// does not join new DKG executions and holds off with joining the sortition
+// pool. Duties already in progress are completed.
+type maintenanceMode struct {
+	mutex   sync.RWMutex
+	enabled bool
+}
+
+func newMaintenanceMode(enabled bool) *maintenanceMode {
+	if enabled {
+		logger.Warnf(
+			"node started in the maintenance mode; the node declines new " +
+				"coordination windows, DKG executions and joining the " +
+				"sortition pool",
+		)
+	}
+
+	return &maintenanceMode{enabled: enabled}
+}
+
+// isEnabled returns true if the node is in the maintenance mode.
+func (mm *maintenanceMode) isEnabled() bool {
+	mm.mutex.RLock()
+	defer mm.mutex.RUnlock()
+
+	return mm.enabled
+}
+
+// set enables or disables the maintenance mode. Returns true if the mode
+// changed.
+func (mm *maintenanceMode) set(enabled bool) bool {
+	mm.mutex.Lock()
+	defer mm.mutex.Unlock()
+
+	if mm.enabled == enabled {
+		return false
+	}
+
+	mm.enabled = enabled
+
+	if enabled {
+		logger.Warnf(
+			"maintenance mode enabled; the node declines new coordination " +
+				"windows, DKG executions and joining the sortition pool",
+		)
+	} else {
+		logger.Infof("maintenance mode disabled; the node accepts new duties")
+	}
+
+	return true
+}
+
+// maintenanceModePolicy is a sortition pool join policy that holds off
+// with joining the pool while the node is in the maintenance mode.
+type maintenanceModePolicy struct {
+	maintenanceMode *maintenanceMode
+}
+
+func (mmp *maintenanceModePolicy) ShouldJoin() bool {
+	return !mmp.maintenanceMode.isEnabled()
+}
+
+// CoordinationWindowDuties describes the duties of the node in the given
+// coordination window.
+type CoordinationWindowDuties struct {
+	CoordinationBlock   uint64 `json:"coordination_block"`
+	ActivePhaseEndBlock uint64 `json:"active_phase_end_block"`
+	EndBlock            uint64 `json:"end_block"`
+	// Wallets are the wallets the node takes part in the coordination of.
+	Wallets []WalletCoordinationDuty `json:"wallets"`
+}
+
+// WalletCoordinationDuty describes the role of the node in the coordination
+// of the given wallet.
+type WalletCoordinationDuty struct {
+	WalletPublicKeyHash string `json:"wallet_public_key_hash"`
+	// Leader is the address of the coordination leader. The leader is
+	// known only once the safe block of the coordination window is mined;
+	// the field is empty otherwise.
+	Leader   string `json:"leader,omitempty"`
+	IsLeader bool   `json:"is_leader"`
+	// BackupLeaders are the addresses of the backup leaders proposing in
+	// order if the leader does not.
+	BackupLeaders []string `json:"backup_leaders,omitempty"`
+	// LeaderRepeatedlyFaulty is true if the leader has been faulty in the
+	// recent coordination windows of the wallet.
+	LeaderRepeatedlyFaulty bool `json:"leader_repeatedly_faulty,omitempty"`
+}
+
+// upcomingCoordinationWindows returns the given number of coordination
+// windows not ended at the given block, starting from the window in
+// progress, if any.
+func upcomingCoordinationWindows(
+	currentBlock uint64,
+	count int,
+) []*coordinationWindow {
+	windowIndex := currentBlock / coordinationFrequencyBlocks
+	if windowIndex == 0 ||
+		newCoordinationWindow(
+			windowIndex*coordinationFrequencyBlocks,
+		).endBlock() <= currentBlock {
+		windowIndex++
+	}
+
+	windows := make([]*coordinationWindow, count)
+	for i := range windows {
+		windows[i] = newCoordinationWindow(
+			(windowIndex + uint64(i)) * coordinationFrequencyBlocks,
+		)
+	}
+
+	return windows
+}
+
+// dutiesDiagnostics returns the duties of the node: the upcoming
+// coordination windows along with the node's role in them, the DKG state,
+// the wallets moving funds, and the work in progress.
+func (n *node) dutiesDiagnostics() clientinfo.ApplicationInfo {
+	blockCounter, err := n.chain.BlockCounter()
+	if err != nil {
+		logger.Errorf("cannot get block counter: [%v]", err)
+		return nil
+	}
+
+	currentBlock, err := blockCounter.CurrentBlock()
+	if err != nil {
+		logger.Errorf("cannot get current block: [%v]", err)
+		return nil
+	}
+
+	operatorAddress, err := n.operatorAddress()
+	if err != nil {
+		logger.Errorf("cannot get node's operator address: [%v]", err)
+		return nil
+	}
+
+	walletsPublicKeys := n.walletRegistry.getWalletsPublicKeys()
+
+	windows := make([]CoordinationWindowDuties, 0, dutiesPredictedWindows)
+	for _, window := range upcomingCoordinationWindows(
+		currentBlock,
+		dutiesPredictedWindows,
+	) {
+		windowDuties := CoordinationWindowDuties{
+			CoordinationBlock:   window.coordinationBlock,
+			ActivePhaseEndBlock: window.activePhaseEndBlock(),
+			EndBlock:            window.endBlock(),
+			Wallets:             make([]WalletCoordinationDuty, 0),
+		}
+
+		safeBlockMined := currentBlock >=
+			window.coordinationBlock-coordinationSafeBlockShift
+
+		for _, walletPublicKey := range walletsPublicKeys {
+			signers := n.walletRegistry.getSigners(walletPublicKey)
+			if len(signers) == 0 {
+				continue
+			}
+
+			walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+			walletDuty := WalletCoordinationDuty{
+				WalletPublicKeyHash: hex.EncodeToString(walletPublicKeyHash[:]),
+			}
+
+			if safeBlockMined {
+				seed, err := coordinationSeed(
+					n.chain,
+					walletPublicKeyHash,
+					window.coordinationBlock,
+				)
+				if err != nil {
+					logger.Errorf("cannot compute coordination seed: [%v]", err)
+				} else {
+					leaders := coordinationLeaders(
+						window,
+						signers[0].wallet.signingGroupOperators,
+						seed,
+					)
+					leader := leaders[0]
+					walletDuty.Leader = leader.String()
+					for _, backupLeader := range leaders[1:] {
+						walletDuty.BackupLeaders = append(
+							walletDuty.BackupLeaders,
+							backupLeader.String(),
+						)
+					}
+					walletDuty.IsLeader = leader == operatorAddress
+					walletDuty.LeaderRepeatedlyFaulty =
+						n.coordinationFaults.isRepeatedlyFaulty(
+							walletPublicKeyHash,
+							leader,
+						)
+				}
+			}
+
+			windowDuties.Wallets = append(windowDuties.Wallets, walletDuty)
+		}
+
+		windows = append(windows, windowDuties)
+	}
+
+	dkgState := "unknown"
+	if state, err := n.chain.GetDKGState(); err != nil {
+		logger.Errorf("cannot get DKG state: [%v]", err)
+	} else {
+		dkgState = state.String()
+	}
+
+	movingFundsWallets := make([]string, 0)
+	for _, walletPublicKey := range walletsPublicKeys {
+		walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+		walletChainData, err := n.chain.GetWallet(walletPublicKeyHash)
+		if err != nil {
+			logger.Errorf(
+				"cannot get on-chain data of wallet [0x%x]: [%v]",
+				walletPublicKeyHash,
+				err,
+			)
+			continue
+		}
+
+		if walletChainData.State == StateMovingFunds {
+			movingFundsWallets = append(
+				movingFundsWallets,
+				hex.EncodeToString(walletPublicKeyHash[:]),
+			)
+		}
+	}
+
+	inFlight := n.shutdownCoordinator.inFlightDescriptions()
+	if inFlight == nil {
+		inFlight = make([]string, 0)
+	}
+
+	return clientinfo.ApplicationInfo{
+		"current_block":        currentBlock,
+		"maintenance_mode":     n.maintenanceMode.isEnabled(),
+		"coordination_windows": windows,
+		"dkg_state":            dkgState,
+		"moving_funds_wallets": movingFundsWallets,
+		"in_flight":            inFlight,
+	}
+}
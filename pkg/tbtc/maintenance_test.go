@@ -0,0 +1,72 @@
+package tbtc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+)
+
+func TestUpcomingCoordinationWindows(t *testing.T) {
+	var tests = map[string]struct {
+		currentBlock    uint64
+		expectedWindows []uint64
+	}{
+		"before first window": {
+			currentBlock:    500,
+			expectedWindows: []uint64{900, 1800, 2700},
+		},
+		"at window start": {
+			currentBlock:    1800,
+			expectedWindows: []uint64{1800, 2700, 3600},
+		},
+		"during window": {
+			currentBlock:    1899,
+			expectedWindows: []uint64{1800, 2700, 3600},
+		},
+		"at window end": {
+			currentBlock:    1900,
+			expectedWindows: []uint64{2700, 3600, 4500},
+		},
+		"between windows": {
+			currentBlock:    2000,
+			expectedWindows: []uint64{2700, 3600, 4500},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			windows := upcomingCoordinationWindows(test.currentBlock, 3)
+
+			actualWindows := make([]uint64, len(windows))
+			for i, window := range windows {
+				actualWindows[i] = window.coordinationBlock
+			}
+
+			if !reflect.DeepEqual(test.expectedWindows, actualWindows) {
+				t.Errorf(
+					"unexpected windows\nexpected: %v\nactual:   %v",
+					test.expectedWindows,
+					actualWindows,
+				)
+			}
+		})
+	}
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	maintenanceMode := newMaintenanceMode(false)
+	policy := &maintenanceModePolicy{maintenanceMode: maintenanceMode}
+
+	testutils.AssertBoolsEqual(t, "enabled", false, maintenanceMode.isEnabled())
+	testutils.AssertBoolsEqual(t, "should join", true, policy.ShouldJoin())
+
+	testutils.AssertBoolsEqual(t, "changed", true, maintenanceMode.set(true))
+	testutils.AssertBoolsEqual(t, "changed", false, maintenanceMode.set(true))
+
+	testutils.AssertBoolsEqual(t, "enabled", true, maintenanceMode.isEnabled())
+	testutils.AssertBoolsEqual(t, "should join", false, policy.ShouldJoin())
+
+	testutils.AssertBoolsEqual(t, "changed", true, maintenanceMode.set(false))
+	testutils.AssertBoolsEqual(t, "should join", true, policy.ShouldJoin())
+}
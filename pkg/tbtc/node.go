@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -63,6 +64,15 @@ type node struct {
 	// by appropriate actions dispatched through this component.
 	walletDispatcher *walletDispatcher
 
+	// shutdownCoordinator keeps track of the coordination procedures,
+	// wallet actions and DKG executions in progress, so they can complete
+	// before the node is stopped.
+	shutdownCoordinator *shutdownCoordinator
+
+	// maintenanceMode determines whether the node accepts new coordination
+	// windows and DKG executions.
+	maintenanceMode *maintenanceMode
+
 	// protocolLatch makes sure no expensive number generator operations are
 	// running when signing or generating a wallet key are executed. The
 	// protocolLatch is used by dkgExecutor and signingExecutor.
@@ -77,6 +87,19 @@ type node struct {
 	// failures for each wallet.
 	heartbeatFailureCounter *heartbeatFailureCounter
 
+	// operatorStatusRecorder keeps the recent inactivity claims and exposes
+	// them in the operator status along with the recent coordination faults
+	// accounted by coordinationFaults.
+	operatorStatusRecorder *operatorStatusRecorder
+
+	// coordinationFaults aggregates the coordination faults observed by
+	// the node per wallet and per operator responsible for them.
+	coordinationFaults *coordinationFaultsAccounting
+
+	// coordinationJournal records the coordination windows of the wallets
+	// controlled by the node along with the outcomes of the proposed actions.
+	coordinationJournal *coordinationJournal
+
 	inactivityClaimExecutorMutex sync.Mutex
 	// inactivityClaimExecutors is the cache holding inactivity claim executors
 	// for specific wallets. The cache key is the uncompressed public key
@@ -111,6 +134,14 @@ type node struct {
 	// proposalGenerator is the implementation of the coordination proposal
 	// generator used by the node.
 	proposalGenerator CoordinationProposalGenerator
+
+	// coordinationPolicy determines the wallet actions checked in the
+	// coordination windows.
+	coordinationPolicy CoordinationPolicy
+
+	// redemptionMonitor raises alerts for pending redemption requests of
+	// the wallets controlled by the node that near their timeout.
+	redemptionMonitor *redemptionMonitor
 }
 
 func newNode(
@@ -135,19 +166,52 @@ func newNode(
 	latch := generator.NewProtocolLatch()
 	scheduler.RegisterProtocol(latch)
 
+	shutdownCoordinator := newShutdownCoordinator()
+
+	coordinationJournal := newCoordinationJournal(
+		workPersistence,
+		config.CoordinationJournalRetention,
+	)
+
+	coordinationPolicy := newCoordinationPolicy(chain)
+
+	heartbeatFailureCounter := newHeartbeatFailureCounter()
+
+	coordinationFaults := newCoordinationFaultsAccounting(workPersistence)
+
+	redemptionMonitor, err := newRedemptionMonitor(
+		chain,
+		btcChain,
+		walletRegistry,
+		heartbeatFailureCounter,
+		groupParameters.HonestThreshold,
+		config.RedemptionTimeoutWarningThreshold,
+		config.RedemptionTimeoutCriticalThreshold,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create redemption monitor: [%v]", err)
+	}
+
 	node := &node{
 		groupParameters:          groupParameters,
 		chain:                    chain,
 		btcChain:                 btcChain,
 		netProvider:              netProvider,
 		walletRegistry:           walletRegistry,
-		walletDispatcher:         newWalletDispatcher(),
+		walletDispatcher:         newWalletDispatcher(shutdownCoordinator),
+		shutdownCoordinator:      shutdownCoordinator,
+		maintenanceMode:          newMaintenanceMode(config.MaintenanceMode),
 		protocolLatch:            latch,
-		heartbeatFailureCounter:  newHeartbeatFailureCounter(),
+		heartbeatFailureCounter:  heartbeatFailureCounter,
+		operatorStatusRecorder:   newOperatorStatusRecorder(coordinationFaults),
+		coordinationFaults:       coordinationFaults,
+		coordinationJournal:      coordinationJournal,
 		signingExecutors:         make(map[string]*signingExecutor),
 		inactivityClaimExecutors: make(map[string]*inactivityClaimExecutor),
 		coordinationExecutors:    make(map[string]*coordinationExecutor),
 		proposalGenerator:        proposalGenerator,
+		coordinationPolicy:       coordinationPolicy,
+		redemptionMonitor:        redemptionMonitor,
 	}
 
 	// Archive any wallets that might have been closed or terminated while the
@@ -178,6 +242,7 @@ func newNode(
 		config,
 		workPersistence,
 		scheduler,
+		shutdownCoordinator,
 		node.waitForBlockHeight,
 	)
 
@@ -233,6 +298,18 @@ func (n *node) joinDKGIfEligible(
 	n.dkgExecutor.executeDkgIfEligible(seed, startBlock, delayBlocks)
 }
 
+// updateConfig applies changes of the TBTC configuration to the running node.
+// It returns names of the Config fields whose changes were applied.
+func (n *node) updateConfig(config Config) []string {
+	applied := n.dkgExecutor.updateConfig(config)
+
+	if n.maintenanceMode.set(config.MaintenanceMode) {
+		applied = append(applied, "MaintenanceMode")
+	}
+
+	return applied
+}
+
 // validateDKG performs the submitted DKG result validation process.
 // If the result is not valid, this function submits an on-chain result
 // challenge. If the result is valid and the given node was involved in the DKG,
@@ -247,6 +324,37 @@ func (n *node) validateDKG(
 	n.dkgExecutor.executeDkgValidation(seed, submissionBlock, result, resultHash)
 }
 
+// signingChannelName returns the name of the broadcast channel used by the
+// signing protocol of the wallet with the given public key bytes.
+func signingChannelName(walletPublicKeyBytes []byte) string {
+	return fmt.Sprintf(
+		"%s-%s",
+		ProtocolName,
+		hex.EncodeToString(walletPublicKeyBytes),
+	)
+}
+
+// coordinationChannelName returns the name of the broadcast channel used by
+// the coordination procedure of the wallet with the given public key bytes.
+func coordinationChannelName(walletPublicKeyBytes []byte) string {
+	return fmt.Sprintf(
+		"%s-%s-coordination",
+		ProtocolName,
+		hex.EncodeToString(walletPublicKeyBytes),
+	)
+}
+
+// inactivityChannelName returns the name of the broadcast channel used by
+// the inactivity claim protocol of the wallet with the given public key
+// bytes.
+func inactivityChannelName(walletPublicKeyBytes []byte) string {
+	return fmt.Sprintf(
+		"%s-%s-inactivity",
+		ProtocolName,
+		hex.EncodeToString(walletPublicKeyBytes),
+	)
+}
+
 // getSigningExecutor gets the signing executor responsible for executing
 // signing related to a specific wallet whose part is controlled by this node.
 // The second boolean return value indicates whether the node controls at least
@@ -283,11 +391,7 @@ func (n *node) getSigningExecutor(
 	// first signer.
 	wallet := signers[0].wallet
 
-	channelName := fmt.Sprintf(
-		"%s-%s",
-		ProtocolName,
-		hex.EncodeToString(walletPublicKeyBytes),
-	)
+	channelName := signingChannelName(walletPublicKeyBytes)
 
 	broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
 	if err != nil {
@@ -380,11 +484,7 @@ func (n *node) getCoordinationExecutor(
 	// first signer.
 	wallet := signers[0].wallet
 
-	channelName := fmt.Sprintf(
-		"%s-%s-coordination",
-		ProtocolName,
-		hex.EncodeToString(walletPublicKeyBytes),
-	)
+	channelName := coordinationChannelName(walletPublicKeyBytes)
 
 	broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
 	if err != nil {
@@ -428,6 +528,7 @@ func (n *node) getCoordinationExecutor(
 		membersIndexes,
 		operatorAddress,
 		n.proposalGenerator,
+		n.coordinationPolicy,
 		broadcastChannel,
 		membershipValidator,
 		n.protocolLatch,
@@ -479,11 +580,7 @@ func (n *node) getInactivityClaimExecutor(
 	// All signers belong to one wallet. Take that wallet from the first signer.
 	wallet := signers[0].wallet
 
-	channelName := fmt.Sprintf(
-		"%s-%s-inactivity",
-		ProtocolName,
-		hex.EncodeToString(walletPublicKeyBytes),
-	)
+	channelName := inactivityChannelName(walletPublicKeyBytes)
 
 	broadcastChannel, err := n.netProvider.BroadcastChannelFor(channelName)
 	if err != nil {
@@ -528,23 +625,26 @@ func (n *node) getInactivityClaimExecutor(
 }
 
 // handleHeartbeatProposal handles an incoming heartbeat proposal by
-// orchestrating and dispatching an appropriate wallet action.
+// orchestrating and dispatching an appropriate wallet action. The outcome
+// function is notified once the dispatched action completes. Returns an
+// error if the action was not dispatched.
 func (n *node) handleHeartbeatProposal(
 	wallet wallet,
 	proposal *HeartbeatProposal,
 	startBlock uint64,
 	expiryBlock uint64,
-) {
+	outcomeFn walletActionOutcomeFn,
+) error {
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot marshal wallet public key: [%v]", err)
-		return
+		return fmt.Errorf("cannot marshal wallet public key: [%w]", err)
 	}
 
 	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot get signing executor: [%v]", err)
-		return
+		return fmt.Errorf("cannot get signing executor: [%w]", err)
 	}
 	// This check is actually redundant. We know the node controls some
 	// wallet signers as we just got the wallet from the registry using their
@@ -556,13 +656,13 @@ func (n *node) handleHeartbeatProposal(
 				"ignoring the received heartbeat request",
 			walletPublicKeyBytes,
 		)
-		return
+		return fmt.Errorf("node does not control signers of the wallet")
 	}
 
 	inactivityClaimExecutor, ok, err := n.getInactivityClaimExecutor(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot get inactivity claim executor: [%v]", err)
-		return
+		return fmt.Errorf("cannot get inactivity claim executor: [%w]", err)
 	}
 	// This check is actually redundant. We know the node controls some
 	// wallet signers as we just got the wallet from the registry using their
@@ -574,7 +674,7 @@ func (n *node) handleHeartbeatProposal(
 				"ignoring the received heartbeat request",
 			walletPublicKeyBytes,
 		)
-		return
+		return fmt.Errorf("node does not control signers of the wallet")
 	}
 
 	logger.Infof(
@@ -605,33 +705,38 @@ func (n *node) handleHeartbeatProposal(
 		n.waitForBlockHeight,
 	)
 
-	err = n.walletDispatcher.dispatch(action)
+	err = n.walletDispatcher.dispatch(action, outcomeFn)
 	if err != nil {
 		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
-		return
+		return fmt.Errorf("cannot dispatch wallet action: [%w]", err)
 	}
 
 	walletActionLogger.Infof("wallet action dispatched successfully")
+
+	return nil
 }
 
 // handleDepositSweepProposal handles an incoming deposit sweep proposal by
-// orchestrating and dispatching an appropriate wallet action.
+// orchestrating and dispatching an appropriate wallet action. The outcome
+// function is notified once the dispatched action completes. Returns an
+// error if the action was not dispatched.
 func (n *node) handleDepositSweepProposal(
 	wallet wallet,
 	proposal *DepositSweepProposal,
 	startBlock uint64,
 	expiryBlock uint64,
-) {
+	outcomeFn walletActionOutcomeFn,
+) error {
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot marshal wallet public key: [%v]", err)
-		return
+		return fmt.Errorf("cannot marshal wallet public key: [%w]", err)
 	}
 
 	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot get signing executor: [%v]", err)
-		return
+		return fmt.Errorf("cannot get signing executor: [%w]", err)
 	}
 	// This check is actually redundant. We know the node controls some
 	// wallet signers as we just got the wallet from the registry using their
@@ -643,7 +748,7 @@ func (n *node) handleDepositSweepProposal(
 				"ignoring the received deposit sweep proposal",
 			walletPublicKeyBytes,
 		)
-		return
+		return fmt.Errorf("node does not control signers of the wallet")
 	}
 
 	logger.Infof(
@@ -673,33 +778,38 @@ func (n *node) handleDepositSweepProposal(
 		n.waitForBlockHeight,
 	)
 
-	err = n.walletDispatcher.dispatch(action)
+	err = n.walletDispatcher.dispatch(action, outcomeFn)
 	if err != nil {
 		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
-		return
+		return fmt.Errorf("cannot dispatch wallet action: [%w]", err)
 	}
 
 	walletActionLogger.Infof("wallet action dispatched successfully")
+
+	return nil
 }
 
 // handleRedemptionProposal handles an incoming redemption proposal by
-// orchestrating and dispatching an appropriate wallet action.
+// orchestrating and dispatching an appropriate wallet action. The outcome
+// function is notified once the dispatched action completes. Returns an
+// error if the action was not dispatched.
 func (n *node) handleRedemptionProposal(
 	wallet wallet,
 	proposal *RedemptionProposal,
 	startBlock uint64,
 	expiryBlock uint64,
-) {
+	outcomeFn walletActionOutcomeFn,
+) error {
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot marshal wallet public key: [%v]", err)
-		return
+		return fmt.Errorf("cannot marshal wallet public key: [%w]", err)
 	}
 
 	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot get signing executor: [%v]", err)
-		return
+		return fmt.Errorf("cannot get signing executor: [%w]", err)
 	}
 	// This check is actually redundant. We know the node controls some
 	// wallet signers as we just got the wallet from the registry using their
@@ -711,7 +821,7 @@ func (n *node) handleRedemptionProposal(
 				"ignoring the received redemption proposal",
 			walletPublicKeyBytes,
 		)
-		return
+		return fmt.Errorf("node does not control signers of the wallet")
 	}
 
 	logger.Infof(
@@ -741,33 +851,38 @@ func (n *node) handleRedemptionProposal(
 		n.waitForBlockHeight,
 	)
 
-	err = n.walletDispatcher.dispatch(action)
+	err = n.walletDispatcher.dispatch(action, outcomeFn)
 	if err != nil {
 		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
-		return
+		return fmt.Errorf("cannot dispatch wallet action: [%w]", err)
 	}
 
 	walletActionLogger.Infof("wallet action dispatched successfully")
+
+	return nil
 }
 
 // handleMovingFundsProposal handles an incoming moving funds proposal by
-// orchestrating and dispatching an appropriate wallet action.
+// orchestrating and dispatching an appropriate wallet action. The outcome
+// function is notified once the dispatched action completes. Returns an
+// error if the action was not dispatched.
 func (n *node) handleMovingFundsProposal(
 	wallet wallet,
 	proposal *MovingFundsProposal,
 	startBlock uint64,
 	expiryBlock uint64,
-) {
+	outcomeFn walletActionOutcomeFn,
+) error {
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot marshal wallet public key: [%v]", err)
-		return
+		return fmt.Errorf("cannot marshal wallet public key: [%w]", err)
 	}
 
 	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot get signing executor: [%v]", err)
-		return
+		return fmt.Errorf("cannot get signing executor: [%w]", err)
 	}
 	// This check is actually redundant. We know the node controls some
 	// wallet signers as we just got the wallet from the registry using their
@@ -779,7 +894,7 @@ func (n *node) handleMovingFundsProposal(
 				"ignoring the received moving funds proposal",
 			walletPublicKeyBytes,
 		)
-		return
+		return fmt.Errorf("node does not control signers of the wallet")
 	}
 
 	logger.Infof(
@@ -809,33 +924,38 @@ func (n *node) handleMovingFundsProposal(
 		n.waitForBlockHeight,
 	)
 
-	err = n.walletDispatcher.dispatch(action)
+	err = n.walletDispatcher.dispatch(action, outcomeFn)
 	if err != nil {
 		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
-		return
+		return fmt.Errorf("cannot dispatch wallet action: [%w]", err)
 	}
 
 	walletActionLogger.Infof("wallet action dispatched successfully")
+
+	return nil
 }
 
 // handleMovedFundsSweepProposal handles an incoming moved funds sweep proposal
-// by orchestrating and dispatching an appropriate wallet action.
+// by orchestrating and dispatching an appropriate wallet action. The outcome
+// function is notified once the dispatched action completes. Returns an
+// error if the action was not dispatched.
 func (n *node) handleMovedFundsSweepProposal(
 	wallet wallet,
 	proposal *MovedFundsSweepProposal,
 	startBlock uint64,
 	expiryBlock uint64,
-) {
+	outcomeFn walletActionOutcomeFn,
+) error {
 	walletPublicKeyBytes, err := marshalPublicKey(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot marshal wallet public key: [%v]", err)
-		return
+		return fmt.Errorf("cannot marshal wallet public key: [%w]", err)
 	}
 
 	signingExecutor, ok, err := n.getSigningExecutor(wallet.publicKey)
 	if err != nil {
 		logger.Errorf("cannot get signing executor: [%v]", err)
-		return
+		return fmt.Errorf("cannot get signing executor: [%w]", err)
 	}
 	// This check is actually redundant. We know the node controls some
 	// wallet signers as we just got the wallet from the registry using their
@@ -847,7 +967,7 @@ func (n *node) handleMovedFundsSweepProposal(
 				"ignoring the received moved funds sweep proposal",
 			walletPublicKeyBytes,
 		)
-		return
+		return fmt.Errorf("node does not control signers of the wallet")
 	}
 
 	logger.Infof(
@@ -877,13 +997,15 @@ func (n *node) handleMovedFundsSweepProposal(
 		n.waitForBlockHeight,
 	)
 
-	err = n.walletDispatcher.dispatch(action)
+	err = n.walletDispatcher.dispatch(action, outcomeFn)
 	if err != nil {
 		walletActionLogger.Errorf("cannot dispatch wallet action: [%v]", err)
-		return
+		return fmt.Errorf("cannot dispatch wallet action: [%w]", err)
 	}
 
 	walletActionLogger.Infof("wallet action dispatched successfully")
+
+	return nil
 }
 
 // coordinationLayerSettings represents settings for the coordination layer.
@@ -929,28 +1051,80 @@ func (n *node) runCoordinationLayer(
 		return fmt.Errorf("cannot get block counter: [%w]", err)
 	}
 
-	coordinationResultChan := make(chan *coordinationResult)
+	// pendingCoordinationResult is a coordination result along with the
+	// function marking its processing as completed.
+	type pendingCoordinationResult struct {
+		result *coordinationResult
+		done   func()
+	}
+
+	coordinationResultChan := make(chan *pendingCoordinationResult)
 
 	// Prepare a callback function that will be called every time a new
 	// coordination window is detected.
 	onWindowFn := func(window *coordinationWindow) {
+		if n.shutdownCoordinator.isDraining() {
+			logger.Infof(
+				"skipping coordination window at block [%v] as the node "+
+					"is shutting down",
+				window.coordinationBlock,
+			)
+			return
+		}
+
+		if n.maintenanceMode.isEnabled() {
+			logger.Infof(
+				"skipping coordination window at block [%v] as the node "+
+					"is in the maintenance mode",
+				window.coordinationBlock,
+			)
+			return
+		}
+
 		// Fetch all wallets controlled by the node. It is important to
 		// get the wallets every time the window is triggered as the
 		// node may have started controlling a new wallet in the meantime.
 		walletsPublicKeys := n.walletRegistry.getWalletsPublicKeys()
 
 		for _, currentWalletPublicKey := range walletsPublicKeys {
+			procedureDone, ok := n.shutdownCoordinator.accept(
+				fmt.Sprintf(
+					"coordination procedure of wallet [0x%x] "+
+						"for window at block [%v]",
+					bitcoin.PublicKeyHash(currentWalletPublicKey),
+					window.coordinationBlock,
+				),
+			)
+			if !ok {
+				return
+			}
+
 			// Run an independent coordination procedure for the given wallet
 			// in a separate goroutine. The coordination result will be sent
 			// to the coordination result channel.
 			go func(walletPublicKey *ecdsa.PublicKey) {
+				defer procedureDone()
+
 				result, ok := cls.executeCoordinationProcedureFn(
 					n,
 					window,
 					walletPublicKey,
 				)
 				if ok {
-					coordinationResultChan <- result
+					// Track the result processing before the procedure
+					// is marked as done so that there is no gap in the
+					// tracked work.
+					coordinationResultChan <- &pendingCoordinationResult{
+						result: result,
+						done: n.shutdownCoordinator.track(
+							fmt.Sprintf(
+								"processing of coordination result of "+
+									"wallet [0x%x] for window at block [%v]",
+								bitcoin.PublicKeyHash(walletPublicKey),
+								window.coordinationBlock,
+							),
+						),
+					}
 				}
 			}(currentWalletPublicKey)
 		}
@@ -967,8 +1141,11 @@ func (n *node) runCoordinationLayer(
 	go func() {
 		for {
 			select {
-			case result := <-coordinationResultChan:
-				go cls.processCoordinationResultFn(n, result)
+			case pending := <-coordinationResultChan:
+				go func() {
+					defer pending.done()
+					cls.processCoordinationResultFn(n, pending.result)
+				}()
 			case <-ctx.Done():
 				return
 			}
@@ -1015,6 +1192,20 @@ func executeCoordinationProcedure(
 	result, err := executor.coordinate(window)
 	if err != nil {
 		procedureLogger.Errorf("coordination procedure failed: [%v]", err)
+
+		var faults []*coordinationFault
+		var failedErr *coordinationFailedError
+		if errors.As(err, &failedErr) {
+			faults = failedErr.faults
+		}
+
+		node.coordinationJournal.recordFailedWindow(
+			executor.coordinatedWallet,
+			window,
+			err,
+			faults,
+		)
+
 		return nil, false
 	}
 
@@ -1030,8 +1221,8 @@ func executeCoordinationProcedure(
 func processCoordinationResult(node *node, result *coordinationResult) {
 	logger.Infof("processing coordination result [%s]", result)
 
-	// TODO: In the future, create coordination faults cache and
-	//       record faults from the processed results there.
+	node.coordinationFaults.record(result)
+	node.coordinationJournal.recordWindow(result)
 
 	proposedAction := result.proposal.ActionType()
 
@@ -1043,55 +1234,67 @@ func processCoordinationResult(node *node, result *coordinationResult) {
 
 	startBlock := result.window.endBlock()
 	expiryBlock := startBlock + result.proposal.ValidityBlocks()
+	outcomeFn := node.coordinationJournal.outcomeFn(result)
 
+	var err error
 	switch proposedAction {
 	case ActionHeartbeat:
 		if proposal, ok := result.proposal.(*HeartbeatProposal); ok {
-			node.handleHeartbeatProposal(
+			err = node.handleHeartbeatProposal(
 				result.wallet,
 				proposal,
 				startBlock,
 				expiryBlock,
+				outcomeFn,
 			)
 		}
 	case ActionDepositSweep:
 		if proposal, ok := result.proposal.(*DepositSweepProposal); ok {
-			node.handleDepositSweepProposal(
+			err = node.handleDepositSweepProposal(
 				result.wallet,
 				proposal,
 				startBlock,
 				expiryBlock,
+				outcomeFn,
 			)
 		}
 	case ActionRedemption:
 		if proposal, ok := result.proposal.(*RedemptionProposal); ok {
-			node.handleRedemptionProposal(
+			err = node.handleRedemptionProposal(
 				result.wallet,
 				proposal,
 				startBlock,
 				expiryBlock,
+				outcomeFn,
 			)
 		}
 	case ActionMovingFunds:
 		if proposal, ok := result.proposal.(*MovingFundsProposal); ok {
-			node.handleMovingFundsProposal(
+			err = node.handleMovingFundsProposal(
 				result.wallet,
 				proposal,
 				startBlock,
 				expiryBlock,
+				outcomeFn,
 			)
 		}
 	case ActionMovedFundsSweep:
 		if proposal, ok := result.proposal.(*MovedFundsSweepProposal); ok {
-			node.handleMovedFundsSweepProposal(
+			err = node.handleMovedFundsSweepProposal(
 				result.wallet,
 				proposal,
 				startBlock,
 				expiryBlock,
+				outcomeFn,
 			)
 		}
 	default:
 		logger.Errorf("no handler for coordination result [%s]", result)
+		err = fmt.Errorf("no handler for action [%s]", proposedAction)
+	}
+
+	if err != nil {
+		node.coordinationJournal.recordNotDispatched(result, err)
 	}
 }
 
@@ -287,6 +287,90 @@ func TestNode_GetCoordinationExecutor(t *testing.T) {
 	}
 }
 
+func TestNode_ExecuteCoordinationProcedure_BusyExecutor(t *testing.T) {
+	groupParameters := &GroupParameters{
+		GroupSize:       5,
+		GroupQuorum:     4,
+		HonestThreshold: 3,
+	}
+
+	localChain := Connect()
+	localProvider := local.Connect()
+
+	signer := createMockSigner(t)
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signer.wallet.publicKey)
+	walletID, err := localChain.CalculateWalletID(signer.wallet.publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localChain.setWallet(
+		walletPublicKeyHash,
+		&WalletChainData{
+			EcdsaWalletID: walletID,
+			State:         StateLive,
+		},
+	)
+
+	keyStorePersistence := createMockKeyStorePersistence(t, signer)
+
+	node, err := newNode(
+		groupParameters,
+		localChain,
+		newLocalBitcoinChain(),
+		localProvider,
+		keyStorePersistence,
+		&mockPersistenceHandle{},
+		generator.StartScheduler(),
+		&mockCoordinationProposalGenerator{},
+		Config{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executor, ok, err := node.getCoordinationExecutor(signer.wallet.publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("node is supposed to control wallet signers")
+	}
+
+	// Simulate an ongoing coordination.
+	if !executor.lock.TryAcquire(1) {
+		t.Fatal("cannot acquire the executor lock")
+	}
+	defer executor.lock.Release(1)
+
+	window := newCoordinationWindow(900)
+
+	_, ok = executeCoordinationProcedure(node, window, signer.wallet.publicKey)
+	if ok {
+		t.Fatal("coordination procedure is not supposed to succeed")
+	}
+
+	// The failed window should be journaled.
+	entries := node.coordinationJournal.entries[hex.EncodeToString(
+		walletPublicKeyHash[:],
+	)]
+
+	testutils.AssertIntsEqual(t, "journal entries count", 1, len(entries))
+	testutils.AssertUintsEqual(
+		t,
+		"coordination block",
+		window.coordinationBlock,
+		entries[0].CoordinationBlock,
+	)
+	testutils.AssertStringsEqual(
+		t,
+		"error",
+		errCoordinationExecutorBusy.Error(),
+		entries[0].Error,
+	)
+}
+
 func TestNode_RunCoordinationLayer(t *testing.T) {
 	groupParameters := &GroupParameters{
 		GroupSize:       5,
@@ -138,7 +138,15 @@ func newRedemptionAction(
 		waitForBlockFn,
 	)
 
+	// The processing start block follows the coordination window the
+	// proposal was made in so it is beyond the coordination upgrade block
+	// only for the upgraded windows.
 	feeDistribution := withRedemptionTotalFee(proposal.RedemptionTxFee.Int64())
+	if proposalProcessingStartBlock >= coordinationUpgradeBlock {
+		feeDistribution = withRedemptionProportionalFee(
+			proposal.RedemptionTxFee.Int64(),
+		)
+	}
 
 	return &redemptionAction{
 		logger:                           logger,
@@ -342,6 +350,8 @@ type redemptionFeeDistributionFn func([]*RedemptionRequest) []int64
 // withRedemptionTotalFee is a fee distribution function that takes a
 // total transaction fee and distributes it evenly over all redemption requests.
 // If the fee cannot be divided evenly, the last request incurs the remainder.
+// Each signer assembles the transaction on its own from the proposal carrying
+// only the total fee so all signers must use the same distribution.
 func withRedemptionTotalFee(totalFee int64) redemptionFeeDistributionFn {
 	return func(requests []*RedemptionRequest) []int64 {
 		requestsCount := int64(len(requests))
@@ -363,6 +373,58 @@ func withRedemptionTotalFee(totalFee int64) redemptionFeeDistributionFn {
 	}
 }
 
+// withRedemptionProportionalFee is a fee distribution function that takes
+// a total transaction fee and distributes it over all redemption requests in
+// proportion to their maximum fees, so requests with higher maximum fees pay
+// more. The shares are rounded down and the remainder is distributed by one
+// satoshi over the requests, in order, whose share is below their maximum
+// fee. If the total fee exceeds the sum of the maximum fees, the last request
+// incurs what cannot be distributed. If all the maximum fees are zero, the
+// fee is distributed evenly.
+func withRedemptionProportionalFee(totalFee int64) redemptionFeeDistributionFn {
+	return func(requests []*RedemptionRequest) []int64 {
+		txMaxFeesSum := new(big.Int)
+		for _, request := range requests {
+			txMaxFeesSum.Add(
+				txMaxFeesSum,
+				new(big.Int).SetUint64(request.TxMaxFee),
+			)
+		}
+
+		if txMaxFeesSum.Sign() == 0 {
+			return withRedemptionTotalFee(totalFee)(requests)
+		}
+
+		feeShares := make([]int64, len(requests))
+		remainder := totalFee
+		for i, request := range requests {
+			feeShare := new(big.Int).Mul(
+				big.NewInt(totalFee),
+				new(big.Int).SetUint64(request.TxMaxFee),
+			)
+			feeShare.Div(feeShare, txMaxFeesSum)
+
+			feeShares[i] = feeShare.Int64()
+			remainder -= feeShares[i]
+		}
+
+		for i, request := range requests {
+			if remainder == 0 {
+				break
+			}
+
+			if uint64(feeShares[i]) < request.TxMaxFee {
+				feeShares[i]++
+				remainder--
+			}
+		}
+
+		feeShares[len(feeShares)-1] += remainder
+
+		return feeShares
+	}
+}
+
 // assembleRedemptionTransaction constructs an unsigned redemption Bitcoin
 // transaction.
 //
@@ -372,6 +434,12 @@ func withRedemptionTotalFee(totalFee int64) redemptionFeeDistributionFn {
 // respect to the system limitations. The shape argument is optional - if not
 // provided the RedemptionChangeFirst value is used by default.
 //
+// The main UTXO is the only input of the transaction. The Bridge accepts
+// redemption proofs only for single-input transactions, so deposits MUST NOT
+// be swept by the redemption transaction. A transaction spending the main
+// UTXO that cannot be proven leaves the main UTXO unmarked as spent and the
+// wallet unable to defeat a fraud challenge of its signature.
+//
 // The resulting bitcoin.TransactionBuilder instance holds all the data
 // necessary to sign the transaction and obtain a bitcoin.Transaction instance
 // ready to be spread across the Bitcoin network.
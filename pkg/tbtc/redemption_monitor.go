@@ -0,0 +1,533 @@
+package tbtc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+)
+
+const (
+	// DefaultRedemptionTimeoutWarningThreshold is the default percentage of
+	// the redemption timeout that must elapse since the creation of a pending
+	// redemption request for the redemption monitor to raise a warning.
+	DefaultRedemptionTimeoutWarningThreshold = 50
+	// DefaultRedemptionTimeoutCriticalThreshold is the default percentage of
+	// the redemption timeout that must elapse since the creation of a pending
+	// redemption request for the redemption monitor to raise a critical
+	// alert.
+	DefaultRedemptionTimeoutCriticalThreshold = 75
+
+	// redemptionMonitorTick is the frequency of the redemption monitor checks.
+	redemptionMonitorTick = 10 * time.Minute
+	// redemptionMonitorLookbackMarginBlocks is the number of blocks the
+	// redemption requested events lookup is extended by, in addition to the
+	// redemption timeout, to not miss requests because of an imprecise
+	// block-by-timestamp estimation.
+	redemptionMonitorLookbackMarginBlocks = 1000
+)
+
+// RedemptionTimeoutAlertLevel is the level of an alert raised by the
+// redemption monitor for a pending redemption request nearing its timeout.
+type RedemptionTimeoutAlertLevel string
+
+const (
+	// RedemptionTimeoutWarning is the level of the alert raised once the
+	// elapsed part of the redemption timeout reaches the warning threshold.
+	RedemptionTimeoutWarning RedemptionTimeoutAlertLevel = "warning"
+	// RedemptionTimeoutCritical is the level of the alert raised once the
+	// elapsed part of the redemption timeout reaches the critical threshold.
+	RedemptionTimeoutCritical RedemptionTimeoutAlertLevel = "critical"
+)
+
+// severity returns the severity of the alert level used to detect raised
+// alerts; the higher the more severe.
+func (rtal RedemptionTimeoutAlertLevel) severity() int {
+	switch rtal {
+	case RedemptionTimeoutWarning:
+		return 1
+	case RedemptionTimeoutCritical:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// RedemptionTimeoutAlert describes a pending redemption request nearing its
+// timeout.
+type RedemptionTimeoutAlert struct {
+	RedeemerOutputScript string    `json:"redeemer_output_script"`
+	RequestedAmount      uint64    `json:"requested_amount"`
+	RequestedAt          time.Time `json:"requested_at"`
+	TimesOutAt           time.Time `json:"times_out_at"`
+	// ElapsedTimeoutPercent is the percentage of the redemption timeout
+	// elapsed since the request creation. It exceeds 100 once the request
+	// timed out and can be reported as such by anyone.
+	ElapsedTimeoutPercent uint64                      `json:"elapsed_timeout_percent"`
+	Level                 RedemptionTimeoutAlertLevel `json:"level"`
+}
+
+// WalletRedemptionsHealth describes the ability of a wallet to process its
+// pending redemption requests.
+type WalletRedemptionsHealth struct {
+	// PendingRedemptionsValue is the total value, in satoshi, of the wallet's
+	// pending redemption requests, as recorded by the Bridge.
+	PendingRedemptionsValue uint64 `json:"pending_redemptions_value"`
+	// MainUtxoValue is the value, in satoshi, of the wallet's main UTXO.
+	MainUtxoValue int64 `json:"main_utxo_value"`
+	EnoughBtc     bool  `json:"enough_btc"`
+	// LastHeartbeat is the result of the last heartbeat executed by the node
+	// since its start.
+	LastHeartbeat                *HeartbeatResult `json:"last_heartbeat,omitempty"`
+	ConsecutiveHeartbeatFailures uint             `json:"consecutive_heartbeat_failures"`
+	// RequiredActiveMembers is the number of active wallet members needed
+	// to sign the redemption transaction.
+	RequiredActiveMembers int `json:"required_active_members"`
+	// EnoughActiveMembers is true if the last heartbeat had at least
+	// RequiredActiveMembers active members. It is false if the node executed
+	// no heartbeat of the wallet since its start.
+	EnoughActiveMembers bool   `json:"enough_active_members"`
+	Error               string `json:"error,omitempty"`
+}
+
+// WalletRedemptionsStatus describes the pending redemption requests of
+// a wallet controlled by the node, as of the last redemption monitor check.
+type WalletRedemptionsStatus struct {
+	WalletPublicKeyHash string                    `json:"wallet_public_key_hash"`
+	CheckedAt           time.Time                 `json:"checked_at"`
+	PendingRequests     int                       `json:"pending_requests"`
+	Alerts              []*RedemptionTimeoutAlert `json:"alerts"`
+	// Health is determined only if the wallet has pending requests nearing
+	// their timeout.
+	Health *WalletRedemptionsHealth `json:"health,omitempty"`
+	Error  string                   `json:"error,omitempty"`
+}
+
+// redemptionMonitor periodically checks the pending redemption requests of
+// the wallets controlled by the node against the redemption timeout. A wallet
+// that does not process a request before the timeout gets its operators
+// slashed so the monitor raises alerts for requests nearing their timeout,
+// along with the information whether the wallet is able to process them.
+// Alerts are logged only once they are raised or escalated and are exposed
+// through metrics and diagnostics.
+type redemptionMonitor struct {
+	chain                   Chain
+	btcChain                bitcoin.Chain
+	walletRegistry          *walletRegistry
+	heartbeatFailureCounter *heartbeatFailureCounter
+	requiredActiveMembers   int
+
+	// warningThreshold and criticalThreshold are the percentages of the
+	// redemption timeout at which the alerts are raised. A warningThreshold
+	// of 0 disables the monitor.
+	warningThreshold  uint64
+	criticalThreshold uint64
+
+	mutex    sync.Mutex
+	statuses []*WalletRedemptionsStatus
+	// alertLevels holds the alert levels of the pending requests as of the
+	// last check. The key is the wallet public key hash followed by the
+	// redeemer output script, both hex-encoded.
+	alertLevels map[string]RedemptionTimeoutAlertLevel
+}
+
+func newRedemptionMonitor(
+	chain Chain,
+	btcChain bitcoin.Chain,
+	walletRegistry *walletRegistry,
+	heartbeatFailureCounter *heartbeatFailureCounter,
+	requiredActiveMembers int,
+	warningThreshold int,
+	criticalThreshold int,
+) (*redemptionMonitor, error) {
+	if warningThreshold < 0 ||
+		(warningThreshold > 0 &&
+			(warningThreshold >= criticalThreshold || criticalThreshold > 100)) {
+		return nil, fmt.Errorf(
+			"invalid redemption timeout thresholds; warning [%d] and "+
+				"critical [%d] must satisfy 0 < warning < critical <= 100 "+
+				"or warning must be 0 to disable the monitor",
+			warningThreshold,
+			criticalThreshold,
+		)
+	}
+
+	return &redemptionMonitor{
+		chain:                   chain,
+		btcChain:                btcChain,
+		walletRegistry:          walletRegistry,
+		heartbeatFailureCounter: heartbeatFailureCounter,
+		requiredActiveMembers:   requiredActiveMembers,
+		warningThreshold:        uint64(warningThreshold),
+		criticalThreshold:       uint64(criticalThreshold),
+		statuses:                make([]*WalletRedemptionsStatus, 0),
+		alertLevels:             make(map[string]RedemptionTimeoutAlertLevel),
+	}, nil
+}
+
+func (rm *redemptionMonitor) isEnabled() bool {
+	return rm.warningThreshold > 0
+}
+
+// run executes the redemption monitor checks until the context is done.
+func (rm *redemptionMonitor) run(ctx context.Context) {
+	if !rm.isEnabled() {
+		logger.Infof("redemption monitor is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(redemptionMonitorTick)
+	defer ticker.Stop()
+
+	for {
+		rm.check(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check checks the pending redemption requests of all wallets controlled by
+// the node as of the given time.
+func (rm *redemptionMonitor) check(now time.Time) {
+	walletsPublicKeys := rm.walletRegistry.getWalletsPublicKeys()
+
+	statuses := make([]*WalletRedemptionsStatus, 0, len(walletsPublicKeys))
+	alertLevels := make(map[string]RedemptionTimeoutAlertLevel)
+
+	if len(walletsPublicKeys) > 0 {
+		requestTimeout, startBlock, err := rm.lookback(now)
+		if err != nil {
+			logger.Errorf("cannot check pending redemption requests: [%v]", err)
+			return
+		}
+
+		for _, walletPublicKey := range walletsPublicKeys {
+			status := rm.checkWallet(
+				walletPublicKey,
+				now,
+				requestTimeout,
+				startBlock,
+			)
+			statuses = append(statuses, status)
+
+			raised := false
+			for _, alert := range status.Alerts {
+				key := status.WalletPublicKeyHash + alert.RedeemerOutputScript
+				alertLevels[key] = alert.Level
+
+				if alert.Level.severity() <= rm.alertLevel(key).severity() {
+					continue
+				}
+
+				raised = true
+
+				logf := logger.Warnf
+				if alert.Level == RedemptionTimeoutCritical {
+					logf = logger.Errorf
+				}
+
+				logf(
+					"redemption request of wallet [0x%s] for redeemer "+
+						"output script [0x%s] reached [%d]%% of the "+
+						"redemption timeout; the request times out at [%s]",
+					status.WalletPublicKeyHash,
+					alert.RedeemerOutputScript,
+					alert.ElapsedTimeoutPercent,
+					alert.TimesOutAt.Format(time.RFC3339),
+				)
+			}
+
+			if raised && status.Health != nil {
+				logger.Warnf(
+					"wallet [0x%s] redemptions health; pending redemptions "+
+						"value: [%d], main UTXO value: [%d], enough BTC: [%v], "+
+						"enough active members: [%v], consecutive heartbeat "+
+						"failures: [%d]",
+					status.WalletPublicKeyHash,
+					status.Health.PendingRedemptionsValue,
+					status.Health.MainUtxoValue,
+					status.Health.EnoughBtc,
+					status.Health.EnoughActiveMembers,
+					status.Health.ConsecutiveHeartbeatFailures,
+				)
+			}
+		}
+	}
+
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	rm.statuses = statuses
+	rm.alertLevels = alertLevels
+}
+
+// lookback returns the redemption timeout and the block from which
+// the redemption requested events must be fetched to find all requests
+// that did not time out as of the given time.
+func (rm *redemptionMonitor) lookback(
+	now time.Time,
+) (time.Duration, uint64, error) {
+	_, _, _, _, timeout, _, _, err := rm.chain.GetRedemptionParameters()
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot get redemption timeout: [%v]", err)
+	}
+
+	if timeout == 0 {
+		return 0, 0, fmt.Errorf("redemption timeout is zero")
+	}
+
+	requestTimeout := time.Duration(timeout) * time.Second
+
+	startTimestamp := now.Add(-requestTimeout).Unix()
+	if startTimestamp < 0 {
+		startTimestamp = 0
+	}
+
+	startBlock, err := rm.chain.GetBlockNumberByTimestamp(uint64(startTimestamp))
+	if err != nil {
+		return 0, 0, fmt.Errorf(
+			"cannot get block number for timestamp [%d]: [%v]",
+			startTimestamp,
+			err,
+		)
+	}
+
+	if startBlock > redemptionMonitorLookbackMarginBlocks {
+		startBlock -= redemptionMonitorLookbackMarginBlocks
+	} else {
+		startBlock = 0
+	}
+
+	return requestTimeout, startBlock, nil
+}
+
+// checkWallet determines the status of the pending redemption requests of
+// the given wallet as of the given time.
+func (rm *redemptionMonitor) checkWallet(
+	walletPublicKey *ecdsa.PublicKey,
+	now time.Time,
+	requestTimeout time.Duration,
+	startBlock uint64,
+) *WalletRedemptionsStatus {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+	status := &WalletRedemptionsStatus{
+		WalletPublicKeyHash: hex.EncodeToString(walletPublicKeyHash[:]),
+		CheckedAt:           now,
+		Alerts:              make([]*RedemptionTimeoutAlert, 0),
+	}
+
+	requests, err := rm.pendingRequests(walletPublicKeyHash, startBlock)
+	if err != nil {
+		logger.Errorf(
+			"cannot get pending redemption requests of wallet [0x%x]: [%v]",
+			walletPublicKeyHash,
+			err,
+		)
+		status.Error = err.Error()
+		return status
+	}
+
+	status.PendingRequests = len(requests)
+
+	for _, request := range requests {
+		var elapsedTimeoutPercent uint64
+		if elapsed := now.Sub(request.RequestedAt); elapsed > 0 {
+			elapsedTimeoutPercent = uint64(elapsed * 100 / requestTimeout)
+		}
+
+		var level RedemptionTimeoutAlertLevel
+		switch {
+		case elapsedTimeoutPercent >= rm.criticalThreshold:
+			level = RedemptionTimeoutCritical
+		case elapsedTimeoutPercent >= rm.warningThreshold:
+			level = RedemptionTimeoutWarning
+		default:
+			continue
+		}
+
+		status.Alerts = append(status.Alerts, &RedemptionTimeoutAlert{
+			RedeemerOutputScript: hex.EncodeToString(
+				request.RedeemerOutputScript,
+			),
+			RequestedAmount:       request.RequestedAmount,
+			RequestedAt:           request.RequestedAt,
+			TimesOutAt:            request.RequestedAt.Add(requestTimeout),
+			ElapsedTimeoutPercent: elapsedTimeoutPercent,
+			Level:                 level,
+		})
+	}
+
+	sort.SliceStable(status.Alerts, func(i, j int) bool {
+		return status.Alerts[i].RequestedAt.Before(status.Alerts[j].RequestedAt)
+	})
+
+	if len(status.Alerts) > 0 {
+		status.Health = rm.walletHealth(walletPublicKey)
+	}
+
+	return status
+}
+
+// pendingRequests returns the pending redemption requests of the given
+// wallet created since the given block.
+func (rm *redemptionMonitor) pendingRequests(
+	walletPublicKeyHash [20]byte,
+	startBlock uint64,
+) ([]*RedemptionRequest, error) {
+	events, err := rm.chain.PastRedemptionRequestedEvents(
+		&RedemptionRequestedEventFilter{
+			StartBlock:          startBlock,
+			WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get past redemption requested events: [%v]",
+			err,
+		)
+	}
+
+	requests := make([]*RedemptionRequest, 0)
+	// The same redeemer output script can be used by many requests over
+	// time but only one of them can be pending at once.
+	seen := make(map[string]bool)
+	for _, event := range events {
+		scriptKey := hex.EncodeToString(event.RedeemerOutputScript)
+		if seen[scriptKey] {
+			continue
+		}
+		seen[scriptKey] = true
+
+		request, found, err := rm.chain.GetPendingRedemptionRequest(
+			walletPublicKeyHash,
+			event.RedeemerOutputScript,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get pending redemption request for redeemer "+
+					"output script [0x%s]: [%v]",
+				scriptKey,
+				err,
+			)
+		}
+
+		if !found {
+			continue
+		}
+
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+// walletHealth determines the ability of the given wallet to process its
+// pending redemption requests.
+func (rm *redemptionMonitor) walletHealth(
+	walletPublicKey *ecdsa.PublicKey,
+) *WalletRedemptionsHealth {
+	walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+	health := &WalletRedemptionsHealth{
+		RequiredActiveMembers: rm.requiredActiveMembers,
+	}
+
+	walletPublicKeyBytes, err := marshalPublicKey(walletPublicKey)
+	if err != nil {
+		health.Error = fmt.Sprintf("cannot marshal wallet public key: [%v]", err)
+		return health
+	}
+
+	walletKey := hex.EncodeToString(walletPublicKeyBytes)
+
+	health.LastHeartbeat = rm.heartbeatFailureCounter.lastResult(walletKey)
+	health.ConsecutiveHeartbeatFailures = rm.heartbeatFailureCounter.get(walletKey)
+	health.EnoughActiveMembers = health.LastHeartbeat != nil &&
+		health.LastHeartbeat.ActiveMembers >= rm.requiredActiveMembers
+
+	walletChainData, err := rm.chain.GetWallet(walletPublicKeyHash)
+	if err != nil {
+		health.Error = fmt.Sprintf(
+			"cannot get on-chain data of wallet: [%v]",
+			err,
+		)
+		return health
+	}
+
+	health.PendingRedemptionsValue = walletChainData.PendingRedemptionsValue
+
+	mainUtxo, err := DetermineWalletMainUtxo(
+		walletPublicKeyHash,
+		rm.chain,
+		rm.btcChain,
+	)
+	if err != nil {
+		health.Error = fmt.Sprintf("cannot determine wallet main UTXO: [%v]", err)
+		return health
+	}
+
+	// A nil main UTXO means the wallet holds no BTC.
+	if mainUtxo != nil {
+		health.MainUtxoValue = mainUtxo.Value
+	}
+
+	health.EnoughBtc = health.MainUtxoValue >= 0 &&
+		uint64(health.MainUtxoValue) >= health.PendingRedemptionsValue
+
+	return health
+}
+
+// alertLevel returns the alert level of the pending request with the given
+// key as of the last check.
+func (rm *redemptionMonitor) alertLevel(key string) RedemptionTimeoutAlertLevel {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	return rm.alertLevels[key]
+}
+
+// alertsCount returns the number of pending requests with the given alert
+// level as of the last check.
+func (rm *redemptionMonitor) alertsCount(
+	level RedemptionTimeoutAlertLevel,
+) int {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	count := 0
+	for _, alertLevel := range rm.alertLevels {
+		if alertLevel == level {
+			count++
+		}
+	}
+
+	return count
+}
+
+// diagnostics returns the status of the pending redemption requests of the
+// wallets controlled by the node, as of the last check.
+func (rm *redemptionMonitor) diagnostics() clientinfo.ApplicationInfo {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+
+	statuses := make([]*WalletRedemptionsStatus, len(rm.statuses))
+	copy(statuses, rm.statuses)
+
+	return clientinfo.ApplicationInfo{
+		"enabled":            rm.isEnabled(),
+		"warning_threshold":  rm.warningThreshold,
+		"critical_threshold": rm.criticalThreshold,
+		"wallets":            statuses,
+	}
+}
@@ -0,0 +1,282 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+func TestNewRedemptionMonitor_Thresholds(t *testing.T) {
+	tests := map[string]struct {
+		warningThreshold  int
+		criticalThreshold int
+		expectedEnabled   bool
+		expectedError     bool
+	}{
+		"default thresholds": {
+			warningThreshold:  DefaultRedemptionTimeoutWarningThreshold,
+			criticalThreshold: DefaultRedemptionTimeoutCriticalThreshold,
+			expectedEnabled:   true,
+		},
+		"disabled": {
+			warningThreshold:  0,
+			criticalThreshold: 0,
+			expectedEnabled:   false,
+		},
+		"warning not lower than critical": {
+			warningThreshold:  75,
+			criticalThreshold: 75,
+			expectedError:     true,
+		},
+		"critical above 100": {
+			warningThreshold:  50,
+			criticalThreshold: 101,
+			expectedError:     true,
+		},
+		"negative warning": {
+			warningThreshold:  -1,
+			criticalThreshold: 75,
+			expectedError:     true,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			monitor, err := newRedemptionMonitor(
+				Connect(),
+				newLocalBitcoinChain(),
+				nil,
+				newHeartbeatFailureCounter(),
+				51,
+				test.warningThreshold,
+				test.criticalThreshold,
+			)
+
+			if test.expectedError {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"enabled",
+				test.expectedEnabled,
+				monitor.isEnabled(),
+			)
+		})
+	}
+}
+
+func TestRedemptionMonitor_Check(t *testing.T) {
+	now := time.Now()
+	// 100 hours timeout makes the elapsed hours the elapsed percentage.
+	requestTimeout := 100 * time.Hour
+
+	localChain := Connect()
+	localChain.setRedemptionTimeout(uint32(requestTimeout.Seconds()))
+	localChain.setBlockNumberByTimestamp(
+		uint64(now.Add(-requestTimeout).Unix()),
+		5000,
+	)
+
+	signer := createMockSigner(t)
+	walletRegistry, err := newWalletRegistry(
+		&mockPersistenceHandle{},
+		localChain.CalculateWalletID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = walletRegistry.registerSigner(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walletPublicKeyHash := bitcoin.PublicKeyHash(signer.wallet.publicKey)
+
+	localChain.setWallet(walletPublicKeyHash, &WalletChainData{
+		PendingRedemptionsValue: 30000,
+	})
+
+	addRequest := func(id byte, age time.Duration, blockNumber uint64) {
+		script := bitcoin.Script{id}
+
+		localChain.addPastRedemptionRequestedEvent(&RedemptionRequestedEvent{
+			WalletPublicKeyHash:  walletPublicKeyHash,
+			RedeemerOutputScript: script,
+			BlockNumber:          blockNumber,
+		})
+		localChain.setPendingRedemptionRequest(
+			walletPublicKeyHash,
+			&RedemptionRequest{
+				RedeemerOutputScript: script,
+				RequestedAmount:      10000,
+				RequestedAt:          now.Add(-age),
+			},
+		)
+	}
+
+	// Recent request with no alert.
+	addRequest(0x01, 10*time.Hour, 9000)
+	// Request past the warning threshold.
+	addRequest(0x02, 60*time.Hour, 7000)
+	// Request past the critical threshold, within the lookback margin.
+	addRequest(0x03, 80*time.Hour, 4500)
+	// Request processed already.
+	localChain.addPastRedemptionRequestedEvent(&RedemptionRequestedEvent{
+		WalletPublicKeyHash:  walletPublicKeyHash,
+		RedeemerOutputScript: bitcoin.Script{0x04},
+		BlockNumber:          6000,
+	})
+	// Request before the lookback start block.
+	addRequest(0x05, 200*time.Hour, 1000)
+
+	heartbeatFailureCounter := newHeartbeatFailureCounter()
+
+	walletPublicKeyBytes, err := marshalPublicKey(signer.wallet.publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	heartbeatFailureCounter.recordResult(
+		hex.EncodeToString(walletPublicKeyBytes),
+		&HeartbeatResult{
+			StartBlock:    8000,
+			Outcome:       HeartbeatSucceeded,
+			ActiveMembers: 80,
+		},
+	)
+
+	monitor, err := newRedemptionMonitor(
+		localChain,
+		newLocalBitcoinChain(),
+		walletRegistry,
+		heartbeatFailureCounter,
+		51,
+		DefaultRedemptionTimeoutWarningThreshold,
+		DefaultRedemptionTimeoutCriticalThreshold,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monitor.check(now)
+
+	testutils.AssertIntsEqual(
+		t,
+		"warning alerts",
+		1,
+		monitor.alertsCount(RedemptionTimeoutWarning),
+	)
+	testutils.AssertIntsEqual(
+		t,
+		"critical alerts",
+		1,
+		monitor.alertsCount(RedemptionTimeoutCritical),
+	)
+
+	statuses := monitor.diagnostics()["wallets"].([]*WalletRedemptionsStatus)
+	testutils.AssertIntsEqual(t, "wallets", 1, len(statuses))
+
+	status := statuses[0]
+	testutils.AssertStringsEqual(t, "error", "", status.Error)
+	testutils.AssertIntsEqual(t, "pending requests", 3, status.PendingRequests)
+
+	var actualAlerts []string
+	for _, alert := range status.Alerts {
+		actualAlerts = append(
+			actualAlerts,
+			fmt.Sprintf(
+				"%s:%d:%s",
+				alert.RedeemerOutputScript,
+				alert.ElapsedTimeoutPercent,
+				alert.Level,
+			),
+		)
+	}
+	expectedAlerts := []string{"03:80:critical", "02:60:warning"}
+	if !reflect.DeepEqual(expectedAlerts, actualAlerts) {
+		t.Errorf(
+			"unexpected alerts\nexpected: %v\nactual:   %v",
+			expectedAlerts,
+			actualAlerts,
+		)
+	}
+
+	expectedHealth := &WalletRedemptionsHealth{
+		PendingRedemptionsValue: 30000,
+		MainUtxoValue:           0,
+		EnoughBtc:               false,
+		LastHeartbeat: &HeartbeatResult{
+			StartBlock:    8000,
+			Outcome:       HeartbeatSucceeded,
+			ActiveMembers: 80,
+		},
+		RequiredActiveMembers: 51,
+		EnoughActiveMembers:   true,
+	}
+	if !reflect.DeepEqual(expectedHealth, status.Health) {
+		t.Errorf(
+			"unexpected health\nexpected: %+v\nactual:   %+v",
+			expectedHealth,
+			status.Health,
+		)
+	}
+
+	// Once the request is processed, its alert is cleared.
+	localChain.pendingRedemptionRequestsMutex.Lock()
+	delete(
+		localChain.pendingRedemptionRequests,
+		buildRedemptionRequestKey(walletPublicKeyHash, bitcoin.Script{0x03}),
+	)
+	localChain.pendingRedemptionRequestsMutex.Unlock()
+
+	monitor.check(now)
+
+	testutils.AssertIntsEqual(
+		t,
+		"critical alerts after processing",
+		0,
+		monitor.alertsCount(RedemptionTimeoutCritical),
+	)
+}
+
+func TestRedemptionMonitor_Check_NoWallets(t *testing.T) {
+	walletRegistry, err := newWalletRegistry(
+		&mockPersistenceHandle{},
+		Connect().CalculateWalletID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The chain has no redemption timeout set; it must not be queried when
+	// there are no wallets.
+	monitor, err := newRedemptionMonitor(
+		Connect(),
+		newLocalBitcoinChain(),
+		walletRegistry,
+		newHeartbeatFailureCounter(),
+		51,
+		DefaultRedemptionTimeoutWarningThreshold,
+		DefaultRedemptionTimeoutCriticalThreshold,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monitor.check(time.Now())
+
+	statuses := monitor.diagnostics()["wallets"].([]*WalletRedemptionsStatus)
+	testutils.AssertIntsEqual(t, "wallets", 0, len(statuses))
+}
@@ -305,3 +305,111 @@ func TestWithRedemptionTotalFee(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRedemptionAction_FeeDistribution(t *testing.T) {
+	defer func(upgradeBlock uint64) {
+		coordinationUpgradeBlock = upgradeBlock
+	}(coordinationUpgradeBlock)
+	coordinationUpgradeBlock = 1800
+
+	requests := []*RedemptionRequest{
+		{TxMaxFee: 1000},
+		{TxMaxFee: 3000},
+	}
+
+	var tests = map[string]struct {
+		proposalProcessingStartBlock uint64
+		expectedFeeShares            []int64
+	}{
+		"proposal made before the upgrade": {
+			proposalProcessingStartBlock: 1000,
+			expectedFeeShares:            []int64{2000, 2000},
+		},
+		"proposal made after the upgrade": {
+			proposalProcessingStartBlock: 1900,
+			expectedFeeShares:            []int64{1000, 3000},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			action := newRedemptionAction(
+				logger.With(),
+				nil,
+				nil,
+				wallet{},
+				nil,
+				&RedemptionProposal{RedemptionTxFee: big.NewInt(4000)},
+				test.proposalProcessingStartBlock,
+				test.proposalProcessingStartBlock+600,
+				nil,
+			)
+
+			feeShares := action.feeDistribution(requests)
+
+			if diff := deep.Equal(test.expectedFeeShares, feeShares); diff != nil {
+				t.Errorf(
+					"unexpected fee shares\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedFeeShares,
+					feeShares,
+				)
+			}
+		})
+	}
+}
+
+func TestWithRedemptionProportionalFee(t *testing.T) {
+	var tests = map[string]struct {
+		totalFee          int64
+		txMaxFees         []uint64
+		expectedFeeShares []int64
+	}{
+		"total fee divisible in proportion to the max fees": {
+			totalFee:          6000,
+			txMaxFees:         []uint64{1000, 2000, 3000},
+			expectedFeeShares: []int64{1000, 2000, 3000},
+		},
+		"remainder distributed over requests below their max fees": {
+			totalFee:  1000,
+			txMaxFees: []uint64{1000, 1000, 1000},
+			// Each share is 333 and the remainder of 1 goes to the first
+			// request.
+			expectedFeeShares: []int64{334, 333, 333},
+		},
+		"total fee exceeding the max fees": {
+			totalFee:  7000,
+			txMaxFees: []uint64{1000, 2000, 3000},
+			// All the shares exceed the max fees so the last request incurs
+			// the remainder.
+			expectedFeeShares: []int64{1166, 2333, 3501},
+		},
+		"zero max fees": {
+			totalFee:          10000,
+			txMaxFees:         []uint64{0, 0, 0},
+			expectedFeeShares: []int64{3333, 3333, 3334},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			requests := make([]*RedemptionRequest, len(test.txMaxFees))
+			for i, txMaxFee := range test.txMaxFees {
+				requests[i] = &RedemptionRequest{TxMaxFee: txMaxFee}
+			}
+
+			feeShares := withRedemptionProportionalFee(test.totalFee)(requests)
+
+			if diff := deep.Equal(test.expectedFeeShares, feeShares); diff != nil {
+				t.Errorf(
+					"unexpected fee shares\n"+
+						"expected: [%v]\n"+
+						"actual:   [%v]",
+					test.expectedFeeShares,
+					feeShares,
+				)
+			}
+		})
+	}
+}
@@ -34,6 +34,10 @@ type walletRegistry struct {
 	// calculateWalletIdFunc calculates the ECDSA wallet ID based on the
 	// provided wallet public key.
 	calculateWalletIdFunc CalculateWalletIdFunc
+
+	// loadFailures is the number of signer files that could not be loaded
+	// from the wallet storage when the registry was created.
+	loadFailures int
 }
 
 type walletCacheValue struct {
@@ -56,7 +60,7 @@ func newWalletRegistry(
 
 	// Pre-populate the wallet cache using the wallet storage.
 	walletCache := make(map[string]*walletCacheValue)
-	walletSigners := walletStorage.loadSigners()
+	walletSigners, loadFailures := walletStorage.loadSigners()
 	if len(walletSigners) > 0 {
 		for walletStorageKey, signers := range walletSigners {
 			// We need to extract the wallet from the signers array. The
@@ -99,6 +103,7 @@ func newWalletRegistry(
 		walletCache:           walletCache,
 		walletStorage:         walletStorage,
 		calculateWalletIdFunc: calculateWalletIdFunc,
+		loadFailures:          loadFailures,
 	}, nil
 }
 
@@ -296,10 +301,16 @@ func (ws *walletStorage) archiveWallet(walletStorageKey string) error {
 }
 
 // loadSigners loads all signers stored using the underlying persistence layer.
-// This function should not be called from any other place than walletRegistry.
-func (ws *walletStorage) loadSigners() map[string][]*signer {
+// Signers that could not be loaded are logged and counted; the count is
+// returned along with the loaded signers. This function should not be called
+// from any other place than walletRegistry.
+func (ws *walletStorage) loadSigners() (map[string][]*signer, int) {
 	signersByWallet := make(map[string][]*signer)
 
+	// Each of the goroutines below counts its failures separately so that
+	// the counters do not need to be synchronized.
+	descriptorFailures, readFailures := 0, 0
+
 	descriptorsChan, errorsChan := ws.persistence.ReadAll()
 
 	// Two goroutines read from descriptors and errors channels and either
@@ -322,6 +333,7 @@ func (ws *walletStorage) loadSigners() map[string][]*signer {
 					descriptor.Directory(),
 					err,
 				)
+				descriptorFailures++
 				continue
 			}
 
@@ -334,6 +346,7 @@ func (ws *walletStorage) loadSigners() map[string][]*signer {
 					descriptor.Directory(),
 					err,
 				)
+				descriptorFailures++
 				continue
 			}
 
@@ -354,6 +367,7 @@ func (ws *walletStorage) loadSigners() map[string][]*signer {
 				"could not load signer from disk: [%v]",
 				err,
 			)
+			readFailures++
 		}
 
 		wg.Done()
@@ -361,7 +375,7 @@ func (ws *walletStorage) loadSigners() map[string][]*signer {
 
 	wg.Wait()
 
-	return signersByWallet
+	return signersByWallet, descriptorFailures + readFailures
 }
 
 // getWalletStorageKey compute the wallet storage key that is used to identify
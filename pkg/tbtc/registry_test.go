@@ -443,7 +443,7 @@ func TestWalletStorage_LoadSigners(t *testing.T) {
 
 	walletStorage := newWalletStorage(persistenceHandle)
 
-	signersByWallet := walletStorage.loadSigners()
+	signersByWallet, _ := walletStorage.loadSigners()
 
 	testutils.AssertIntsEqual(
 		t,
@@ -548,7 +548,15 @@ func (mph *mockPersistenceHandle) Archive(directory string) error {
 }
 
 func (mph *mockPersistenceHandle) Delete(directory string, name string) error {
-	panic("not implemented")
+	saved := make([]persistence.DataDescriptor, 0)
+	for _, descriptor := range mph.saved {
+		if descriptor.Directory() != directory || descriptor.Name() != name {
+			saved = append(saved, descriptor)
+		}
+	}
+	mph.saved = saved
+
+	return nil
 }
 
 type mockDescriptor struct {
@@ -0,0 +1,139 @@
+package tbtc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultShutdownTimeout is the default maximum time the node waits for
+// in-flight wallet actions and DKG executions to complete on shutdown.
+const DefaultShutdownTimeout = 5 * time.Minute
+
+// ShutdownFunc stops the TBTC node gracefully. The node stops accepting new
+// coordination windows and DKG executions and waits until the in-flight
+// coordination procedures, wallet actions and DKG executions complete or
+// the context is done. Returns descriptions of the work that was still in
+// progress when the context was done.
+type ShutdownFunc func(ctx context.Context) []string
+
+// shutdownCoordinator keeps track of the work in progress so that the node
+// can be stopped without interrupting it. Once the coordinator starts
+// draining, no new work is accepted but the work already accepted can still
+// register its continuations, e.g. a wallet action dispatched as a result
+// of an accepted coordination window.
+type shutdownCoordinator struct {
+	mutex    sync.Mutex
+	draining bool
+	nextID   uint64
+	inFlight map[uint64]string
+	// idle is closed and replaced every time the last work in progress
+	// completes.
+	idle chan struct{}
+}
+
+func newShutdownCoordinator() *shutdownCoordinator {
+	return &shutdownCoordinator{
+		inFlight: make(map[uint64]string),
+		idle:     make(chan struct{}),
+	}
+}
+
+// accept registers new work with the given description. Returns false if
+// the coordinator is draining and the work must not be started. Otherwise,
+// the returned function must be called once the work completes.
+func (sc *shutdownCoordinator) accept(description string) (func(), bool) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if sc.draining {
+		return nil, false
+	}
+
+	return sc.register(description), true
+}
+
+// track registers the continuation of already accepted work with the given
+// description. The work is registered even if the coordinator is draining.
+// The returned function must be called once the work completes.
+func (sc *shutdownCoordinator) track(description string) func() {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	return sc.register(description)
+}
+
+// register must be called with the mutex held.
+func (sc *shutdownCoordinator) register(description string) func() {
+	id := sc.nextID
+	sc.nextID++
+
+	sc.inFlight[id] = description
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			sc.mutex.Lock()
+			defer sc.mutex.Unlock()
+
+			delete(sc.inFlight, id)
+
+			if len(sc.inFlight) == 0 {
+				close(sc.idle)
+				sc.idle = make(chan struct{})
+			}
+		})
+	}
+}
+
+// isDraining returns true if the coordinator no longer accepts new work.
+func (sc *shutdownCoordinator) isDraining() bool {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	return sc.draining
+}
+
+// drain stops accepting new work and waits until all the work in progress
+// completes or the context is done. Returns descriptions of the work still
+// in progress, sorted, or nil if all the work completed.
+func (sc *shutdownCoordinator) drain(ctx context.Context) []string {
+	for {
+		sc.mutex.Lock()
+		sc.draining = true
+
+		if len(sc.inFlight) == 0 {
+			sc.mutex.Unlock()
+			return nil
+		}
+
+		idle := sc.idle
+		sc.mutex.Unlock()
+
+		select {
+		case <-idle:
+			// Continuations may have been registered in the meantime, so
+			// check again.
+		case <-ctx.Done():
+			return sc.inFlightDescriptions()
+		}
+	}
+}
+
+func (sc *shutdownCoordinator) inFlightDescriptions() []string {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if len(sc.inFlight) == 0 {
+		return nil
+	}
+
+	descriptions := make([]string, 0, len(sc.inFlight))
+	for _, description := range sc.inFlight {
+		descriptions = append(descriptions, description)
+	}
+	sort.Strings(descriptions)
+
+	return descriptions
+}
@@ -0,0 +1,96 @@
+package tbtc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestShutdownCoordinator_Drain(t *testing.T) {
+	sc := newShutdownCoordinator()
+
+	windowDone, ok := sc.accept("window")
+	if !ok {
+		t.Fatal("expected work to be accepted")
+	}
+
+	drained := make(chan []string, 1)
+	go func() {
+		drained <- sc.drain(context.Background())
+	}()
+
+	// Wait until the coordinator starts draining.
+	for !sc.isDraining() {
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, ok := sc.accept("another window"); ok {
+		t.Error("expected work not to be accepted while draining")
+	}
+
+	// Continuations of the accepted work are still registered while
+	// draining and the drain waits for them as well.
+	actionDone := sc.track("action")
+	windowDone()
+
+	select {
+	case interrupted := <-drained:
+		t.Fatalf("drain completed before continuation: %v", interrupted)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	actionDone()
+	// Calling the done function again has no effect.
+	actionDone()
+
+	select {
+	case interrupted := <-drained:
+		if interrupted != nil {
+			t.Errorf("unexpected interrupted work: %v", interrupted)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("drain did not complete")
+	}
+}
+
+func TestShutdownCoordinator_DrainTimeout(t *testing.T) {
+	sc := newShutdownCoordinator()
+
+	_, ok := sc.accept("window")
+	if !ok {
+		t.Fatal("expected work to be accepted")
+	}
+	sc.track("action")
+	completedDone := sc.track("completed action")
+	completedDone()
+
+	ctx, cancelCtx := context.WithTimeout(
+		context.Background(),
+		50*time.Millisecond,
+	)
+	defer cancelCtx()
+
+	interrupted := sc.drain(ctx)
+
+	expectedInterrupted := []string{"action", "window"}
+	if !reflect.DeepEqual(expectedInterrupted, interrupted) {
+		t.Errorf(
+			"unexpected interrupted work\nexpected: %v\nactual:   %v",
+			expectedInterrupted,
+			interrupted,
+		)
+	}
+}
+
+func TestShutdownCoordinator_DrainIdle(t *testing.T) {
+	sc := newShutdownCoordinator()
+
+	if interrupted := sc.drain(context.Background()); interrupted != nil {
+		t.Errorf("unexpected interrupted work: %v", interrupted)
+	}
+
+	if !sc.isDraining() {
+		t.Error("expected coordinator to be draining")
+	}
+}
@@ -0,0 +1,194 @@
+package tbtc
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/clientinfo"
+	"github.com/keep-network/keep-core/pkg/sortition"
+)
+
+const (
+	// operatorStatusRecentFaultsLimit is the maximum number of faulty
+	// operators whose coordination faults are exposed by the operator status
+	// recorder.
+	operatorStatusRecentFaultsLimit = 50
+	// operatorStatusRecentInactivityClaimsLimit is the maximum number of
+	// recent inactivity claims kept by the operator status recorder.
+	operatorStatusRecentInactivityClaimsLimit = 20
+)
+
+// CoordinationFaultRecord describes the coordination faults of a single
+// operator observed by the node in the coordination windows of a single
+// wallet.
+type CoordinationFaultRecord struct {
+	WalletPublicKeyHash string `json:"wallet_public_key_hash"`
+	Culprit             string `json:"culprit"`
+	// Faults holds the number of faults per fault type.
+	Faults                   map[string]uint `json:"faults"`
+	ConsecutiveFaultyWindows uint            `json:"consecutive_faulty_windows"`
+	// LastFaultBlock is the coordination block of the last faulty window.
+	LastFaultBlock uint64 `json:"last_fault_block"`
+}
+
+// InactivityClaimRecord describes an inactivity claim submitted for
+// a wallet the node holds signers of. The on-chain claim notification does
+// not reveal the members claimed inactive; if the claim targeted the node's
+// operator, the operator loses the eligibility for rewards.
+type InactivityClaimRecord struct {
+	WalletPublicKeyHash string `json:"wallet_public_key_hash"`
+	Notifier            string `json:"notifier"`
+	Nonce               string `json:"nonce"`
+	BlockNumber         uint64 `json:"block_number"`
+}
+
+// operatorStatusRecorder keeps the recent events relevant for the status of
+// the node's operator. Only a limited number of the most recent events is
+// kept. Coordination faults are not kept by the recorder but read from
+// the coordination faults accounting.
+type operatorStatusRecorder struct {
+	mutex              sync.Mutex
+	coordinationFaults *coordinationFaultsAccounting
+	inactivityClaims   []*InactivityClaimRecord
+}
+
+func newOperatorStatusRecorder(
+	coordinationFaults *coordinationFaultsAccounting,
+) *operatorStatusRecorder {
+	return &operatorStatusRecorder{
+		coordinationFaults: coordinationFaults,
+		inactivityClaims:   make([]*InactivityClaimRecord, 0),
+	}
+}
+
+// recordInactivityClaim records the inactivity claim submitted for the
+// wallet with the given public key hash.
+func (osr *operatorStatusRecorder) recordInactivityClaim(
+	walletPublicKeyHash [20]byte,
+	event *InactivityClaimedEvent,
+) {
+	record := &InactivityClaimRecord{
+		WalletPublicKeyHash: hex.EncodeToString(walletPublicKeyHash[:]),
+		Notifier:            event.Notifier.String(),
+		Nonce:               event.Nonce.String(),
+		BlockNumber:         event.BlockNumber,
+	}
+
+	osr.mutex.Lock()
+	defer osr.mutex.Unlock()
+
+	// The same claim may be notified more than once.
+	for _, existing := range osr.inactivityClaims {
+		if existing.WalletPublicKeyHash == record.WalletPublicKeyHash &&
+			existing.Nonce == record.Nonce {
+			return
+		}
+	}
+
+	osr.inactivityClaims = append(osr.inactivityClaims, record)
+
+	if excess := len(osr.inactivityClaims) -
+		operatorStatusRecentInactivityClaimsLimit; excess > 0 {
+		osr.inactivityClaims = osr.inactivityClaims[excess:]
+	}
+}
+
+// recentCoordinationFaults returns the accounted coordination faults of the
+// operators who were faulty most recently, oldest first.
+func (osr *operatorStatusRecorder) recentCoordinationFaults() []*CoordinationFaultRecord {
+	return osr.coordinationFaults.recentFaults(operatorStatusRecentFaultsLimit)
+}
+
+// recentInactivityClaims returns the recent inactivity claims, oldest first.
+func (osr *operatorStatusRecorder) recentInactivityClaims() []*InactivityClaimRecord {
+	osr.mutex.Lock()
+	defer osr.mutex.Unlock()
+
+	return append([]*InactivityClaimRecord{}, osr.inactivityClaims...)
+}
+
+// SortitionPoolStatus describes the status of the operator in the sortition
+// pool.
+type SortitionPoolStatus struct {
+	CheckedAt          *time.Time `json:"checked_at,omitempty"`
+	InPool             bool       `json:"in_pool"`
+	UpToDate           bool       `json:"up_to_date"`
+	EligibleForRewards bool       `json:"eligible_for_rewards"`
+	Error              string     `json:"error,omitempty"`
+}
+
+// WalletStatus describes the status of a wallet the node holds signers of.
+type WalletStatus struct {
+	WalletPublicKeyHash string `json:"wallet_public_key_hash"`
+	MembersCount        int    `json:"members_count"`
+	State               string `json:"state"`
+	// LastHeartbeat is the result of the last heartbeat executed by the node
+	// since its start.
+	LastHeartbeat                *HeartbeatResult `json:"last_heartbeat,omitempty"`
+	ConsecutiveHeartbeatFailures uint             `json:"consecutive_heartbeat_failures"`
+}
+
+// operatorStatusDiagnostics returns the status of the node's operator: the
+// sortition pool membership, the pre-parameters pool, the wallets the node
+// holds signers of, and the recent coordination faults and inactivity claims.
+func (n *node) operatorStatusDiagnostics(
+	poolMonitor *sortition.PoolMonitor,
+) clientinfo.ApplicationInfo {
+	poolStatus := &SortitionPoolStatus{}
+	if status := poolMonitor.Status(); status != nil {
+		checkedAt := status.CheckedAt
+		poolStatus.CheckedAt = &checkedAt
+		poolStatus.InPool = status.IsOperatorInPool
+		poolStatus.UpToDate = status.IsOperatorUpToDate
+		poolStatus.EligibleForRewards = status.IsEligibleForRewards
+		if status.Err != nil {
+			poolStatus.Error = status.Err.Error()
+		}
+	}
+
+	wallets := make([]*WalletStatus, 0)
+	for _, walletPublicKey := range n.walletRegistry.getWalletsPublicKeys() {
+		walletPublicKeyBytes, err := marshalPublicKey(walletPublicKey)
+		if err != nil {
+			logger.Errorf("cannot marshal wallet public key: [%v]", err)
+			continue
+		}
+
+		walletKey := hex.EncodeToString(walletPublicKeyBytes)
+		walletPublicKeyHash := bitcoin.PublicKeyHash(walletPublicKey)
+
+		walletStatus := &WalletStatus{
+			WalletPublicKeyHash: hex.EncodeToString(walletPublicKeyHash[:]),
+			MembersCount:        len(n.walletRegistry.getSigners(walletPublicKey)),
+			State:               StateUnknown.String(),
+			LastHeartbeat:       n.heartbeatFailureCounter.lastResult(walletKey),
+			ConsecutiveHeartbeatFailures: n.heartbeatFailureCounter.get(
+				walletKey,
+			),
+		}
+
+		walletChainData, err := n.chain.GetWallet(walletPublicKeyHash)
+		if err != nil {
+			logger.Errorf(
+				"cannot get on-chain data of wallet [0x%x]: [%v]",
+				walletPublicKeyHash,
+				err,
+			)
+		} else {
+			walletStatus.State = walletChainData.State.String()
+		}
+
+		wallets = append(wallets, walletStatus)
+	}
+
+	return clientinfo.ApplicationInfo{
+		"sortition_pool":             poolStatus,
+		"pre_params_count":           n.dkgExecutor.preParamsCount(),
+		"maintenance_mode":           n.maintenanceMode.isEnabled(),
+		"wallets":                    wallets,
+		"recent_coordination_faults": n.operatorStatusRecorder.recentCoordinationFaults(),
+		"recent_inactivity_claims":   n.operatorStatusRecorder.recentInactivityClaims(),
+	}
+}
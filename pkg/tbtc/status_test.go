@@ -0,0 +1,84 @@
+package tbtc
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/chain"
+)
+
+func TestOperatorStatusRecorder_CoordinationFaults(t *testing.T) {
+	accounting := newCoordinationFaultsAccounting(&mockPersistenceHandle{})
+	recorder := newOperatorStatusRecorder(accounting)
+
+	walletPublicKey := createMockSigner(t).wallet.publicKey
+
+	for i := 0; i < operatorStatusRecentFaultsLimit+1; i++ {
+		accounting.record(&coordinationResult{
+			wallet:   wallet{publicKey: walletPublicKey},
+			window:   newCoordinationWindow(uint64(i+1) * coordinationFrequencyBlocks),
+			proposal: &NoopProposal{},
+			faults: []*coordinationFault{
+				{
+					culprit:   chain.Address(fmt.Sprintf("address-%v", i)),
+					faultType: FaultLeaderIdleness,
+				},
+			},
+		})
+	}
+
+	faults := recorder.recentCoordinationFaults()
+
+	testutils.AssertIntsEqual(
+		t,
+		"faults count",
+		operatorStatusRecentFaultsLimit,
+		len(faults),
+	)
+	// The operator who was faulty least recently should be dropped.
+	testutils.AssertStringsEqual(t, "oldest culprit", "address-1", faults[0].Culprit)
+	testutils.AssertUintsEqual(
+		t,
+		"idleness faults",
+		1,
+		uint64(faults[0].Faults["LeaderIdleness"]),
+	)
+	testutils.AssertUintsEqual(
+		t,
+		"last fault block",
+		2*coordinationFrequencyBlocks,
+		faults[0].LastFaultBlock,
+	)
+}
+
+func TestOperatorStatusRecorder_InactivityClaims(t *testing.T) {
+	recorder := newOperatorStatusRecorder(
+		newCoordinationFaultsAccounting(&mockPersistenceHandle{}),
+	)
+
+	walletPublicKeyHash := [20]byte{0x01}
+
+	event := &InactivityClaimedEvent{
+		Nonce:       big.NewInt(1),
+		Notifier:    "notifier",
+		BlockNumber: 100,
+	}
+
+	recorder.recordInactivityClaim(walletPublicKeyHash, event)
+	// The duplicated notification should be ignored.
+	recorder.recordInactivityClaim(walletPublicKeyHash, event)
+
+	recorder.recordInactivityClaim(walletPublicKeyHash, &InactivityClaimedEvent{
+		Nonce:       big.NewInt(2),
+		Notifier:    "notifier",
+		BlockNumber: 200,
+	})
+
+	claims := recorder.recentInactivityClaims()
+
+	testutils.AssertIntsEqual(t, "claims count", 2, len(claims))
+	testutils.AssertStringsEqual(t, "first nonce", "1", claims[0].Nonce)
+	testutils.AssertStringsEqual(t, "second nonce", "2", claims[1].Nonce)
+}
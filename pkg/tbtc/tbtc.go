@@ -44,6 +44,15 @@ func (gp *GroupParameters) DishonestThreshold() int {
 	return gp.GroupSize - gp.HonestThreshold
 }
 
+// newGroupParameters returns the group parameters of TBTC wallets.
+func newGroupParameters() *GroupParameters {
+	return &GroupParameters{
+		GroupSize:       100,
+		GroupQuorum:     90,
+		HonestThreshold: 51,
+	}
+}
+
 const (
 	DefaultPreParamsPoolSize              = 1000
 	DefaultPreParamsGenerationTimeout     = 2 * time.Minute
@@ -65,11 +74,43 @@ type Config struct {
 	PreParamsGenerationConcurrency int
 	// Concurrency level for key-generation for tECDSA.
 	KeyGenerationConcurrency int
+	// The minimum severity of key store health issues found at startup that
+	// prevents the client from starting: `warning`, `error` or `never`.
+	KeyStoreCheckFailOn string
+	// The maximum time the node waits for in-flight work on shutdown.
+	ShutdownTimeout time.Duration
+	// Determines whether the node declines new coordination windows, DKG
+	// executions and joining the sortition pool.
+	MaintenanceMode bool
+	// The number of the most recent coordination windows of each wallet kept
+	// in the coordination journal.
+	CoordinationJournalRetention int
+	// The name of the strategy selecting deposits to sweep when the node
+	// proposes a deposit sweep: `oldest` or `feeOptimized`.
+	DepositSweepSelection string
+	// The name of the strategy selecting pending redemption requests when the
+	// node proposes a redemption: `oldest` or `feePriority`.
+	RedemptionSelection string
+	// The percentage of the redemption timeout that must elapse since the
+	// creation of a pending redemption request for the node to raise
+	// a warning. The value of 0 disables the redemption monitor.
+	RedemptionTimeoutWarningThreshold int
+	// The percentage of the redemption timeout that must elapse since the
+	// creation of a pending redemption request for the node to raise
+	// a critical alert.
+	RedemptionTimeoutCriticalThreshold int
 }
 
+// ConfigUpdater applies changes of the TBTC configuration to the running
+// node. It returns names of the Config fields whose changes were applied.
+// Changes of other fields require a client restart.
+type ConfigUpdater func(config Config) []string
+
 // Initialize kicks off the TBTC by initializing internal state, ensuring
 // preconditions like staking are met, and then kicking off the internal TBTC
-// implementation. Returns an error if this failed.
+// implementation. Returns a ConfigUpdater that can be used to update the
+// configuration of the running node, a ShutdownFunc that can be used to stop
+// the node gracefully, or an error if the initialization failed.
 func Initialize(
 	ctx context.Context,
 	chain Chain,
@@ -81,12 +122,8 @@ func Initialize(
 	proposalGenerator CoordinationProposalGenerator,
 	config Config,
 	clientInfo *clientinfo.Registry,
-) error {
-	groupParameters := &GroupParameters{
-		GroupSize:       100,
-		GroupQuorum:     90,
-		HonestThreshold: 51,
-	}
+) (ConfigUpdater, ShutdownFunc, error) {
+	groupParameters := newGroupParameters()
 
 	node, err := newNode(
 		groupParameters,
@@ -100,14 +137,21 @@ func Initialize(
 		config,
 	)
 	if err != nil {
-		return fmt.Errorf("cannot set up TBTC node: [%v]", err)
+		return nil, nil, fmt.Errorf("cannot set up TBTC node: [%v]", err)
+	}
+
+	keyStoreHealth, err := node.checkKeyStore(config)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	err = node.runCoordinationLayer(ctx)
 	if err != nil {
-		return fmt.Errorf("cannot run coordination layer: [%w]", err)
+		return nil, nil, fmt.Errorf("cannot run coordination layer: [%w]", err)
 	}
 
+	go node.redemptionMonitor.run(ctx)
+
 	deduplicator := newDeduplicator()
 
 	if clientInfo != nil {
@@ -118,11 +162,63 @@ func Initialize(
 				"pre_params_count": func() float64 {
 					return float64(node.dkgExecutor.preParamsCount())
 				},
+				"coordination_faults": func() float64 {
+					return float64(node.coordinationFaults.totalFaults())
+				},
+				"coordination_repeatedly_faulty_operators": func() float64 {
+					return float64(
+						node.coordinationFaults.repeatedlyFaultyOperatorsCount(),
+					)
+				},
+				"redemptions_timeout_warning": func() float64 {
+					return float64(
+						node.redemptionMonitor.alertsCount(
+							RedemptionTimeoutWarning,
+						),
+					)
+				},
+				"redemptions_timeout_critical": func() float64 {
+					return float64(
+						node.redemptionMonitor.alertsCount(
+							RedemptionTimeoutCritical,
+						),
+					)
+				},
 			},
 		)
+
+		clientInfo.RegisterApplicationSource(
+			"tbtc_network",
+			node.networkDiagnostics,
+		)
+
+		clientInfo.RegisterApplicationSource(
+			"tbtc_keystore",
+			keyStoreHealth.Diagnostics,
+		)
+
+		clientInfo.RegisterApplicationSource(
+			"tbtc_duties",
+			node.dutiesDiagnostics,
+		)
+
+		clientInfo.RegisterApplicationSource(
+			"tbtc_coordination_faults",
+			node.coordinationFaults.diagnostics,
+		)
+
+		clientInfo.RegisterApplicationSource(
+			"tbtc_coordination_journal",
+			node.coordinationJournal.diagnostics,
+		)
+
+		clientInfo.RegisterApplicationSource(
+			"tbtc_redemption_monitor",
+			node.redemptionMonitor.diagnostics,
+		)
 	}
 
-	err = sortition.MonitorPool(
+	poolMonitor, err := sortition.MonitorPool(
 		ctx,
 		logger,
 		chain,
@@ -133,15 +229,50 @@ func Initialize(
 				node:   node,
 				config: config,
 			},
+			&maintenanceModePolicy{
+				maintenanceMode: node.maintenanceMode,
+			},
 		),
 	)
 	if err != nil {
-		return fmt.Errorf(
+		return nil, nil, fmt.Errorf(
 			"could not set up sortition pool monitoring: [%v]",
 			err,
 		)
 	}
 
+	if clientInfo != nil {
+		clientInfo.RegisterApplicationSource(
+			"tbtc_operator",
+			func() clientinfo.ApplicationInfo {
+				return node.operatorStatusDiagnostics(poolMonitor)
+			},
+		)
+	}
+
+	_ = chain.OnInactivityClaimed(func(event *InactivityClaimedEvent) {
+		wallet, ok := node.walletRegistry.getWalletByID(event.WalletID)
+		if !ok {
+			return
+		}
+
+		walletPublicKeyHash := bitcoin.PublicKeyHash(wallet.publicKey)
+
+		logger.Warnf(
+			"inactivity claim with nonce [%v] submitted by [%s] at block [%v] "+
+				"for wallet [0x%x]",
+			event.Nonce,
+			event.Notifier,
+			event.BlockNumber,
+			walletPublicKeyHash,
+		)
+
+		node.operatorStatusRecorder.recordInactivityClaim(
+			walletPublicKeyHash,
+			event,
+		)
+	})
+
 	_ = chain.OnDKGStarted(func(event *DKGStartedEvent) {
 		go func() {
 			if ok := deduplicator.notifyDKGStarted(
@@ -214,6 +345,27 @@ func Initialize(
 				// the announcements and the state machine. Here we ensure
 				// a proper start point by delaying the execution by the
 				// confirmation period length.
+				if node.maintenanceMode.isEnabled() {
+					logger.Warnf(
+						"node is in the maintenance mode; not joining DKG "+
+							"with seed [0x%x]",
+						lastEvent.Seed,
+					)
+					return
+				}
+
+				dkgDone, ok := node.shutdownCoordinator.accept(
+					fmt.Sprintf("DKG start with seed [0x%x]", lastEvent.Seed),
+				)
+				if !ok {
+					logger.Warnf(
+						"node is shutting down; not joining DKG with seed [0x%x]",
+						lastEvent.Seed,
+					)
+					return
+				}
+				defer dkgDone()
+
 				node.joinDKGIfEligible(
 					lastEvent.Seed,
 					lastEvent.BlockNumber,
@@ -298,7 +450,7 @@ func Initialize(
 		}()
 	})
 
-	return nil
+	return node.updateConfig, node.shutdownCoordinator.drain, nil
 }
 
 // enoughPreParamsInPoolPolicy is a policy that enforces the sufficient size
@@ -127,17 +127,28 @@ type walletDispatcher struct {
 	// given wallet. The mapping key is the uncompressed public key
 	// (with 04 prefix) of the wallet.
 	actions map[string]WalletActionType
+	// shutdownCoordinator tracks the dispatched actions so the node can wait
+	// for them to complete on shutdown.
+	shutdownCoordinator *shutdownCoordinator
 }
 
-func newWalletDispatcher() *walletDispatcher {
+func newWalletDispatcher(
+	shutdownCoordinator *shutdownCoordinator,
+) *walletDispatcher {
 	return &walletDispatcher{
-		actions: make(map[string]WalletActionType),
+		actions:             make(map[string]WalletActionType),
+		shutdownCoordinator: shutdownCoordinator,
 	}
 }
 
 // dispatch sends the given walletAction for execution. If the wallet is
 // already busy, an errWalletBusy error is returned and the action is ignored.
-func (wd *walletDispatcher) dispatch(action walletAction) error {
+// The optional outcomeFn is called with the action execution result once
+// the action completes.
+func (wd *walletDispatcher) dispatch(
+	action walletAction,
+	outcomeFn walletActionOutcomeFn,
+) error {
 	wd.actionsMutex.Lock()
 	defer wd.actionsMutex.Unlock()
 
@@ -159,7 +170,18 @@ func (wd *walletDispatcher) dispatch(action walletAction) error {
 
 	wd.actions[key] = action.actionType()
 
+	// Actions are results of already accepted work, e.g. coordination
+	// windows, so they are tracked even if the node is shutting down.
+	actionDone := wd.shutdownCoordinator.track(
+		fmt.Sprintf(
+			"%s action of wallet [0x%x]",
+			action.actionType(),
+			bitcoin.PublicKeyHash(action.wallet().publicKey),
+		),
+	)
+
 	go func() {
+		defer actionDone()
 		defer func() {
 			wd.actionsMutex.Lock()
 			delete(wd.actions, key)
@@ -169,6 +191,11 @@ func (wd *walletDispatcher) dispatch(action walletAction) error {
 		walletActionLogger.Infof("starting action execution")
 
 		err := action.execute()
+
+		if outcomeFn != nil {
+			outcomeFn(err)
+		}
+
 		if err != nil {
 			walletActionLogger.Errorf(
 				"action execution terminated with error: [%v]",
@@ -183,6 +210,10 @@ func (wd *walletDispatcher) dispatch(action walletAction) error {
 	return nil
 }
 
+// walletActionOutcomeFn is a function notified about the outcome of
+// a dispatched walletAction. The error is nil if the action succeeded.
+type walletActionOutcomeFn func(err error)
+
 // walletSigningExecutor is an interface meant to decouple the specific
 // implementation of the signing executor from the wallet transaction executor.
 type walletSigningExecutor interface {
@@ -81,7 +81,7 @@ func TestParseWalletActionType(t *testing.T) {
 }
 
 func TestWalletDispatcher_Dispatch(t *testing.T) {
-	walletDispatcher := newWalletDispatcher()
+	walletDispatcher := newWalletDispatcher(newShutdownCoordinator())
 
 	wallet1 := generateWallet(big.NewInt(100))
 	wallet2 := generateWallet(big.NewInt(101))
@@ -123,31 +123,31 @@ func TestWalletDispatcher_Dispatch(t *testing.T) {
 	}
 
 	// Dispatch Action 1 for Wallet 1.
-	err := walletDispatcher.dispatch(wallet1Action1)
+	err := walletDispatcher.dispatch(wallet1Action1, nil)
 	if err != nil {
 		t.Errorf("unexpected error: [%v]", err)
 	}
 
 	// Another Action 1 for Wallet 2.
-	err = walletDispatcher.dispatch(wallet2Action1)
+	err = walletDispatcher.dispatch(wallet2Action1, nil)
 	if err != nil {
 		t.Errorf("unexpected error: [%v]", err)
 	}
 
 	// Try to dispatch Action 1 for Wallet 1 again.
-	err = walletDispatcher.dispatch(wallet1Action1)
+	err = walletDispatcher.dispatch(wallet1Action1, nil)
 	testutils.AssertErrorsSame(t, errWalletBusy, err)
 
 	// Try to dispatch Action 1 for Wallet 2 again.
-	err = walletDispatcher.dispatch(wallet2Action1)
+	err = walletDispatcher.dispatch(wallet2Action1, nil)
 	testutils.AssertErrorsSame(t, errWalletBusy, err)
 
 	// Try to dispatch Action 2 for Wallet 1.
-	err = walletDispatcher.dispatch(wallet1Action2)
+	err = walletDispatcher.dispatch(wallet1Action2, nil)
 	testutils.AssertErrorsSame(t, errWalletBusy, err)
 
 	// Try to dispatch Action 2 for Wallet 2.
-	err = walletDispatcher.dispatch(wallet2Action2)
+	err = walletDispatcher.dispatch(wallet2Action2, nil)
 	testutils.AssertErrorsSame(t, errWalletBusy, err)
 
 	// Complete dispatched actions.
@@ -158,13 +158,13 @@ func TestWalletDispatcher_Dispatch(t *testing.T) {
 	time.Sleep(1 * time.Second)
 
 	// Dispatch Action 2 for Wallet 1.
-	err = walletDispatcher.dispatch(wallet1Action2)
+	err = walletDispatcher.dispatch(wallet1Action2, nil)
 	if err != nil {
 		t.Errorf("unexpected error: [%v]", err)
 	}
 
 	// Dispatch Action 2 for Wallet 2.
-	err = walletDispatcher.dispatch(wallet2Action2)
+	err = walletDispatcher.dispatch(wallet2Action2, nil)
 	if err != nil {
 		t.Errorf("unexpected error: [%v]", err)
 	}
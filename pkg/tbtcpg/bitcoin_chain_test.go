@@ -1,6 +1,7 @@
 package tbtcpg
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
@@ -105,6 +106,42 @@ func (lbc *LocalBitcoinChain) GetTxHashesForPublicKeyHash(
 	panic("unsupported")
 }
 
+func (lbc *LocalBitcoinChain) GetTxHashesForScript(
+	script bitcoin.Script,
+) ([]bitcoin.Hash, error) {
+	lbc.mutex.Lock()
+	defer lbc.mutex.Unlock()
+
+	paysScript := func(transaction *bitcoin.Transaction, outputIndex int) bool {
+		return outputIndex < len(transaction.Outputs) &&
+			bytes.Equal(transaction.Outputs[outputIndex].PublicKeyScript, script)
+	}
+
+	txHashes := make([]bitcoin.Hash, 0)
+	for txHash, transaction := range lbc.transactions {
+		matches := false
+
+		for outputIndex := range transaction.Outputs {
+			if paysScript(transaction, outputIndex) {
+				matches = true
+			}
+		}
+
+		for _, input := range transaction.Inputs {
+			previousTransaction, ok := lbc.transactions[input.Outpoint.TransactionHash]
+			if ok && paysScript(previousTransaction, int(input.Outpoint.OutputIndex)) {
+				matches = true
+			}
+		}
+
+		if matches {
+			txHashes = append(txHashes, txHash)
+		}
+	}
+
+	return txHashes, nil
+}
+
 func (lbc *LocalBitcoinChain) GetMempoolForPublicKeyHash(
 	publicKeyHash [20]byte,
 ) ([]*bitcoin.Transaction, error) {
@@ -120,6 +120,10 @@ type Chain interface {
 
 	AverageBlockTime() time.Duration
 
+	// GetBlockTimestamp gets the timestamp of the block with the given
+	// number, in seconds since the Unix epoch.
+	GetBlockTimestamp(blockNumber uint64) (uint64, error)
+
 	// GetOperatorID returns the operator ID for the given operator address.
 	GetOperatorID(operatorAddress chain.Address) (chain.OperatorID, error)
 
@@ -188,4 +192,9 @@ type Chain interface {
 	// the deposit reveal before a deposit becomes eligible for
 	// a processing.
 	GetDepositMinAge() (uint32, error)
+
+	// GetDepositRefundSafetyMargin gets the minimum time that must remain
+	// until the deposit refund locktime for a deposit to be eligible for
+	// a sweep.
+	GetDepositRefundSafetyMargin() (uint32, error)
 }
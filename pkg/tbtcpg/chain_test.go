@@ -85,6 +85,7 @@ type LocalChain struct {
 	blockCounter                             chain.BlockCounter
 	pastRedemptionRequestedEvents            map[[32]byte][]*tbtc.RedemptionRequestedEvent
 	averageBlockTime                         time.Duration
+	blockTimestamps                          map[uint64]uint64
 	pendingRedemptionRequests                map[[32]byte]*tbtc.RedemptionRequest
 	redemptionProposalValidations            map[[32]byte]bool
 	heartbeatProposalValidations             map[[16]byte]bool
@@ -98,6 +99,7 @@ type LocalChain struct {
 	operatorIDs                              map[chain.Address]uint32
 	redemptionDelays                         map[[32]byte]time.Duration
 	depositMinAge                            uint32
+	depositRefundSafetyMargin                uint32
 }
 
 func NewLocalChain() *LocalChain {
@@ -119,6 +121,7 @@ func NewLocalChain() *LocalChain {
 		movedFundsSweepProposalValidations:       make(map[[32]byte]bool),
 		operatorIDs:                              make(map[chain.Address]uint32),
 		redemptionDelays:                         make(map[[32]byte]time.Duration),
+		blockTimestamps:                          make(map[uint64]uint64),
 	}
 }
 
@@ -1021,10 +1024,36 @@ func (lc *LocalChain) SetAverageBlockTime(averageBlockTime time.Duration) {
 	lc.averageBlockTime = averageBlockTime
 }
 
+func (lc *LocalChain) GetBlockTimestamp(blockNumber uint64) (uint64, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	timestamp, ok := lc.blockTimestamps[blockNumber]
+	if !ok {
+		return 0, fmt.Errorf("block timestamp not found")
+	}
+
+	return timestamp, nil
+}
+
+func (lc *LocalChain) SetBlockTimestamp(blockNumber uint64, timestamp uint64) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.blockTimestamps[blockNumber] = timestamp
+}
+
 func (lc *LocalChain) IsWalletRegistered(EcdsaWalletID [32]byte) (bool, error) {
 	panic("unsupported")
 }
 
+func (lc *LocalChain) IsWalletSigningGroup(
+	EcdsaWalletID [32]byte,
+	signingGroupOperators chain.Addresses,
+) (bool, error) {
+	panic("unsupported")
+}
+
 func (lc *LocalChain) CalculateWalletID(
 	walletPublicKey *ecdsa.PublicKey,
 ) ([32]byte, error) {
@@ -1354,6 +1383,22 @@ func (lc *LocalChain) SetDepositMinAge(depositMinAge uint32) {
 	lc.depositMinAge = depositMinAge
 }
 
+func (lc *LocalChain) GetDepositRefundSafetyMargin() (uint32, error) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	return lc.depositRefundSafetyMargin, nil
+}
+
+func (lc *LocalChain) SetDepositRefundSafetyMargin(
+	depositRefundSafetyMargin uint32,
+) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	lc.depositRefundSafetyMargin = depositRefundSafetyMargin
+}
+
 type MockBlockCounter struct {
 	mutex        sync.Mutex
 	currentBlock uint64
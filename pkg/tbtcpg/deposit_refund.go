@@ -0,0 +1,235 @@
+package tbtcpg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/internal/hexutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// DepositRefundStatus describes an unswept deposit with regard to its refund
+// locktime. Once the refund locktime passes, the depositor can take the
+// deposit back on Bitcoin. A refunded deposit stays unswept in the Bridge
+// forever and must be excluded from deposit sweeps.
+type DepositRefundStatus struct {
+	DepositReference
+
+	WalletPublicKeyHash [20]byte
+	DepositKey          string
+	// Amount is the deposit amount in satoshi.
+	Amount uint64
+	// RefundLocktime is the time after which the depositor can take the
+	// deposit back.
+	RefundLocktime time.Time
+	// TimeUntilRefund is the time remaining until the refund locktime.
+	// It is not positive once the deposit is refundable.
+	TimeUntilRefund time.Duration
+	// Refunded is true if the deposit funding output was spent on Bitcoin by
+	// a transaction not paying to the wallet.
+	Refunded bool
+	// RefundTxHash is the hash of the transaction that spent the deposit
+	// funding output. It is set only if the deposit was refunded.
+	RefundTxHash *bitcoin.Hash
+}
+
+// FindDepositRefunds determines the refund status of the unswept deposits
+// of the given wallet or of all wallets if the wallet public key hash is
+// empty. The returned statuses are sorted by the refund locktime, the one
+// closest to being refundable first. Only the deposits whose refund locktime
+// passed are checked for a refund, as only these can be refunded.
+func FindDepositRefunds(
+	fnLogger log.StandardLogger,
+	chain Chain,
+	btcChain bitcoin.Chain,
+	walletPublicKeyHash [20]byte,
+) ([]*DepositRefundStatus, error) {
+	filter := &tbtc.DepositRevealedEventFilter{}
+	if walletPublicKeyHash != [20]byte{} {
+		filter.WalletPublicKeyHash = [][20]byte{walletPublicKeyHash}
+	}
+
+	depositRevealedEvents, err := chain.PastDepositRevealedEvents(filter)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get past deposit revealed events: [%w]",
+			err,
+		)
+	}
+
+	timeNow := time.Now()
+
+	result := make([]*DepositRefundStatus, 0)
+	for _, event := range depositRevealedEvents {
+		depositKey := chain.BuildDepositKey(
+			event.FundingTxHash,
+			event.FundingOutputIndex,
+		)
+		depositKeyStr := hexutils.Encode(depositKey.Bytes())
+
+		depositRequest, found, err := chain.GetDepositRequest(
+			event.FundingTxHash,
+			event.FundingOutputIndex,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get deposit request: [%w]",
+				err,
+			)
+		}
+
+		if !found {
+			return nil, fmt.Errorf(
+				"no deposit request for key [%s]",
+				depositKeyStr,
+			)
+		}
+
+		if depositRequest.SweptAt.Unix() != 0 {
+			continue
+		}
+
+		refundLocktime := time.Unix(
+			int64(depositRefundLocktime(event.RefundLocktime)),
+			0,
+		)
+
+		status := &DepositRefundStatus{
+			DepositReference: DepositReference{
+				FundingTxHash:      event.FundingTxHash,
+				FundingOutputIndex: event.FundingOutputIndex,
+				RevealBlock:        event.BlockNumber,
+			},
+			WalletPublicKeyHash: event.WalletPublicKeyHash,
+			DepositKey:          depositKeyStr,
+			Amount:              depositRequest.Amount,
+			RefundLocktime:      refundLocktime,
+			TimeUntilRefund:     refundLocktime.Sub(timeNow),
+		}
+
+		if status.TimeUntilRefund <= 0 {
+			refundTxHash, err := findDepositRefundTransaction(
+				btcChain,
+				event.FundingTxHash,
+				event.FundingOutputIndex,
+				event.WalletPublicKeyHash,
+			)
+			if err != nil {
+				fnLogger.Errorf(
+					"failed to check refund of deposit [%s]: [%v]",
+					depositKeyStr,
+					err,
+				)
+			} else if refundTxHash != nil {
+				status.Refunded = true
+				status.RefundTxHash = refundTxHash
+			}
+		}
+
+		result = append(result, status)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].RefundLocktime.Before(result[j].RefundLocktime)
+	})
+
+	return result, nil
+}
+
+// depositRefundLocktime decodes the refund locktime of a deposit which is
+// a little-endian Unix timestamp, as encoded in the deposit script.
+func depositRefundLocktime(refundLocktime [4]byte) uint32 {
+	return binary.LittleEndian.Uint32(refundLocktime[:])
+}
+
+// findDepositRefundTransaction returns the hash of the confirmed transaction
+// that spent the given deposit funding output without paying to the wallet,
+// i.e. the transaction refunding the deposit to the depositor. Returns nil if
+// the funding output is unspent or was spent by the wallet, e.g. in a deposit
+// sweep whose proof was not submitted to the Bridge yet.
+func findDepositRefundTransaction(
+	btcChain bitcoin.Chain,
+	fundingTxHash bitcoin.Hash,
+	fundingOutputIndex uint32,
+	walletPublicKeyHash [20]byte,
+) (*bitcoin.Hash, error) {
+	fundingTx, err := btcChain.GetTransaction(fundingTxHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get funding transaction: [%v]", err)
+	}
+
+	if int(fundingOutputIndex) >= len(fundingTx.Outputs) {
+		return nil, fmt.Errorf(
+			"funding transaction has no output [%v]",
+			fundingOutputIndex,
+		)
+	}
+
+	txHashes, err := btcChain.GetTxHashesForScript(
+		fundingTx.Outputs[fundingOutputIndex].PublicKeyScript,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot get transactions of funding output script: [%v]",
+			err,
+		)
+	}
+
+	walletP2PKH, err := bitcoin.PayToPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build wallet P2PKH: [%v]", err)
+	}
+
+	walletP2WPKH, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build wallet P2WPKH: [%v]", err)
+	}
+
+	for _, txHash := range txHashes {
+		// The script history contains the funding transaction itself and
+		// possibly other transactions paying to the same script.
+		if txHash == fundingTxHash {
+			continue
+		}
+
+		transaction, err := btcChain.GetTransaction(txHash)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"cannot get transaction [%s]: [%v]",
+				txHash.Hex(bitcoin.ReversedByteOrder),
+				err,
+			)
+		}
+
+		spendsFundingOutput := false
+		for _, input := range transaction.Inputs {
+			if input.Outpoint.TransactionHash == fundingTxHash &&
+				input.Outpoint.OutputIndex == fundingOutputIndex {
+				spendsFundingOutput = true
+				break
+			}
+		}
+
+		if !spendsFundingOutput {
+			continue
+		}
+
+		for _, output := range transaction.Outputs {
+			if bytes.Equal(output.PublicKeyScript, walletP2PKH) ||
+				bytes.Equal(output.PublicKeyScript, walletP2WPKH) {
+				return nil, nil
+			}
+		}
+
+		refundTxHash := txHash
+		return &refundTxHash, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,222 @@
+package tbtcpg
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestFindDepositRefunds(t *testing.T) {
+	walletPublicKeyHash := [20]byte{0x01, 0x02}
+
+	walletP2WPKH, err := bitcoin.PayToWitnessPublicKeyHash(walletPublicKeyHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := NewLocalChain()
+	btcChain := NewLocalBitcoinChain()
+
+	now := time.Now()
+
+	// addDeposit reveals a deposit funded with an output locked with a script
+	// starting with the given id byte and returns its funding transaction
+	// hash.
+	addDeposit := func(
+		id byte,
+		refundLocktime time.Time,
+		sweptAt time.Time,
+	) bitcoin.Hash {
+		fundingTx := &bitcoin.Transaction{
+			Version: 1,
+			Inputs: []*bitcoin.TransactionInput{
+				{
+					Outpoint: &bitcoin.TransactionOutpoint{
+						TransactionHash: bitcoin.Hash{0xff, id},
+					},
+					Sequence: 0xffffffff,
+				},
+			},
+			Outputs: []*bitcoin.TransactionOutput{
+				{Value: 100000, PublicKeyScript: bitcoin.Script{0x00, id}},
+			},
+		}
+		fundingTxHash := fundingTx.Hash()
+		btcChain.SetTransaction(fundingTxHash, fundingTx)
+
+		var refundLocktimeBytes [4]byte
+		binary.LittleEndian.PutUint32(
+			refundLocktimeBytes[:],
+			uint32(refundLocktime.Unix()),
+		)
+
+		err := chain.AddPastDepositRevealedEvent(
+			&tbtc.DepositRevealedEventFilter{
+				WalletPublicKeyHash: [][20]byte{walletPublicKeyHash},
+			},
+			&tbtc.DepositRevealedEvent{
+				FundingTxHash:       fundingTxHash,
+				WalletPublicKeyHash: walletPublicKeyHash,
+				RefundLocktime:      refundLocktimeBytes,
+				BlockNumber:         uint64(id),
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		chain.SetDepositRequest(fundingTxHash, 0, &tbtc.DepositChainRequest{
+			Amount:  100000,
+			SweptAt: sweptAt,
+		})
+
+		return fundingTxHash
+	}
+
+	// spend spends the funding output of the given deposit with a transaction
+	// paying to the given script and returns the transaction hash.
+	spend := func(fundingTxHash bitcoin.Hash, script bitcoin.Script) bitcoin.Hash {
+		transaction := &bitcoin.Transaction{
+			Version: 1,
+			Inputs: []*bitcoin.TransactionInput{
+				{
+					Outpoint: &bitcoin.TransactionOutpoint{
+						TransactionHash: fundingTxHash,
+					},
+					Sequence: 0xffffffff,
+				},
+			},
+			Outputs: []*bitcoin.TransactionOutput{
+				{Value: 90000, PublicKeyScript: script},
+			},
+		}
+		transactionHash := transaction.Hash()
+		btcChain.SetTransaction(transactionHash, transaction)
+
+		return transactionHash
+	}
+
+	unswept := time.Unix(0, 0)
+
+	// Deposit far from its refund locktime.
+	addDeposit(0x01, now.Add(30*24*time.Hour), unswept)
+	// Refundable deposit not refunded yet.
+	addDeposit(0x02, now.Add(-2*time.Hour), unswept)
+	// Deposit refunded by the depositor.
+	refundedDeposit := addDeposit(0x03, now.Add(-3*time.Hour), unswept)
+	refundTxHash := spend(refundedDeposit, bitcoin.Script{0x00, 0xaa})
+	// Deposit swept by the wallet with the sweep proof not submitted yet.
+	sweptDeposit := addDeposit(0x04, now.Add(-4*time.Hour), unswept)
+	spend(sweptDeposit, walletP2WPKH)
+	// Deposit swept and proven.
+	addDeposit(0x05, now.Add(-5*time.Hour), now.Add(-24*time.Hour))
+
+	statuses, err := FindDepositRefunds(
+		&testutils.MockLogger{},
+		chain,
+		btcChain,
+		walletPublicKeyHash,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type expectedStatus struct {
+		revealBlock  uint64
+		refundable   bool
+		refunded     bool
+		refundTxHash *bitcoin.Hash
+	}
+
+	actualStatuses := make([]expectedStatus, len(statuses))
+	for i, status := range statuses {
+		actualStatuses[i] = expectedStatus{
+			revealBlock:  status.RevealBlock,
+			refundable:   status.TimeUntilRefund <= 0,
+			refunded:     status.Refunded,
+			refundTxHash: status.RefundTxHash,
+		}
+	}
+
+	expectedStatuses := []expectedStatus{
+		{revealBlock: 0x04, refundable: true},
+		{
+			revealBlock:  0x03,
+			refundable:   true,
+			refunded:     true,
+			refundTxHash: &refundTxHash,
+		},
+		{revealBlock: 0x02, refundable: true},
+		{revealBlock: 0x01},
+	}
+
+	if !reflect.DeepEqual(expectedStatuses, actualStatuses) {
+		t.Errorf(
+			"unexpected statuses\nexpected: %+v\nactual:   %+v",
+			expectedStatuses,
+			actualStatuses,
+		)
+	}
+
+	// Refunded deposits are excluded from deposits to sweep and flagged
+	// when listing all deposits.
+	for _, skipSwept := range []bool{true, false} {
+		deposits, err := findDeposits(
+			&testutils.MockLogger{},
+			chain,
+			btcChain,
+			walletPublicKeyHash,
+			0,
+			skipSwept,
+			false,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var refundedRevealBlocks []uint64
+		var otherRevealBlocks []uint64
+		for _, deposit := range deposits {
+			if deposit.IsRefunded {
+				refundedRevealBlocks = append(
+					refundedRevealBlocks,
+					deposit.RevealBlock,
+				)
+			} else {
+				otherRevealBlocks = append(otherRevealBlocks, deposit.RevealBlock)
+			}
+		}
+
+		expectedRefundedRevealBlocks := []uint64{0x03}
+		expectedOtherRevealBlocks := []uint64{0x01, 0x02, 0x04, 0x05}
+		if skipSwept {
+			expectedRefundedRevealBlocks = nil
+			expectedOtherRevealBlocks = []uint64{0x01, 0x02, 0x04}
+		}
+
+		if !reflect.DeepEqual(expectedRefundedRevealBlocks, refundedRevealBlocks) {
+			t.Errorf(
+				"unexpected refunded deposits for skip swept [%v]\n"+
+					"expected: %v\nactual:   %v",
+				skipSwept,
+				expectedRefundedRevealBlocks,
+				refundedRevealBlocks,
+			)
+		}
+
+		if !reflect.DeepEqual(expectedOtherRevealBlocks, otherRevealBlocks) {
+			t.Errorf(
+				"unexpected other deposits for skip swept [%v]\n"+
+					"expected: %v\nactual:   %v",
+				skipSwept,
+				expectedOtherRevealBlocks,
+				otherRevealBlocks,
+			)
+		}
+	}
+}
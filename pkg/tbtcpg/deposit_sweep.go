@@ -21,17 +21,33 @@ const depositScriptByteSize = 126
 
 // DepositSweepTask is a task that may produce a deposit sweep proposal.
 type DepositSweepTask struct {
-	chain    Chain
-	btcChain bitcoin.Chain
+	chain     Chain
+	btcChain  bitcoin.Chain
+	selection depositSweepSelection
 }
 
+// NewDepositSweepTask creates a deposit sweep task sweeping the oldest
+// deposits first.
 func NewDepositSweepTask(
 	chain Chain,
 	btcChain bitcoin.Chain,
+) *DepositSweepTask {
+	return newDepositSweepTask(
+		chain,
+		btcChain,
+		&oldestDepositSweepSelection{},
+	)
+}
+
+func newDepositSweepTask(
+	chain Chain,
+	btcChain bitcoin.Chain,
+	selection depositSweepSelection,
 ) *DepositSweepTask {
 	return &DepositSweepTask{
-		chain:    chain,
-		btcChain: btcChain,
+		chain:     chain,
+		btcChain:  btcChain,
+		selection: selection,
 	}
 }
 
@@ -55,7 +71,7 @@ func (dst *DepositSweepTask) Run(request *tbtc.CoordinationProposalRequest) (
 		)
 	}
 
-	deposits, err := dst.FindDepositsToSweep(
+	deposits, fee, err := dst.findDepositsToSweep(
 		taskLogger,
 		walletPublicKeyHash,
 		depositSweepMaxSize,
@@ -76,7 +92,7 @@ func (dst *DepositSweepTask) Run(request *tbtc.CoordinationProposalRequest) (
 		taskLogger,
 		walletPublicKeyHash,
 		deposits,
-		0,
+		fee,
 	)
 	if err != nil {
 		return nil, false, fmt.Errorf(
@@ -106,6 +122,7 @@ type Deposit struct {
 	WalletPublicKeyHash [20]byte
 	DepositKey          string
 	IsSwept             bool
+	IsRefunded          bool
 	AmountBtc           float64
 	Confirmations       uint
 }
@@ -140,6 +157,38 @@ func findDeposits(
 	skipSwept bool,
 	skipUnconfirmed bool,
 ) ([]*Deposit, error) {
+	candidates, err := findDepositCandidates(
+		fnLogger,
+		chain,
+		btcChain,
+		walletPublicKeyHash,
+		maxNumberOfDeposits,
+		skipSwept,
+		skipUnconfirmed,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Deposit, len(candidates))
+	for i, candidate := range candidates {
+		result[i] = candidate.Deposit
+	}
+
+	return result, nil
+}
+
+// findDepositCandidates finds deposits according to the given criteria and
+// returns them along with the deposit data used to select deposits to sweep.
+func findDepositCandidates(
+	fnLogger log.StandardLogger,
+	chain Chain,
+	btcChain bitcoin.Chain,
+	walletPublicKeyHash [20]byte,
+	maxNumberOfDeposits int,
+	skipSwept bool,
+	skipUnconfirmed bool,
+) ([]*depositSweepCandidate, error) {
 	fnLogger.Infof("reading revealed deposits from chain")
 
 	depositMinAgeSeconds, err := chain.GetDepositMinAge()
@@ -158,7 +207,7 @@ func findDeposits(
 
 	depositRevealedEvents, err := chain.PastDepositRevealedEvents(filter)
 	if err != nil {
-		return []*Deposit{}, fmt.Errorf(
+		return []*depositSweepCandidate{}, fmt.Errorf(
 			"failed to get past deposit revealed events: [%w]",
 			err,
 		)
@@ -181,7 +230,7 @@ func findDeposits(
 	// Capture time now for computations.
 	timeNow := time.Now()
 
-	result := make([]*Deposit, 0, resultSliceCapacity)
+	result := make([]*depositSweepCandidate, 0, resultSliceCapacity)
 	for _, event := range depositRevealedEvents {
 		if len(result) == cap(result) {
 			break
@@ -240,19 +289,57 @@ func findDeposits(
 			continue
 		}
 
+		refundLocktime := depositRefundLocktime(event.RefundLocktime)
+
+		// Once the refund locktime passes, the depositor can take the deposit
+		// back on Bitcoin. A refunded deposit can never be swept.
+		isRefunded := false
+		if !isSwept && !timeNow.Before(time.Unix(int64(refundLocktime), 0)) {
+			refundTxHash, err := findDepositRefundTransaction(
+				btcChain,
+				event.FundingTxHash,
+				event.FundingOutputIndex,
+				event.WalletPublicKeyHash,
+			)
+			if err != nil {
+				fnLogger.Errorf(
+					"failed to check refund of deposit [%s]: [%v]",
+					depositKeyStr,
+					err,
+				)
+			} else if refundTxHash != nil {
+				isRefunded = true
+
+				if skipSwept {
+					fnLogger.Warnf(
+						"deposit [%s] was refunded in transaction [%s]",
+						depositKeyStr,
+						refundTxHash.Hex(bitcoin.ReversedByteOrder),
+					)
+					continue
+				}
+			}
+		}
+
 		result = append(
 			result,
-			&Deposit{
-				DepositReference: DepositReference{
-					FundingTxHash:      event.FundingTxHash,
-					FundingOutputIndex: event.FundingOutputIndex,
-					RevealBlock:        event.BlockNumber,
+			&depositSweepCandidate{
+				Deposit: &Deposit{
+					DepositReference: DepositReference{
+						FundingTxHash:      event.FundingTxHash,
+						FundingOutputIndex: event.FundingOutputIndex,
+						RevealBlock:        event.BlockNumber,
+					},
+					WalletPublicKeyHash: event.WalletPublicKeyHash,
+					DepositKey:          hexutils.Encode(depositKey.Bytes()),
+					IsSwept:             isSwept,
+					IsRefunded:          isRefunded,
+					AmountBtc:           convertSatToBtc(float64(depositRequest.Amount)),
+					Confirmations:       confirmations,
 				},
-				WalletPublicKeyHash: event.WalletPublicKeyHash,
-				DepositKey:          hexutils.Encode(depositKey.Bytes()),
-				IsSwept:             isSwept,
-				AmountBtc:           convertSatToBtc(float64(depositRequest.Amount)),
-				Confirmations:       confirmations,
+				amount:         depositRequest.Amount,
+				treasuryFee:    depositRequest.TreasuryFee,
+				refundLocktime: refundLocktime,
 			},
 		)
 	}
@@ -262,8 +349,8 @@ func findDeposits(
 
 // FindDepositsToSweep finds deposits that can be swept.
 // maxNumberOfDeposits is used as a ceiling for the number of deposits in the
-// result. If number of discovered deposits meets the maxNumberOfDeposits the
-// function will stop fetching more deposits.
+// result. The deposits are chosen among all unswept deposits of the wallet by
+// the task's deposit sweep selection strategy.
 // This function will return a list of deposits from the wallet that can be swept.
 // Deposits with insufficient number of funding transaction confirmations will
 // not be taken into consideration for sweeping.
@@ -274,29 +361,54 @@ func (dst *DepositSweepTask) FindDepositsToSweep(
 	walletPublicKeyHash [20]byte,
 	maxNumberOfDeposits uint16,
 ) ([]*DepositReference, error) {
+	depositsRefs, _, err := dst.findDepositsToSweep(
+		taskLogger,
+		walletPublicKeyHash,
+		maxNumberOfDeposits,
+	)
+
+	return depositsRefs, err
+}
+
+// findDepositsToSweep finds deposits that can be swept, as described in
+// FindDepositsToSweep. It also returns the sweep transaction fee estimated by
+// the deposit sweep selection strategy or 0 if the strategy does not estimate
+// the fee.
+func (dst *DepositSweepTask) findDepositsToSweep(
+	taskLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+	maxNumberOfDeposits uint16,
+) ([]*DepositReference, int64, error) {
 	if walletPublicKeyHash == [20]byte{} {
-		return nil, fmt.Errorf("wallet public key hash is required")
+		return nil, 0, fmt.Errorf("wallet public key hash is required")
 	}
 
 	taskLogger.Infof("fetching max [%d] deposits", maxNumberOfDeposits)
 
-	unsweptDeposits, err := findDeposits(
+	unsweptDeposits, err := findDepositCandidates(
 		taskLogger,
 		dst.chain,
 		dst.btcChain,
 		walletPublicKeyHash,
-		int(maxNumberOfDeposits),
+		dst.selection.candidatesLimit(int(maxNumberOfDeposits)),
 		true,
 		true,
 	)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	depositsToSweep := unsweptDeposits
+	depositsToSweep, fee, err := dst.selection.selectDeposits(
+		taskLogger,
+		unsweptDeposits,
+		int(maxNumberOfDeposits),
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot select deposits to sweep: [%w]", err)
+	}
 
 	if len(depositsToSweep) == 0 {
-		return nil, nil
+		return nil, 0, nil
 	}
 
 	taskLogger.Infof(
@@ -325,7 +437,7 @@ func (dst *DepositSweepTask) FindDepositsToSweep(
 		}
 	}
 
-	return depositsRefs, nil
+	return depositsRefs, fee, nil
 }
 
 // ProposeDepositsSweep returns a deposit sweep proposal.
@@ -353,6 +465,7 @@ func (dst *DepositSweepTask) ProposeDepositsSweep(
 		estimatedFee, _, err := estimateDepositsSweepFee(
 			dst.btcChain,
 			len(deposits),
+			true,
 			perDepositMaxFee,
 		)
 		if err != nil {
@@ -462,6 +575,7 @@ func EstimateDepositsSweepFee(
 		totalFee, satPerVByteFee, err := estimateDepositsSweepFee(
 			btcChain,
 			depositsCountKey,
+			true,
 			perDepositMaxFee,
 		)
 		if err != nil {
@@ -484,16 +598,20 @@ func EstimateDepositsSweepFee(
 	return fees, nil
 }
 
+// estimateDepositsSweepFee estimates the total and sat/vbyte fee of a sweep
+// transaction with the given count of P2WSH (depositsWitness is true) or
+// P2SH (depositsWitness is false) deposit inputs.
 func estimateDepositsSweepFee(
 	btcChain bitcoin.Chain,
 	depositsCount int,
+	depositsWitness bool,
 	perDepositMaxFee uint64,
 ) (int64, int64, error) {
 	transactionSize, err := bitcoin.NewTransactionSizeEstimator().
 		// 1 P2WPKH main UTXO input.
 		AddPublicKeyHashInputs(1, true).
-		// depositsCount P2WSH deposit inputs.
-		AddScriptHashInputs(depositsCount, depositScriptByteSize, true).
+		// depositsCount P2WSH or P2SH deposit inputs.
+		AddScriptHashInputs(depositsCount, depositScriptByteSize, depositsWitness).
 		// 1 P2WPKH output.
 		AddPublicKeyHashOutputs(1, true).
 		VirtualSize()
@@ -0,0 +1,346 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// OldestDepositSweepSelection is the name of the deposit sweep selection
+	// strategy sweeping the oldest unswept deposits first.
+	OldestDepositSweepSelection = "oldest"
+	// FeeOptimizedDepositSweepSelection is the name of the deposit sweep
+	// selection strategy sweeping deposits nearing their refund locktime
+	// first, leaving out dust deposits whose share of the sweep fee would
+	// exceed their treasury fee, and sweeping deposits of the same script
+	// type together.
+	FeeOptimizedDepositSweepSelection = "feeOptimized"
+)
+
+// depositSweepCandidate is a deposit considered for sweeping, along with the
+// deposit data used by the deposit sweep selection strategies.
+type depositSweepCandidate struct {
+	*Deposit
+
+	// amount is the deposit amount in satoshi.
+	amount uint64
+	// treasuryFee is the treasury fee of the deposit in satoshi.
+	treasuryFee uint64
+	// refundLocktime is the Unix timestamp after which the depositor can
+	// take the deposit back.
+	refundLocktime uint32
+}
+
+// depositSweepSelection is a strategy selecting deposits to sweep.
+// The selection is made only by the coordination leader proposing the sweep
+// and the resulting proposal is validated on-chain by the followers so
+// operators of a wallet may use different strategies.
+type depositSweepSelection interface {
+	// selectDeposits selects at most maxNumberOfDeposits deposits to sweep
+	// out of the given candidates, in the order they should be swept in.
+	// A maxNumberOfDeposits of 0 means no limit. It also returns the
+	// estimated fee of the sweep transaction or 0 if the strategy leaves the
+	// fee estimation to the proposal.
+	selectDeposits(
+		fnLogger log.StandardLogger,
+		candidates []*depositSweepCandidate,
+		maxNumberOfDeposits int,
+	) ([]*depositSweepCandidate, int64, error)
+	// candidatesLimit returns the number of the oldest candidates the
+	// strategy needs to select at most maxNumberOfDeposits deposits.
+	// A limit of 0 means all the candidates are needed. Collecting a
+	// candidate costs a Bitcoin chain call so the strategies should not ask
+	// for more candidates than they need.
+	candidatesLimit(maxNumberOfDeposits int) int
+}
+
+// newDepositSweepSelection creates the deposit sweep selection strategy with
+// the given name. An empty name denotes the oldest first strategy.
+func newDepositSweepSelection(
+	name string,
+	chain Chain,
+	btcChain bitcoin.Chain,
+) (depositSweepSelection, error) {
+	switch name {
+	case "", OldestDepositSweepSelection:
+		return &oldestDepositSweepSelection{}, nil
+	case FeeOptimizedDepositSweepSelection:
+		return newFeeOptimizedDepositSweepSelection(chain, btcChain), nil
+	default:
+		return nil, fmt.Errorf(
+			"unsupported deposit sweep selection [%s]; expected one of: %s, %s",
+			name,
+			OldestDepositSweepSelection,
+			FeeOptimizedDepositSweepSelection,
+		)
+	}
+}
+
+// oldestDepositSweepSelection is a deposit sweep selection strategy taking
+// the deposits in the order they were revealed.
+type oldestDepositSweepSelection struct{}
+
+func (odss *oldestDepositSweepSelection) selectDeposits(
+	fnLogger log.StandardLogger,
+	candidates []*depositSweepCandidate,
+	maxNumberOfDeposits int,
+) ([]*depositSweepCandidate, int64, error) {
+	selected := make([]*depositSweepCandidate, len(candidates))
+	copy(selected, candidates)
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].RevealBlock < selected[j].RevealBlock
+	})
+
+	if maxNumberOfDeposits > 0 && len(selected) > maxNumberOfDeposits {
+		selected = selected[:maxNumberOfDeposits]
+	}
+
+	return selected, 0, nil
+}
+
+func (odss *oldestDepositSweepSelection) candidatesLimit(
+	maxNumberOfDeposits int,
+) int {
+	// The oldest deposits are exactly the ones selected.
+	return maxNumberOfDeposits
+}
+
+// feeOptimizedDepositSweepSelection is a deposit sweep selection strategy
+// optimizing the fee paid per swept deposit.
+//
+// The deposits are taken in the order of their refund locktime so that the
+// deposits closest to being refundable are swept first. Deposits within the
+// on-chain validator's refund safety margin of their refund locktime are left
+// out as the validator rejects sweeping them. Only the deposits of
+// the script type of the first deposit are swept together; spending P2SH
+// inputs is more expensive than P2WSH ones, and the fee of a sweep with P2SH
+// inputs only can be estimated without the risk of underestimation. Deposits
+// whose share of the estimated sweep fee exceeds their treasury fee are left
+// out as dust. Deposits revealed with no treasury fee are never considered
+// dust.
+type feeOptimizedDepositSweepSelection struct {
+	// scriptType returns the script type of the given deposit's funding
+	// output.
+	scriptType func(deposit *Deposit) (bitcoin.ScriptType, error)
+	// estimateFee returns the estimated fee of a sweep transaction with the
+	// given count of deposit inputs of the given script type.
+	estimateFee func(
+		depositsCount int,
+		scriptType bitcoin.ScriptType,
+	) (int64, error)
+	// refundSafetyMargin returns the minimum time, in seconds, that must
+	// remain until the refund locktime of a deposit for the deposit to be
+	// swept.
+	refundSafetyMargin func() (uint32, error)
+	// timeNow returns the current time.
+	timeNow func() time.Time
+}
+
+func newFeeOptimizedDepositSweepSelection(
+	chain Chain,
+	btcChain bitcoin.Chain,
+) *feeOptimizedDepositSweepSelection {
+	return &feeOptimizedDepositSweepSelection{
+		scriptType: func(deposit *Deposit) (bitcoin.ScriptType, error) {
+			fundingTx, err := btcChain.GetTransaction(deposit.FundingTxHash)
+			if err != nil {
+				return 0, fmt.Errorf(
+					"cannot get funding transaction: [%v]",
+					err,
+				)
+			}
+
+			if int(deposit.FundingOutputIndex) >= len(fundingTx.Outputs) {
+				return 0, fmt.Errorf(
+					"funding transaction has no output [%v]",
+					deposit.FundingOutputIndex,
+				)
+			}
+
+			return bitcoin.GetScriptType(
+				fundingTx.Outputs[deposit.FundingOutputIndex].PublicKeyScript,
+			), nil
+		},
+		estimateFee: func(
+			depositsCount int,
+			scriptType bitcoin.ScriptType,
+		) (int64, error) {
+			_, _, perDepositMaxFee, _, err := chain.GetDepositParameters()
+			if err != nil {
+				return 0, fmt.Errorf(
+					"cannot get deposit tx max fee: [%v]",
+					err,
+				)
+			}
+
+			fee, _, err := estimateDepositsSweepFee(
+				btcChain,
+				depositsCount,
+				scriptType == bitcoin.P2WSHScript,
+				perDepositMaxFee,
+			)
+
+			return fee, err
+		},
+		refundSafetyMargin: chain.GetDepositRefundSafetyMargin,
+		timeNow:            time.Now,
+	}
+}
+
+func (fodss *feeOptimizedDepositSweepSelection) candidatesLimit(
+	maxNumberOfDeposits int,
+) int {
+	// Any deposit may be the next one to become refundable so the
+	// strategy needs to see all of them.
+	return 0
+}
+
+func (fodss *feeOptimizedDepositSweepSelection) selectDeposits(
+	fnLogger log.StandardLogger,
+	candidates []*depositSweepCandidate,
+	maxNumberOfDeposits int,
+) ([]*depositSweepCandidate, int64, error) {
+	refundSafetyMargin, err := fodss.refundSafetyMargin()
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"cannot get deposit refund safety margin: [%v]",
+			err,
+		)
+	}
+
+	// Deposits can be swept only if their refund locktime is beyond the
+	// safety margin.
+	sweepableAfter := uint64(fodss.timeNow().Unix()) + uint64(refundSafetyMargin)
+
+	prioritized := make([]*depositSweepCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if uint64(candidate.refundLocktime) <= sweepableAfter {
+			fnLogger.Infof(
+				"leaving out deposit [%s] within the refund safety margin; "+
+					"refund locktime: [%d]",
+				candidate.DepositKey,
+				candidate.refundLocktime,
+			)
+			continue
+		}
+
+		prioritized = append(prioritized, candidate)
+	}
+
+	sort.SliceStable(prioritized, func(i, j int) bool {
+		if prioritized[i].refundLocktime != prioritized[j].refundLocktime {
+			return prioritized[i].refundLocktime < prioritized[j].refundLocktime
+		}
+
+		return prioritized[i].RevealBlock < prioritized[j].RevealBlock
+	})
+
+	type typedCandidate struct {
+		*depositSweepCandidate
+		scriptType bitcoin.ScriptType
+	}
+
+	typedCandidates := make([]*typedCandidate, 0, len(prioritized))
+	for _, candidate := range prioritized {
+		scriptType, err := fodss.scriptType(candidate.Deposit)
+		if err != nil {
+			return nil, 0, fmt.Errorf(
+				"cannot get script type of deposit [%s]: [%v]",
+				candidate.DepositKey,
+				err,
+			)
+		}
+
+		if scriptType != bitcoin.P2SHScript && scriptType != bitcoin.P2WSHScript {
+			fnLogger.Warnf(
+				"skipping deposit [%s] with unexpected script type [%s]",
+				candidate.DepositKey,
+				scriptType,
+			)
+			continue
+		}
+
+		typedCandidates = append(
+			typedCandidates,
+			&typedCandidate{candidate, scriptType},
+		)
+	}
+
+	// Leaving dust deposits out increases the fee share of the remaining
+	// ones and may change the script type of the most urgent deposit so
+	// repeat until the selection contains no dust deposits.
+	dust := make(map[*depositSweepCandidate]bool)
+	for {
+		var selected []*depositSweepCandidate
+		var selectedScriptType bitcoin.ScriptType
+
+		for _, candidate := range typedCandidates {
+			if maxNumberOfDeposits > 0 && len(selected) == maxNumberOfDeposits {
+				break
+			}
+
+			if dust[candidate.depositSweepCandidate] {
+				continue
+			}
+
+			if len(selected) == 0 {
+				selectedScriptType = candidate.scriptType
+			}
+
+			// Deposits of the other script type are left for a later sweep.
+			if candidate.scriptType == selectedScriptType {
+				selected = append(selected, candidate.depositSweepCandidate)
+			}
+		}
+
+		if len(selected) == 0 {
+			return nil, 0, nil
+		}
+
+		fee, err := fodss.estimateFee(len(selected), selectedScriptType)
+		if err != nil {
+			return nil, 0, fmt.Errorf(
+				"cannot estimate fee of [%d] [%s] deposits sweep: [%v]",
+				len(selected),
+				selectedScriptType,
+				err,
+			)
+		}
+
+		feeShare := uint64(fee) / uint64(len(selected))
+
+		dustFound := false
+		for _, candidate := range selected {
+			if candidate.treasuryFee > 0 && feeShare > candidate.treasuryFee {
+				fnLogger.Infof(
+					"leaving out dust deposit [%s]; "+
+						"amount: [%d], treasury fee: [%d], fee share: [%d]",
+					candidate.DepositKey,
+					candidate.amount,
+					candidate.treasuryFee,
+					feeShare,
+				)
+
+				dust[candidate] = true
+				dustFound = true
+			}
+		}
+
+		if !dustFound {
+			fnLogger.Infof(
+				"selected [%d] [%s] deposits; estimated fee: [%d]",
+				len(selected),
+				selectedScriptType,
+				fee,
+			)
+
+			return selected, fee, nil
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+func TestNewDepositSweepSelection(t *testing.T) {
+	tests := map[string]struct {
+		name          string
+		expectedType  depositSweepSelection
+		expectedError error
+	}{
+		"empty name": {
+			name:         "",
+			expectedType: &oldestDepositSweepSelection{},
+		},
+		"oldest": {
+			name:         OldestDepositSweepSelection,
+			expectedType: &oldestDepositSweepSelection{},
+		},
+		"fee optimized": {
+			name:         FeeOptimizedDepositSweepSelection,
+			expectedType: &feeOptimizedDepositSweepSelection{},
+		},
+		"unsupported": {
+			name: "newest",
+			expectedError: fmt.Errorf(
+				"unsupported deposit sweep selection [newest]; " +
+					"expected one of: oldest, feeOptimized",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			selection, err := newDepositSweepSelection(
+				test.name,
+				NewLocalChain(),
+				NewLocalBitcoinChain(),
+			)
+
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Fatalf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if reflect.TypeOf(test.expectedType) != reflect.TypeOf(selection) {
+				t.Errorf(
+					"unexpected selection type\nexpected: [%T]\nactual:   [%T]",
+					test.expectedType,
+					selection,
+				)
+			}
+		})
+	}
+}
+
+func TestOldestDepositSweepSelection(t *testing.T) {
+	candidates := []*depositSweepCandidate{
+		newTestDepositSweepCandidate(0x01, 300, 0, 1000),
+		newTestDepositSweepCandidate(0x02, 100, 0, 3000),
+		newTestDepositSweepCandidate(0x03, 200, 0, 2000),
+	}
+
+	tests := map[string]struct {
+		maxNumberOfDeposits int
+		expectedDeposits    []byte
+	}{
+		"no limit": {
+			maxNumberOfDeposits: 0,
+			expectedDeposits:    []byte{0x02, 0x03, 0x01},
+		},
+		"limit": {
+			maxNumberOfDeposits: 2,
+			expectedDeposits:    []byte{0x02, 0x03},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			selected, fee, err := (&oldestDepositSweepSelection{}).selectDeposits(
+				&testutils.MockLogger{},
+				candidates,
+				test.maxNumberOfDeposits,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertSelectedDeposits(t, test.expectedDeposits, selected)
+			testutils.AssertIntsEqual(t, "fee", 0, int(fee))
+		})
+	}
+}
+
+// testDepositSweepSelectionTimeNow is the current time seen by the deposit
+// sweep selection in tests.
+var testDepositSweepSelectionTimeNow = time.Unix(500, 0)
+
+func TestFeeOptimizedDepositSweepSelection(t *testing.T) {
+	tests := map[string]struct {
+		candidates          []*depositSweepCandidate
+		scriptTypes         map[byte]bitcoin.ScriptType
+		refundSafetyMargin  uint32
+		maxNumberOfDeposits int
+		expectedDeposits    []byte
+		expectedFee         int64
+	}{
+		"deposits nearing refund locktime first": {
+			candidates: []*depositSweepCandidate{
+				newTestDepositSweepCandidate(0x01, 100, 0, 3000),
+				newTestDepositSweepCandidate(0x02, 200, 0, 1000),
+				newTestDepositSweepCandidate(0x03, 300, 0, 2000),
+				newTestDepositSweepCandidate(0x04, 400, 0, 1000),
+			},
+			maxNumberOfDeposits: 3,
+			expectedDeposits:    []byte{0x02, 0x04, 0x03},
+			// 3 P2WSH deposits with fee 1000 each.
+			expectedFee: 3000,
+		},
+		"deposits of the most urgent deposit's script type only": {
+			candidates: []*depositSweepCandidate{
+				newTestDepositSweepCandidate(0x01, 100, 0, 1000),
+				newTestDepositSweepCandidate(0x02, 200, 0, 2000),
+				newTestDepositSweepCandidate(0x03, 300, 0, 3000),
+				newTestDepositSweepCandidate(0x04, 400, 0, 4000),
+			},
+			scriptTypes: map[byte]bitcoin.ScriptType{
+				0x01: bitcoin.P2SHScript,
+				0x03: bitcoin.P2SHScript,
+				0x04: bitcoin.NonStandardScript,
+			},
+			expectedDeposits: []byte{0x01, 0x03},
+			// 2 P2SH deposits with fee 2000 each.
+			expectedFee: 4000,
+		},
+		"dust deposits left out": {
+			candidates: []*depositSweepCandidate{
+				newTestDepositSweepCandidate(0x01, 100, 5000, 1000),
+				newTestDepositSweepCandidate(0x02, 200, 900, 2000),
+				newTestDepositSweepCandidate(0x03, 300, 0, 3000),
+				newTestDepositSweepCandidate(0x04, 400, 5000, 4000),
+			},
+			maxNumberOfDeposits: 3,
+			// The deposit 0x02 treasury fee is lower than its fee share;
+			// the deposit 0x04 takes its place.
+			expectedDeposits: []byte{0x01, 0x03, 0x04},
+			expectedFee:      3000,
+		},
+		"script type of the most urgent deposit left out as dust": {
+			candidates: []*depositSweepCandidate{
+				newTestDepositSweepCandidate(0x01, 100, 1500, 1000),
+				newTestDepositSweepCandidate(0x02, 200, 0, 2000),
+				newTestDepositSweepCandidate(0x03, 300, 0, 3000),
+			},
+			scriptTypes: map[byte]bitcoin.ScriptType{
+				0x01: bitcoin.P2SHScript,
+			},
+			expectedDeposits: []byte{0x02, 0x03},
+			expectedFee:      2000,
+		},
+		"all deposits left out as dust": {
+			candidates: []*depositSweepCandidate{
+				newTestDepositSweepCandidate(0x01, 100, 500, 1000),
+				newTestDepositSweepCandidate(0x02, 200, 900, 2000),
+			},
+			expectedDeposits: nil,
+			expectedFee:      0,
+		},
+		"deposits within the refund safety margin left out": {
+			candidates: []*depositSweepCandidate{
+				newTestDepositSweepCandidate(0x01, 100, 0, 400),
+				newTestDepositSweepCandidate(0x02, 200, 0, 1100),
+				newTestDepositSweepCandidate(0x03, 300, 0, 1101),
+				newTestDepositSweepCandidate(0x04, 400, 0, 2000),
+			},
+			// The deposit 0x01 is already refundable and the deposit 0x02
+			// is within the margin of 600 seconds from now.
+			refundSafetyMargin: 600,
+			expectedDeposits:   []byte{0x03, 0x04},
+			expectedFee:        2000,
+		},
+		"no candidates": {
+			candidates:       nil,
+			expectedDeposits: nil,
+			expectedFee:      0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			selection := &feeOptimizedDepositSweepSelection{
+				scriptType: func(deposit *Deposit) (bitcoin.ScriptType, error) {
+					scriptType, ok := test.scriptTypes[deposit.FundingTxHash[0]]
+					if !ok {
+						return bitcoin.P2WSHScript, nil
+					}
+					return scriptType, nil
+				},
+				estimateFee: func(
+					depositsCount int,
+					scriptType bitcoin.ScriptType,
+				) (int64, error) {
+					feePerDeposit := int64(1000)
+					if scriptType == bitcoin.P2SHScript {
+						feePerDeposit = 2000
+					}
+					return int64(depositsCount) * feePerDeposit, nil
+				},
+				refundSafetyMargin: func() (uint32, error) {
+					return test.refundSafetyMargin, nil
+				},
+				timeNow: func() time.Time {
+					return testDepositSweepSelectionTimeNow
+				},
+			}
+
+			selected, fee, err := selection.selectDeposits(
+				&testutils.MockLogger{},
+				test.candidates,
+				test.maxNumberOfDeposits,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertSelectedDeposits(t, test.expectedDeposits, selected)
+			testutils.AssertIntsEqual(
+				t,
+				"fee",
+				int(test.expectedFee),
+				int(fee),
+			)
+		})
+	}
+}
+
+func TestFeeOptimizedDepositSweepSelection_EstimationError(t *testing.T) {
+	selection := &feeOptimizedDepositSweepSelection{
+		scriptType: func(deposit *Deposit) (bitcoin.ScriptType, error) {
+			return bitcoin.P2WSHScript, nil
+		},
+		estimateFee: func(
+			depositsCount int,
+			scriptType bitcoin.ScriptType,
+		) (int64, error) {
+			return 0, fmt.Errorf("estimated fee exceeds the maximum fee")
+		},
+		refundSafetyMargin: func() (uint32, error) {
+			return 0, nil
+		},
+		timeNow: func() time.Time {
+			return testDepositSweepSelectionTimeNow
+		},
+	}
+
+	_, _, err := selection.selectDeposits(
+		&testutils.MockLogger{},
+		[]*depositSweepCandidate{
+			newTestDepositSweepCandidate(0x01, 100, 0, 1000),
+		},
+		0,
+	)
+
+	expectedErr := fmt.Errorf(
+		"cannot estimate fee of [1] [P2WSH] deposits sweep: " +
+			"[estimated fee exceeds the maximum fee]",
+	)
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
+
+func TestDepositSweepSelection_CandidatesLimit(t *testing.T) {
+	tests := map[string]struct {
+		selection     depositSweepSelection
+		expectedLimit int
+	}{
+		"oldest": {
+			selection:     &oldestDepositSweepSelection{},
+			expectedLimit: 5,
+		},
+		"fee optimized": {
+			selection:     &feeOptimizedDepositSweepSelection{},
+			expectedLimit: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testutils.AssertIntsEqual(
+				t,
+				"candidates limit",
+				test.expectedLimit,
+				test.selection.candidatesLimit(5),
+			)
+		})
+	}
+}
+
+// newTestDepositSweepCandidate creates a deposit sweep candidate whose funding
+// transaction hash starts with the given id byte.
+func newTestDepositSweepCandidate(
+	id byte,
+	revealBlock uint64,
+	treasuryFee uint64,
+	refundLocktime uint32,
+) *depositSweepCandidate {
+	return &depositSweepCandidate{
+		Deposit: &Deposit{
+			DepositReference: DepositReference{
+				FundingTxHash: bitcoin.Hash{id},
+				RevealBlock:   revealBlock,
+			},
+			DepositKey: fmt.Sprintf("0x%02x", id),
+		},
+		amount:         100000,
+		treasuryFee:    treasuryFee,
+		refundLocktime: refundLocktime,
+	}
+}
+
+func assertSelectedDeposits(
+	t *testing.T,
+	expectedDeposits []byte,
+	selected []*depositSweepCandidate,
+) {
+	var actualDeposits []byte
+	for _, deposit := range selected {
+		actualDeposits = append(actualDeposits, deposit.FundingTxHash[0])
+	}
+
+	if !reflect.DeepEqual(expectedDeposits, actualDeposits) {
+		t.Errorf(
+			"unexpected deposits\nexpected: %x\nactual:   %x",
+			expectedDeposits,
+			actualDeposits,
+		)
+	}
+}
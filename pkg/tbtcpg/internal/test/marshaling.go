@@ -324,7 +324,9 @@ func (fprts *FindPendingRedemptionsTestScenario) UnmarshalJSON(data []byte) erro
 
 	fprts.MaxNumberOfRequests = unmarshaled.MaxNumberOfRequests
 
-	now := time.Now()
+	// Block timestamps have a one second resolution.
+	now := time.Now().Truncate(time.Second)
+	fprts.ChainParameters.CurrentBlockTime = now
 	currentBlock := fprts.ChainParameters.CurrentBlock
 	averageBlockTime := fprts.ChainParameters.AverageBlockTime
 
@@ -118,6 +118,7 @@ type FindPendingRedemptionsTestScenario struct {
 	ChainParameters struct {
 		AverageBlockTime time.Duration
 		CurrentBlock     uint64
+		CurrentBlockTime time.Time
 		RequestTimeout   uint32
 		RequestMinAge    uint32
 	}
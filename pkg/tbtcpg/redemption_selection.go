@@ -0,0 +1,338 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-log/v2"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+const (
+	// OldestRedemptionSelection is the name of the redemption selection
+	// strategy redeeming the oldest pending redemption requests first.
+	OldestRedemptionSelection = "oldest"
+	// FeePriorityRedemptionSelection is the name of the redemption selection
+	// strategy redeeming the requests nearing their timeout first and the
+	// other requests in the order of their maximum transaction fee, leaving
+	// out requests whose maximum fee is lower than their share of the
+	// redemption transaction fee.
+	FeePriorityRedemptionSelection = "feePriority"
+)
+
+// redemptionUrgencyTimeoutDivisor determines when a pending redemption request
+// is considered nearing its timeout by the fee priority redemption selection:
+// when less than 1/redemptionUrgencyTimeoutDivisor of the redemption timeout
+// remains.
+const redemptionUrgencyTimeoutDivisor = 4
+
+// redemptionSelection is a strategy selecting pending redemption requests to
+// redeem. The selection is made only by the coordination leader proposing
+// the redemption and the resulting proposal is validated on-chain by the
+// followers so operators of a wallet may use different strategies.
+type redemptionSelection interface {
+	// selectRedemptions selects at most maxNumberOfRequests requests to
+	// redeem out of the given pending requests, in the order they should be
+	// redeemed in. A maxNumberOfRequests of 0 means no limit. The timeNow
+	// is the reference time the age of the requests is computed against.
+	// The proportionalFee determines whether the redemption transaction fee
+	// is split between the requests in proportion to their maximum fees
+	// rather than evenly. It also returns the estimated fee of the
+	// redemption transaction or 0 if the strategy leaves the fee estimation
+	// to the proposal.
+	selectRedemptions(
+		fnLogger log.StandardLogger,
+		candidates []*RedemptionRequest,
+		maxNumberOfRequests int,
+		requestTimeout time.Duration,
+		timeNow time.Time,
+		proportionalFee bool,
+	) ([]*RedemptionRequest, int64, error)
+	// candidatesLimit returns the number of the oldest pending requests the
+	// strategy needs to select at most maxNumberOfRequests requests. A limit
+	// of 0 means all the pending requests are needed.
+	candidatesLimit(maxNumberOfRequests int) int
+}
+
+// newRedemptionSelection creates the redemption selection strategy with the
+// given name. An empty name denotes the oldest first strategy.
+func newRedemptionSelection(
+	name string,
+	btcChain bitcoin.Chain,
+) (redemptionSelection, error) {
+	switch name {
+	case "", OldestRedemptionSelection:
+		return &oldestRedemptionSelection{}, nil
+	case FeePriorityRedemptionSelection:
+		return newFeePriorityRedemptionSelection(btcChain), nil
+	default:
+		return nil, fmt.Errorf(
+			"unsupported redemption selection [%s]; expected one of: %s, %s",
+			name,
+			OldestRedemptionSelection,
+			FeePriorityRedemptionSelection,
+		)
+	}
+}
+
+// oldestRedemptionSelection is a redemption selection strategy taking the
+// requests in the order they were created.
+type oldestRedemptionSelection struct{}
+
+func (ors *oldestRedemptionSelection) selectRedemptions(
+	fnLogger log.StandardLogger,
+	candidates []*RedemptionRequest,
+	maxNumberOfRequests int,
+	requestTimeout time.Duration,
+	timeNow time.Time,
+	proportionalFee bool,
+) ([]*RedemptionRequest, int64, error) {
+	selected := make([]*RedemptionRequest, len(candidates))
+	copy(selected, candidates)
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].RequestedAt.Before(selected[j].RequestedAt)
+	})
+
+	if maxNumberOfRequests > 0 && len(selected) > maxNumberOfRequests {
+		selected = selected[:maxNumberOfRequests]
+	}
+
+	return selected, 0, nil
+}
+
+func (ors *oldestRedemptionSelection) candidatesLimit(
+	maxNumberOfRequests int,
+) int {
+	// The oldest requests are exactly the ones selected.
+	return maxNumberOfRequests
+}
+
+// feePriorityRedemptionSelection is a redemption selection strategy
+// maximizing the number of requests that can be redeemed when Bitcoin fees
+// are high.
+//
+// Requests with less than 1/redemptionUrgencyTimeoutDivisor of the redemption
+// timeout remaining are taken first, oldest first, so that they are not
+// starved by newer requests. The other requests are taken in the order of
+// their maximum transaction fee, highest first. If the redemption
+// transaction fee is split evenly between the redeemed requests, requests
+// whose maximum fee is lower than their fee share, including the remainder
+// incurred by the last request, would make the whole proposal invalid and
+// are left out. If the fee is split in proportion to the requests' maximum
+// fees, the shares exceed the maximum fees only if the fee exceeds the sum
+// of the maximum fees; the requests with the lowest maximum fee are then
+// left out one by one, requests nearing their timeout last.
+type feePriorityRedemptionSelection struct {
+	// estimateFee returns the estimated fee of a redemption transaction
+	// paying the given redeemers output scripts.
+	estimateFee func(redeemersOutputScripts []bitcoin.Script) (int64, error)
+}
+
+func newFeePriorityRedemptionSelection(
+	btcChain bitcoin.Chain,
+) *feePriorityRedemptionSelection {
+	return &feePriorityRedemptionSelection{
+		estimateFee: func(
+			redeemersOutputScripts []bitcoin.Script,
+		) (int64, error) {
+			return EstimateRedemptionFee(btcChain, redeemersOutputScripts)
+		},
+	}
+}
+
+func (fprs *feePriorityRedemptionSelection) candidatesLimit(
+	maxNumberOfRequests int,
+) int {
+	// Any request may have the highest maximum fee so the strategy needs
+	// to see all of them.
+	return 0
+}
+
+func (fprs *feePriorityRedemptionSelection) selectRedemptions(
+	fnLogger log.StandardLogger,
+	candidates []*RedemptionRequest,
+	maxNumberOfRequests int,
+	requestTimeout time.Duration,
+	timeNow time.Time,
+	proportionalFee bool,
+) ([]*RedemptionRequest, int64, error) {
+	// Requests created before this time have less than the urgency fraction
+	// of the timeout remaining.
+	urgentBefore := timeNow.Add(
+		-(requestTimeout - requestTimeout/redemptionUrgencyTimeoutDivisor),
+	)
+	isUrgent := func(request *RedemptionRequest) bool {
+		return !request.RequestedAt.After(urgentBefore)
+	}
+
+	prioritized := make([]*RedemptionRequest, len(candidates))
+	copy(prioritized, candidates)
+
+	sort.SliceStable(prioritized, func(i, j int) bool {
+		iUrgent, jUrgent := isUrgent(prioritized[i]), isUrgent(prioritized[j])
+		if iUrgent != jUrgent {
+			return iUrgent
+		}
+
+		if !iUrgent && prioritized[i].TxMaxFee != prioritized[j].TxMaxFee {
+			return prioritized[i].TxMaxFee > prioritized[j].TxMaxFee
+		}
+
+		return prioritized[i].RequestedAt.Before(prioritized[j].RequestedAt)
+	})
+
+	// Leaving requests out changes the fee share of the remaining ones so
+	// repeat until no selected request breaches its maximum fee.
+	breachingRequests := make(map[*RedemptionRequest]bool)
+	for {
+		var selected []*RedemptionRequest
+		var redeemersOutputScripts []bitcoin.Script
+
+		for _, candidate := range prioritized {
+			if maxNumberOfRequests > 0 && len(selected) == maxNumberOfRequests {
+				break
+			}
+
+			if breachingRequests[candidate] {
+				continue
+			}
+
+			selected = append(selected, candidate)
+			redeemersOutputScripts = append(
+				redeemersOutputScripts,
+				candidate.RedeemerOutputScript,
+			)
+		}
+
+		if len(selected) == 0 {
+			return nil, 0, nil
+		}
+
+		fee, err := fprs.estimateFee(redeemersOutputScripts)
+		if err != nil {
+			return nil, 0, fmt.Errorf(
+				"cannot estimate fee of [%d] requests redemption: [%v]",
+				len(selected),
+				err,
+			)
+		}
+
+		if proportionalFee {
+			breaching := fprs.proportionalFeeBreaching(selected, fee, isUrgent)
+			if breaching == nil {
+				fnLogger.Infof(
+					"selected [%d] redemption requests; estimated fee: [%d]",
+					len(selected),
+					fee,
+				)
+
+				return selected, fee, nil
+			}
+
+			if isUrgent(breaching) {
+				fnLogger.Warnf(
+					"leaving out redemption request [%s] nearing its "+
+						"timeout; max fee: [%d], total fee: [%d]",
+					breaching.RedemptionKey,
+					breaching.TxMaxFee,
+					fee,
+				)
+			} else {
+				fnLogger.Infof(
+					"leaving out redemption request [%s]; "+
+						"max fee: [%d], total fee: [%d]",
+					breaching.RedemptionKey,
+					breaching.TxMaxFee,
+					fee,
+				)
+			}
+
+			breachingRequests[breaching] = true
+			continue
+		}
+
+		requestsCount := int64(len(selected))
+		maxFeeShare := fee/requestsCount + fee%requestsCount
+
+		breachFound := false
+		for _, request := range selected {
+			if request.TxMaxFee < uint64(maxFeeShare) {
+				if isUrgent(request) {
+					fnLogger.Warnf(
+						"leaving out redemption request [%s] nearing its "+
+							"timeout; max fee: [%d], fee share: [%d]",
+						request.RedemptionKey,
+						request.TxMaxFee,
+						maxFeeShare,
+					)
+				} else {
+					fnLogger.Infof(
+						"leaving out redemption request [%s]; "+
+							"max fee: [%d], fee share: [%d]",
+						request.RedemptionKey,
+						request.TxMaxFee,
+						maxFeeShare,
+					)
+				}
+
+				breachingRequests[request] = true
+				breachFound = true
+			}
+		}
+
+		if !breachFound {
+			fnLogger.Infof(
+				"selected [%d] redemption requests; estimated fee: [%d]",
+				len(selected),
+				fee,
+			)
+
+			return selected, fee, nil
+		}
+	}
+}
+
+// proportionalFeeBreaching returns the request to leave out of the given
+// selected requests if the given fee, split in proportion to the requests'
+// maximum fees, exceeds the maximum fee of any request, or nil otherwise.
+// The request with the lowest maximum fee is left out, preferring requests
+// not nearing their timeout.
+func (fprs *feePriorityRedemptionSelection) proportionalFeeBreaching(
+	selected []*RedemptionRequest,
+	fee int64,
+	isUrgent func(request *RedemptionRequest) bool,
+) *RedemptionRequest {
+	txMaxFeesSum := uint64(0)
+	for _, request := range selected {
+		txMaxFeesSum += request.TxMaxFee
+	}
+
+	if uint64(fee) <= txMaxFeesSum {
+		return nil
+	}
+
+	var breaching *RedemptionRequest
+	for _, request := range selected {
+		if breaching == nil {
+			breaching = request
+			continue
+		}
+
+		requestUrgent, breachingUrgent := isUrgent(request), isUrgent(breaching)
+		if requestUrgent != breachingUrgent {
+			if breachingUrgent {
+				breaching = request
+			}
+			continue
+		}
+
+		if request.TxMaxFee < breaching.TxMaxFee {
+			breaching = request
+		}
+	}
+
+	return breaching
+}
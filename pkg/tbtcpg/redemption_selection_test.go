@@ -0,0 +1,313 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+)
+
+// testRedemptionTimeNow is the reference time the ages of test redemption
+// requests are computed against.
+var testRedemptionTimeNow = time.Unix(1700000000, 0)
+
+func TestNewRedemptionSelection(t *testing.T) {
+	tests := map[string]struct {
+		name          string
+		expectedType  redemptionSelection
+		expectedError error
+	}{
+		"empty name": {
+			name:         "",
+			expectedType: &oldestRedemptionSelection{},
+		},
+		"oldest": {
+			name:         OldestRedemptionSelection,
+			expectedType: &oldestRedemptionSelection{},
+		},
+		"fee priority": {
+			name:         FeePriorityRedemptionSelection,
+			expectedType: &feePriorityRedemptionSelection{},
+		},
+		"unsupported": {
+			name: "newest",
+			expectedError: fmt.Errorf(
+				"unsupported redemption selection [newest]; " +
+					"expected one of: oldest, feePriority",
+			),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			selection, err := newRedemptionSelection(
+				test.name,
+				NewLocalBitcoinChain(),
+			)
+
+			if !reflect.DeepEqual(test.expectedError, err) {
+				t.Fatalf(
+					"unexpected error\nexpected: [%v]\nactual:   [%v]",
+					test.expectedError,
+					err,
+				)
+			}
+
+			if reflect.TypeOf(test.expectedType) != reflect.TypeOf(selection) {
+				t.Errorf(
+					"unexpected selection type\nexpected: [%T]\nactual:   [%T]",
+					test.expectedType,
+					selection,
+				)
+			}
+		})
+	}
+}
+
+func TestOldestRedemptionSelection(t *testing.T) {
+	candidates := []*RedemptionRequest{
+		newTestRedemptionRequest(0x01, 1*time.Hour, 3000),
+		newTestRedemptionRequest(0x02, 3*time.Hour, 1000),
+		newTestRedemptionRequest(0x03, 2*time.Hour, 2000),
+	}
+
+	tests := map[string]struct {
+		maxNumberOfRequests int
+		expectedRequests    []byte
+	}{
+		"no limit": {
+			maxNumberOfRequests: 0,
+			expectedRequests:    []byte{0x02, 0x03, 0x01},
+		},
+		"limit": {
+			maxNumberOfRequests: 2,
+			expectedRequests:    []byte{0x02, 0x03},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			selected, fee, err := (&oldestRedemptionSelection{}).selectRedemptions(
+				&testutils.MockLogger{},
+				candidates,
+				test.maxNumberOfRequests,
+				24*time.Hour,
+				testRedemptionTimeNow,
+				false,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertSelectedRedemptions(t, test.expectedRequests, selected)
+			testutils.AssertIntsEqual(t, "fee", 0, int(fee))
+		})
+	}
+}
+
+func TestFeePriorityRedemptionSelection(t *testing.T) {
+	// With the 24h timeout, requests older than 18h are nearing their
+	// timeout.
+	requestTimeout := 24 * time.Hour
+
+	tests := map[string]struct {
+		candidates          []*RedemptionRequest
+		feePerRequest       int64
+		feeRemainder        int64
+		proportionalFee     bool
+		maxNumberOfRequests int
+		expectedRequests    []byte
+		expectedFee         int64
+	}{
+		"highest max fee first": {
+			candidates: []*RedemptionRequest{
+				newTestRedemptionRequest(0x01, 3*time.Hour, 1000),
+				newTestRedemptionRequest(0x02, 2*time.Hour, 3000),
+				newTestRedemptionRequest(0x03, 1*time.Hour, 2000),
+				newTestRedemptionRequest(0x04, 4*time.Hour, 3000),
+			},
+			feePerRequest:       500,
+			maxNumberOfRequests: 3,
+			expectedRequests:    []byte{0x04, 0x02, 0x03},
+			expectedFee:         1500,
+		},
+		"requests nearing timeout first": {
+			candidates: []*RedemptionRequest{
+				newTestRedemptionRequest(0x01, 19*time.Hour, 1000),
+				newTestRedemptionRequest(0x02, 2*time.Hour, 3000),
+				newTestRedemptionRequest(0x03, 20*time.Hour, 1500),
+				newTestRedemptionRequest(0x04, 1*time.Hour, 2000),
+			},
+			feePerRequest:       500,
+			maxNumberOfRequests: 3,
+			expectedRequests:    []byte{0x03, 0x01, 0x02},
+			expectedFee:         1500,
+		},
+		"requests breaching max fee left out": {
+			candidates: []*RedemptionRequest{
+				newTestRedemptionRequest(0x01, 19*time.Hour, 900),
+				newTestRedemptionRequest(0x02, 2*time.Hour, 3000),
+				newTestRedemptionRequest(0x03, 3*time.Hour, 500),
+				newTestRedemptionRequest(0x04, 1*time.Hour, 2000),
+			},
+			feePerRequest:       1000,
+			maxNumberOfRequests: 3,
+			// Requests 0x01 and 0x03 cannot afford their fee shares.
+			expectedRequests: []byte{0x02, 0x04},
+			expectedFee:      2000,
+		},
+		"fee remainder considered": {
+			candidates: []*RedemptionRequest{
+				newTestRedemptionRequest(0x01, 2*time.Hour, 1002),
+				newTestRedemptionRequest(0x02, 1*time.Hour, 1001),
+			},
+			// Total fee of 2003 gives the 1001 fee share and the 1002 fee
+			// share of the last request.
+			feePerRequest:    1001,
+			feeRemainder:     1,
+			expectedRequests: []byte{0x01},
+			expectedFee:      1002,
+		},
+		"all requests breaching max fee": {
+			candidates: []*RedemptionRequest{
+				newTestRedemptionRequest(0x01, 2*time.Hour, 900),
+				newTestRedemptionRequest(0x02, 1*time.Hour, 800),
+			},
+			feePerRequest:    1000,
+			expectedRequests: nil,
+			expectedFee:      0,
+		},
+		"proportional fee split": {
+			candidates: []*RedemptionRequest{
+				newTestRedemptionRequest(0x01, 19*time.Hour, 900),
+				newTestRedemptionRequest(0x02, 2*time.Hour, 3000),
+				newTestRedemptionRequest(0x03, 3*time.Hour, 500),
+				newTestRedemptionRequest(0x04, 1*time.Hour, 2000),
+			},
+			feePerRequest:       1000,
+			proportionalFee:     true,
+			maxNumberOfRequests: 3,
+			// The request 0x01 cannot afford the even fee share but the
+			// total fee does not exceed the sum of the max fees.
+			expectedRequests: []byte{0x01, 0x02, 0x04},
+			expectedFee:      3000,
+		},
+		"proportional fee split exceeding max fees": {
+			candidates: []*RedemptionRequest{
+				newTestRedemptionRequest(0x01, 19*time.Hour, 400),
+				newTestRedemptionRequest(0x02, 2*time.Hour, 1700),
+				newTestRedemptionRequest(0x03, 3*time.Hour, 500),
+			},
+			feePerRequest:   1000,
+			proportionalFee: true,
+			// The total fee of 3000 exceeds the sum of the max fees so the
+			// request 0x03 with the lowest max fee among the requests not
+			// nearing their timeout is left out.
+			expectedRequests: []byte{0x01, 0x02},
+			expectedFee:      2000,
+		},
+		"no candidates": {
+			candidates:       nil,
+			feePerRequest:    1000,
+			expectedRequests: nil,
+			expectedFee:      0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			selection := &feePriorityRedemptionSelection{
+				estimateFee: func(
+					redeemersOutputScripts []bitcoin.Script,
+				) (int64, error) {
+					requestsCount := int64(len(redeemersOutputScripts))
+					return requestsCount*test.feePerRequest + test.feeRemainder, nil
+				},
+			}
+
+			selected, fee, err := selection.selectRedemptions(
+				&testutils.MockLogger{},
+				test.candidates,
+				test.maxNumberOfRequests,
+				requestTimeout,
+				testRedemptionTimeNow,
+				test.proportionalFee,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assertSelectedRedemptions(t, test.expectedRequests, selected)
+			testutils.AssertIntsEqual(
+				t,
+				"fee",
+				int(test.expectedFee),
+				int(fee),
+			)
+		})
+	}
+}
+
+func TestRedemptionSelection_CandidatesLimit(t *testing.T) {
+	tests := map[string]struct {
+		selection     redemptionSelection
+		expectedLimit int
+	}{
+		"oldest": {
+			selection:     &oldestRedemptionSelection{},
+			expectedLimit: 5,
+		},
+		"fee priority": {
+			selection:     &feePriorityRedemptionSelection{},
+			expectedLimit: 0,
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testutils.AssertIntsEqual(
+				t,
+				"candidates limit",
+				test.expectedLimit,
+				test.selection.candidatesLimit(5),
+			)
+		})
+	}
+}
+
+// newTestRedemptionRequest creates a redemption request created the given
+// time ago whose redeemer output script starts with the given id byte.
+func newTestRedemptionRequest(
+	id byte,
+	age time.Duration,
+	txMaxFee uint64,
+) *RedemptionRequest {
+	return &RedemptionRequest{
+		RedemptionKey:        fmt.Sprintf("0x%02x", id),
+		RedeemerOutputScript: bitcoin.Script{id},
+		RequestedAt:          testRedemptionTimeNow.Add(-age),
+		TxMaxFee:             txMaxFee,
+	}
+}
+
+func assertSelectedRedemptions(
+	t *testing.T,
+	expectedRequests []byte,
+	selected []*RedemptionRequest,
+) {
+	var actualRequests []byte
+	for _, request := range selected {
+		actualRequests = append(actualRequests, request.RedeemerOutputScript[0])
+	}
+
+	if !reflect.DeepEqual(expectedRequests, actualRequests) {
+		t.Errorf(
+			"unexpected requests\nexpected: %x\nactual:   %x",
+			expectedRequests,
+			actualRequests,
+		)
+	}
+}
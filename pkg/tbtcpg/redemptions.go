@@ -16,17 +16,33 @@ import (
 
 // RedemptionTask is a task that may produce a redemption proposal.
 type RedemptionTask struct {
-	chain    Chain
-	btcChain bitcoin.Chain
+	chain     Chain
+	btcChain  bitcoin.Chain
+	selection redemptionSelection
 }
 
+// NewRedemptionTask creates a redemption task redeeming the oldest requests
+// first.
 func NewRedemptionTask(
 	chain Chain,
 	btcChain bitcoin.Chain,
+) *RedemptionTask {
+	return newRedemptionTask(
+		chain,
+		btcChain,
+		&oldestRedemptionSelection{},
+	)
+}
+
+func newRedemptionTask(
+	chain Chain,
+	btcChain bitcoin.Chain,
+	selection redemptionSelection,
 ) *RedemptionTask {
 	return &RedemptionTask{
-		chain:    chain,
-		btcChain: btcChain,
+		chain:     chain,
+		btcChain:  btcChain,
+		selection: selection,
 	}
 }
 
@@ -50,10 +66,11 @@ func (rt *RedemptionTask) Run(request *tbtc.CoordinationProposalRequest) (
 		)
 	}
 
-	redeemersOutputScripts, err := rt.FindPendingRedemptions(
+	redeemersOutputScripts, fee, err := rt.selectPendingRedemptions(
 		taskLogger,
 		walletPublicKeyHash,
 		redemptionMaxSize,
+		request.UpgradedCoordination,
 	)
 	if err != nil {
 		return nil, false, fmt.Errorf(
@@ -71,7 +88,7 @@ func (rt *RedemptionTask) Run(request *tbtc.CoordinationProposalRequest) (
 		taskLogger,
 		walletPublicKeyHash,
 		redeemersOutputScripts,
-		0,
+		fee,
 	)
 	if err != nil {
 		return nil, false, fmt.Errorf(
@@ -94,21 +111,46 @@ type RedemptionRequest struct {
 	RedeemerOutputScript bitcoin.Script
 	RequestedAt          time.Time
 	RequestedAmount      uint64
+	TxMaxFee             uint64
 }
 
 // FindPendingRedemptions finds pending redemptions requests for the
 // provided wallet. The returned value is a list of redeemers output
 // scripts that come from detected pending requests targeting this wallet.
 // The maxNumberOfRequests parameter is used as a ceiling for the number of
-// requests in the result. If number of discovered requests meets the
-// maxNumberOfRequests the function will stop fetching more requests.
+// requests in the result. The requests are chosen among all pending requests
+// of the wallet by the task's redemption selection strategy.
 func (rt *RedemptionTask) FindPendingRedemptions(
 	taskLogger log.StandardLogger,
 	walletPublicKeyHash [20]byte,
 	maxNumberOfRequests uint16,
 ) ([]bitcoin.Script, error) {
+	// The requests are not proposed in a coordination window so assume
+	// the even fee split valid in all the windows.
+	redeemersOutputScripts, _, err := rt.selectPendingRedemptions(
+		taskLogger,
+		walletPublicKeyHash,
+		maxNumberOfRequests,
+		false,
+	)
+
+	return redeemersOutputScripts, err
+}
+
+// selectPendingRedemptions finds pending redemption requests, as described in
+// FindPendingRedemptions. The proportionalFee determines whether the
+// redemption transaction fee is split between the requests in proportion to
+// their maximum fees. It also returns the redemption transaction fee
+// estimated by the redemption selection strategy or 0 if the strategy does
+// not estimate the fee.
+func (rt *RedemptionTask) selectPendingRedemptions(
+	taskLogger log.StandardLogger,
+	walletPublicKeyHash [20]byte,
+	maxNumberOfRequests uint16,
+	proportionalFee bool,
+) ([]bitcoin.Script, int64, error) {
 	if walletPublicKeyHash == [20]byte{} {
-		return nil, fmt.Errorf("wallet public key hash is required")
+		return nil, 0, fmt.Errorf("wallet public key hash is required")
 	}
 
 	taskLogger.Infof(
@@ -118,7 +160,7 @@ func (rt *RedemptionTask) FindPendingRedemptions(
 
 	blockCounter, err := rt.chain.BlockCounter()
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, 0, fmt.Errorf(
 			"failed to get block counter: [%w]",
 			err,
 		)
@@ -126,15 +168,27 @@ func (rt *RedemptionTask) FindPendingRedemptions(
 
 	currentBlockNumber, err := blockCounter.CurrentBlock()
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, 0, fmt.Errorf(
 			"failed to get current block number: [%w]",
 			err,
 		)
 	}
 
+	// Use the current block time rather than the local clock as the
+	// reference for request ages so the outcome depends only on the chain
+	// state.
+	currentBlockTimestamp, err := rt.chain.GetBlockTimestamp(currentBlockNumber)
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"failed to get current block timestamp: [%w]",
+			err,
+		)
+	}
+	timeNow := time.Unix(int64(currentBlockTimestamp), 0)
+
 	requestMinAge, err := rt.chain.GetRedemptionRequestMinAge()
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, 0, fmt.Errorf(
 			"failed to get redemption request minimum age: [%w]",
 			err,
 		)
@@ -142,7 +196,7 @@ func (rt *RedemptionTask) FindPendingRedemptions(
 
 	_, _, _, _, requestTimeout, _, _, err := rt.chain.GetRedemptionParameters()
 	if err != nil {
-		return nil, fmt.Errorf(
+		return nil, 0, fmt.Errorf(
 			"failed to get redemption parameters: [%w]",
 			err,
 		)
@@ -155,16 +209,32 @@ func (rt *RedemptionTask) FindPendingRedemptions(
 		rt.chain,
 		walletPublicKeyHash,
 		currentBlockNumber,
-		maxNumberOfRequests,
+		timeNow,
+		uint16(rt.selection.candidatesLimit(int(maxNumberOfRequests))),
 		requestTimeout,
 		requestMinAge,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get pending redemptions: [%w]", err)
+		return nil, 0, fmt.Errorf("cannot get pending redemptions: [%w]", err)
 	}
 
 	taskLogger.Infof("found [%d] redemption requests", len(pendingRedemptions))
 
+	pendingRedemptions, fee, err := rt.selection.selectRedemptions(
+		taskLogger,
+		pendingRedemptions,
+		int(maxNumberOfRequests),
+		time.Duration(requestTimeout)*time.Second,
+		timeNow,
+		proportionalFee,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf(
+			"cannot select redemption requests: [%w]",
+			err,
+		)
+	}
+
 	result := make([]bitcoin.Script, 0)
 
 	for _, pendingRedemption := range pendingRedemptions {
@@ -177,7 +247,7 @@ func (rt *RedemptionTask) FindPendingRedemptions(
 		result = append(result, pendingRedemption.RedeemerOutputScript)
 	}
 
-	return result, nil
+	return result, fee, nil
 }
 
 // ProposeRedemption returns a redemption proposal.
@@ -240,6 +310,7 @@ func findPendingRedemptions(
 	chain Chain,
 	walletPublicKeyHash [20]byte,
 	currentBlockNumber uint64,
+	timeNow time.Time,
 	requestsLimit uint16,
 	requestTimeout uint32,
 	requestMinAge uint32,
@@ -350,6 +421,7 @@ redemptionRequestedLoop:
 				RedeemerOutputScript: event.RedeemerOutputScript,
 				RequestedAt:          pendingRedemption.RequestedAt,
 				RequestedAmount:      pendingRedemption.RequestedAmount,
+				TxMaxFee:             pendingRedemption.TxMaxFee,
 			},
 		)
 	}
@@ -366,9 +438,6 @@ redemptionRequestedLoop:
 		},
 	)
 
-	// Capture time now for computations.
-	timeNow := time.Now()
-
 	// Only redemption requests in range:
 	// [now - requestTimeout, now - minAge]
 	// should be taken into consideration.
@@ -62,6 +62,10 @@ func TestRedemptionAction_FindPendingRedemptions(t *testing.T) {
 			blockCounter := tbtcpg.NewMockBlockCounter()
 			blockCounter.SetCurrentBlock(scenario.ChainParameters.CurrentBlock)
 			tbtcChain.SetBlockCounter(blockCounter)
+			tbtcChain.SetBlockTimestamp(
+				scenario.ChainParameters.CurrentBlock,
+				uint64(scenario.ChainParameters.CurrentBlockTime.Unix()),
+			)
 
 			// Set relevant governable parameters based on values provided by
 			// the scenario.
@@ -0,0 +1,235 @@
+package tbtcpg
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/keep-network/keep-core/pkg/bitcoin"
+	"github.com/keep-network/keep-core/pkg/chain"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+// simulationOperator is the placeholder operator executing the simulated
+// proposal task. Tasks need the executing operator only to submit
+// transactions, which never happens during the simulation.
+const simulationOperator = chain.Address("simulation")
+
+// errSimulatedSubmission is returned by the simulation chain instead of
+// submitting a transaction to the chain.
+var errSimulatedSubmission = fmt.Errorf(
+	"transaction submission skipped in simulation",
+)
+
+// ProposalSimulation is the result of a proposal task simulation.
+type ProposalSimulation struct {
+	// Action is the type of the simulated proposal task.
+	Action tbtc.WalletActionType
+	// Proposal is the proposal generated by the task; nil if the task
+	// completed without a proposal.
+	Proposal tbtc.CoordinationProposal
+	// Fee is the fee of the proposed Bitcoin transaction, in satoshi; nil if
+	// the proposal does not involve a Bitcoin transaction.
+	Fee *big.Int
+	// Validated is true if the proposal was checked by the on-chain
+	// validator.
+	Validated bool
+	// ValidationError is the error returned by the on-chain validator; nil
+	// if the proposal is valid.
+	ValidationError error
+	// SkippedSubmissions are the descriptions of the transactions the task
+	// would submit to the chain before proposing.
+	SkippedSubmissions []string
+}
+
+// SimulateProposal runs the proposal task of the given action type for the
+// given wallet against the current state of the chains, as the coordination
+// leader would do in a coordination window. The proposal is not broadcast,
+// and transactions the task would submit to the chain, e.g. the moving funds
+// commitment, are skipped. Unlike the coordination procedure, the on-chain
+// validation error of the generated proposal is not treated as the task's
+// failure but reported as part of the result.
+func SimulateProposal(
+	tbtcChain Chain,
+	btcChain bitcoin.Chain,
+	walletPublicKeyHash [20]byte,
+	action tbtc.WalletActionType,
+) (*ProposalSimulation, error) {
+	simChain := &simulationChain{Chain: tbtcChain}
+
+	var task ProposalTask
+	switch action {
+	case tbtc.ActionDepositSweep:
+		task = NewDepositSweepTask(simChain, btcChain)
+	case tbtc.ActionRedemption:
+		task = NewRedemptionTask(simChain, btcChain)
+	case tbtc.ActionHeartbeat:
+		task = NewHeartbeatTask(simChain)
+	case tbtc.ActionMovingFunds:
+		task = NewMovingFundsTask(simChain, btcChain)
+	case tbtc.ActionMovedFundsSweep:
+		task = NewMovedFundsSweepTask(simChain, btcChain)
+	default:
+		return nil, fmt.Errorf("unsupported action [%s]", action)
+	}
+
+	proposal, ok, err := task.Run(&tbtc.CoordinationProposalRequest{
+		WalletPublicKeyHash: walletPublicKeyHash,
+		WalletOperators:     []chain.Address{simulationOperator},
+		ExecutingOperator:   simulationOperator,
+		ActionsChecklist:    []tbtc.WalletActionType{action},
+	})
+
+	simulation := &ProposalSimulation{
+		Action:             action,
+		Validated:          simChain.validated,
+		ValidationError:    simChain.validationErr,
+		SkippedSubmissions: simChain.skippedSubmissions,
+	}
+
+	if err != nil {
+		// The task cannot propose before its transaction is submitted.
+		if errors.Is(err, errSimulatedSubmission) {
+			return simulation, nil
+		}
+
+		return nil, fmt.Errorf("error while running proposal task: [%w]", err)
+	}
+
+	if ok {
+		simulation.Proposal = proposal
+		simulation.Fee = proposalFee(proposal)
+	}
+
+	return simulation, nil
+}
+
+// proposalFee returns the fee of the Bitcoin transaction of the given
+// proposal or nil if the proposal does not involve a Bitcoin transaction.
+func proposalFee(proposal tbtc.CoordinationProposal) *big.Int {
+	switch p := proposal.(type) {
+	case *tbtc.DepositSweepProposal:
+		return p.SweepTxFee
+	case *tbtc.RedemptionProposal:
+		return p.RedemptionTxFee
+	case *tbtc.MovingFundsProposal:
+		return p.MovingFundsTxFee
+	case *tbtc.MovedFundsSweepProposal:
+		return p.SweepTxFee
+	default:
+		return nil
+	}
+}
+
+// simulationChain is the chain used by the simulated proposal tasks. It
+// records the on-chain validation result instead of returning it to the
+// task so that the task returns the generated proposal even if it is not
+// valid. It also skips transaction submissions.
+type simulationChain struct {
+	Chain
+
+	mutex              sync.Mutex
+	validated          bool
+	validationErr      error
+	skippedSubmissions []string
+}
+
+func (sc *simulationChain) recordValidation(err error) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.validated = true
+	sc.validationErr = err
+
+	return nil
+}
+
+func (sc *simulationChain) ValidateDepositSweepProposal(
+	walletPublicKeyHash [20]byte,
+	proposal *tbtc.DepositSweepProposal,
+	depositsExtraInfo []struct {
+		*tbtc.Deposit
+		FundingTx *bitcoin.Transaction
+	},
+) error {
+	return sc.recordValidation(
+		sc.Chain.ValidateDepositSweepProposal(
+			walletPublicKeyHash,
+			proposal,
+			depositsExtraInfo,
+		),
+	)
+}
+
+func (sc *simulationChain) ValidateRedemptionProposal(
+	walletPublicKeyHash [20]byte,
+	proposal *tbtc.RedemptionProposal,
+) error {
+	return sc.recordValidation(
+		sc.Chain.ValidateRedemptionProposal(walletPublicKeyHash, proposal),
+	)
+}
+
+func (sc *simulationChain) ValidateHeartbeatProposal(
+	walletPublicKeyHash [20]byte,
+	proposal *tbtc.HeartbeatProposal,
+) error {
+	return sc.recordValidation(
+		sc.Chain.ValidateHeartbeatProposal(walletPublicKeyHash, proposal),
+	)
+}
+
+func (sc *simulationChain) ValidateMovingFundsProposal(
+	walletPublicKeyHash [20]byte,
+	mainUTXO *bitcoin.UnspentTransactionOutput,
+	proposal *tbtc.MovingFundsProposal,
+) error {
+	return sc.recordValidation(
+		sc.Chain.ValidateMovingFundsProposal(
+			walletPublicKeyHash,
+			mainUTXO,
+			proposal,
+		),
+	)
+}
+
+func (sc *simulationChain) ValidateMovedFundsSweepProposal(
+	walletPublicKeyHash [20]byte,
+	proposal *tbtc.MovedFundsSweepProposal,
+) error {
+	return sc.recordValidation(
+		sc.Chain.ValidateMovedFundsSweepProposal(walletPublicKeyHash, proposal),
+	)
+}
+
+func (sc *simulationChain) GetOperatorID(
+	operatorAddress chain.Address,
+) (chain.OperatorID, error) {
+	if operatorAddress == simulationOperator {
+		return 0, nil
+	}
+
+	return sc.Chain.GetOperatorID(operatorAddress)
+}
+
+func (sc *simulationChain) SubmitMovingFundsCommitment(
+	walletPublicKeyHash [20]byte,
+	walletMainUTXO bitcoin.UnspentTransactionOutput,
+	walletMembersIDs []uint32,
+	walletMemberIndex uint32,
+	targetWallets [][20]byte,
+) error {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.skippedSubmissions = append(
+		sc.skippedSubmissions,
+		fmt.Sprintf(
+			"moving funds commitment with [%v] target wallets",
+			len(targetWallets),
+		),
+	)
+
+	return errSimulatedSubmission
+}
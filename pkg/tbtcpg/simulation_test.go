@@ -0,0 +1,107 @@
+package tbtcpg
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/keep-network/keep-core/internal/testutils"
+	"github.com/keep-network/keep-core/pkg/tbtc"
+)
+
+func TestSimulateProposal(t *testing.T) {
+	expectedProposal := &tbtc.HeartbeatProposal{
+		Message: [16]byte{
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xe0, 0xd7, 0x5a, 0xec, 0xd2, 0x9e, 0x5b, 0xca,
+		},
+	}
+
+	tests := map[string]struct {
+		validationResult        bool
+		expectedValidationError error
+	}{
+		"valid proposal": {
+			validationResult:        true,
+			expectedValidationError: nil,
+		},
+		"invalid proposal": {
+			validationResult:        false,
+			expectedValidationError: fmt.Errorf("validation failed"),
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			tbtcChain := NewLocalChain()
+			blockCounter := NewMockBlockCounter()
+
+			blockCounter.SetCurrentBlock(900)
+			tbtcChain.SetBlockCounter(blockCounter)
+
+			tbtcChain.SetHeartbeatProposalValidationResult(
+				expectedProposal,
+				test.validationResult,
+			)
+
+			simulation, err := SimulateProposal(
+				tbtcChain,
+				nil,
+				[20]byte{0x01, 0x02},
+				tbtc.ActionHeartbeat,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The proposal is returned even if it is not valid.
+			if !reflect.DeepEqual(expectedProposal, simulation.Proposal) {
+				t.Errorf(
+					"unexpected proposal\nexpected: %v\nactual:   %v",
+					expectedProposal,
+					simulation.Proposal,
+				)
+			}
+
+			testutils.AssertBoolsEqual(
+				t,
+				"validated",
+				true,
+				simulation.Validated,
+			)
+
+			if !reflect.DeepEqual(
+				test.expectedValidationError,
+				simulation.ValidationError,
+			) {
+				t.Errorf(
+					"unexpected validation error\nexpected: %v\nactual:   %v",
+					test.expectedValidationError,
+					simulation.ValidationError,
+				)
+			}
+
+			if simulation.Fee != nil {
+				t.Errorf("unexpected fee: [%v]", simulation.Fee)
+			}
+		})
+	}
+}
+
+func TestSimulateProposal_UnsupportedAction(t *testing.T) {
+	_, err := SimulateProposal(
+		NewLocalChain(),
+		nil,
+		[20]byte{0x01, 0x02},
+		tbtc.ActionNoop,
+	)
+
+	expectedErr := fmt.Errorf("unsupported action [Noop]")
+	if !reflect.DeepEqual(expectedErr, err) {
+		t.Errorf(
+			"unexpected error\nexpected: [%v]\nactual:   [%v]",
+			expectedErr,
+			err,
+		)
+	}
+}
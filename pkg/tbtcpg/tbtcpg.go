@@ -31,14 +31,49 @@ type ProposalGenerator struct {
 	tasks []ProposalTask
 }
 
+// ProposalGeneratorConfig carries the config of the proposal generator.
+// Empty names denote the default strategies.
+type ProposalGeneratorConfig struct {
+	// DepositSweepSelection is the name of the strategy selecting deposits
+	// to sweep.
+	DepositSweepSelection string
+	// RedemptionSelection is the name of the strategy selecting pending
+	// redemption requests to redeem.
+	RedemptionSelection string
+}
+
 // NewProposalGenerator returns a new proposal generator.
 func NewProposalGenerator(
 	chain Chain,
 	btcChain bitcoin.Chain,
-) *ProposalGenerator {
+	config *ProposalGeneratorConfig,
+) (*ProposalGenerator, error) {
+	depositSweepSelection, err := newDepositSweepSelection(
+		config.DepositSweepSelection,
+		chain,
+		btcChain,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot create deposit sweep selection: [%w]",
+			err,
+		)
+	}
+
+	redemptionSelection, err := newRedemptionSelection(
+		config.RedemptionSelection,
+		btcChain,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"cannot create redemption selection: [%w]",
+			err,
+		)
+	}
+
 	tasks := []ProposalTask{
-		NewDepositSweepTask(chain, btcChain),
-		NewRedemptionTask(chain, btcChain),
+		newDepositSweepTask(chain, btcChain, depositSweepSelection),
+		newRedemptionTask(chain, btcChain, redemptionSelection),
 		NewHeartbeatTask(chain),
 		NewMovingFundsTask(chain, btcChain),
 		NewMovedFundsSweepTask(chain, btcChain),
@@ -46,7 +81,7 @@ func NewProposalGenerator(
 
 	return &ProposalGenerator{
 		tasks: tasks,
-	}
+	}, nil
 }
 
 // Generate generates a coordination proposal based on the given checklist
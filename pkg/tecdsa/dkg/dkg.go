@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/ipfs/go-log/v2"
@@ -18,7 +19,7 @@ import (
 // Executor represents an ECDSA distributed key generation process executor.
 type Executor struct {
 	tssPreParamsPool         *tssPreParamsPool
-	keyGenerationConcurrency int
+	keyGenerationConcurrency atomic.Int64
 }
 
 // NewExecutor creates a new Executor instance.
@@ -36,7 +37,7 @@ func NewExecutor(
 		"ECDSA key generation concurrency level is [%d]",
 		keyGenerationConcurrency,
 	)
-	return &Executor{
+	executor := &Executor{
 		tssPreParamsPool: newTssPreParamsPool(
 			logger,
 			scheduler,
@@ -46,8 +47,31 @@ func NewExecutor(
 			preParamsGenerationDelay,
 			preParamsGenerationConcurrency,
 		),
-		keyGenerationConcurrency: keyGenerationConcurrency,
 	}
+	executor.keyGenerationConcurrency.Store(int64(keyGenerationConcurrency))
+
+	return executor
+}
+
+// UpdatePreParamsGeneration updates the pre-parameters generation timeout,
+// delay, and concurrency level. The new values take effect for the next
+// generated pre-parameters. The pool size cannot be changed at runtime.
+func (e *Executor) UpdatePreParamsGeneration(
+	preParamsGenerationTimeout time.Duration,
+	preParamsGenerationDelay time.Duration,
+	preParamsGenerationConcurrency int,
+) {
+	e.tssPreParamsPool.updateGenerationParameters(
+		preParamsGenerationTimeout,
+		preParamsGenerationDelay,
+		preParamsGenerationConcurrency,
+	)
+}
+
+// UpdateKeyGenerationConcurrency updates the concurrency level for
+// key-generation. The new value takes effect for the next executed DKG.
+func (e *Executor) UpdateKeyGenerationConcurrency(keyGenerationConcurrency int) {
+	e.keyGenerationConcurrency.Store(int64(keyGenerationConcurrency))
 }
 
 // Execute runs the tECDSA distributed key generation protocol, given a
@@ -81,7 +105,7 @@ func (e *Executor) Execute(
 		membershipValidator,
 		sessionID,
 		e.tssPreParamsPool.GetNow,
-		e.keyGenerationConcurrency,
+		int(e.keyGenerationConcurrency.Load()),
 	)
 
 	// Mark excluded members as disqualified in order to not exchange messages
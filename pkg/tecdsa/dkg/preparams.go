@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
@@ -37,6 +38,9 @@ func newPreParams(data *keygen.LocalPreParams) *PreParams {
 type tssPreParamsPool struct {
 	*generator.ParameterPool[PreParams]
 	logger log.StandardLogger
+
+	generationTimeout     atomic.Int64
+	generationConcurrency atomic.Int64
 }
 
 // newTssPreParamsPool initializes a new TSS pre-parameters pool.
@@ -58,13 +62,20 @@ func newTssPreParamsPool(
 		generationConcurrency,
 	)
 
+	tssPool := &tssPreParamsPool{logger: logger}
+	tssPool.generationTimeout.Store(int64(generationTimeout))
+	tssPool.generationConcurrency.Store(int64(generationConcurrency))
+
 	newPreParamsFn := func(ctx context.Context) *PreParams {
-		timingOutCtx, cancel := context.WithTimeout(ctx, generationTimeout)
+		timingOutCtx, cancel := context.WithTimeout(
+			ctx,
+			time.Duration(tssPool.generationTimeout.Load()),
+		)
 		defer cancel()
 
 		preParams, err := keygen.GeneratePreParamsWithContext(
 			timingOutCtx,
-			generationConcurrency,
+			int(tssPool.generationConcurrency.Load()),
 		)
 		// tss-lib returns generic errors saying "timeout or error while ...".
 		// There are three possibilities:
@@ -90,17 +101,37 @@ func newTssPreParamsPool(
 
 	tssPreParamsPersistance := newPreParamsStorage(persistence, logger)
 
-	return &tssPreParamsPool{
-		generator.NewParameterPool[PreParams](
-			logger,
-			scheduler,
-			&tssPreParamsPersistance,
-			poolSize,
-			newPreParamsFn,
-			generationDelay,
-		),
+	tssPool.ParameterPool = generator.NewParameterPool[PreParams](
 		logger,
-	}
+		scheduler,
+		&tssPreParamsPersistance,
+		poolSize,
+		newPreParamsFn,
+		generationDelay,
+	)
+
+	return tssPool
+}
+
+// updateGenerationParameters updates the pre-parameters generation timeout,
+// delay, and concurrency level. The new values take effect for the next
+// generated pre-parameters.
+func (tppp *tssPreParamsPool) updateGenerationParameters(
+	generationTimeout time.Duration,
+	generationDelay time.Duration,
+	generationConcurrency int,
+) {
+	tppp.generationTimeout.Store(int64(generationTimeout))
+	tppp.generationConcurrency.Store(int64(generationConcurrency))
+	tppp.SetGenerateDelay(generationDelay)
+
+	tppp.logger.Infof(
+		"TSS pre-parameters generation timeout is [%s], generation delay "+
+			"is [%v], and concurrency level is [%d]",
+		generationTimeout,
+		generationDelay,
+		generationConcurrency,
+	)
 }
 
 const (
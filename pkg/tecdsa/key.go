@@ -2,7 +2,10 @@ package tecdsa
 
 import (
 	"crypto/ecdsa"
+	"fmt"
+	"math/big"
 
+	"github.com/bnb-chain/tss-lib/crypto"
 	"github.com/bnb-chain/tss-lib/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/tss"
 )
@@ -44,3 +47,172 @@ func (pks *PrivateKeyShare) PublicKey() *ecdsa.PublicKey {
 func (pks *PrivateKeyShare) Data() keygen.LocalPartySaveData {
 	return pks.data
 }
+
+// RecoverPublicKey recomputes the ECDSA public key of the signing group from
+// the public key shares of all group members held by the given private key
+// share. Before that, it checks the private key share corresponds to the
+// public key share of its owner. The result should be equal to the one
+// returned by PublicKey; a difference means the private key share is
+// corrupted.
+func (pks *PrivateKeyShare) RecoverPublicKey() (*ecdsa.PublicKey, error) {
+	data := pks.data
+
+	if data.Xi == nil || data.ShareID == nil || data.ECDSAPub == nil {
+		return nil, fmt.Errorf("private key share is incomplete")
+	}
+
+	if len(data.Ks) == 0 || len(data.Ks) != len(data.BigXj) {
+		return nil, fmt.Errorf(
+			"inconsistent number of share IDs [%v] and public key shares [%v]",
+			len(data.Ks),
+			len(data.BigXj),
+		)
+	}
+
+	curve := data.ECDSAPub.Curve()
+
+	ownShareFound := false
+	for j, shareID := range data.Ks {
+		if shareID == nil || data.BigXj[j] == nil {
+			return nil, fmt.Errorf("missing group data of party [%v]", j)
+		}
+
+		if shareID.Cmp(data.ShareID) != 0 {
+			continue
+		}
+
+		ownShareFound = true
+
+		if !crypto.ScalarBaseMult(curve, data.Xi).Equals(data.BigXj[j]) {
+			return nil, fmt.Errorf(
+				"private key share does not correspond to the public key share",
+			)
+		}
+	}
+
+	if !ownShareFound {
+		return nil, fmt.Errorf("public key share of the owner not found")
+	}
+
+	// The public key is the free term of the polynomial whose values at
+	// share IDs are the public key shares. Recover it using the Lagrange
+	// interpolation at zero.
+	publicKey, err := interpolatePublicKeyShares(
+		data.Ks,
+		data.BigXj,
+		big.NewInt(0),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return publicKey.ToECDSAPubKey(), nil
+}
+
+// HonestThreshold determines the minimum number of signing group members
+// needed to produce a signature, i.e. the threshold the private key share was
+// generated with. The public key shares of all group members held by the
+// private key share are values of a polynomial whose degree is one less than
+// the threshold so the threshold is the smallest number of public key shares
+// that determine all the others.
+func (pks *PrivateKeyShare) HonestThreshold() (int, error) {
+	data := pks.data
+
+	if len(data.Ks) == 0 || len(data.Ks) != len(data.BigXj) {
+		return 0, fmt.Errorf(
+			"inconsistent number of share IDs [%v] and public key shares [%v]",
+			len(data.Ks),
+			len(data.BigXj),
+		)
+	}
+
+	// determinesNext tells whether the first k public key shares determine
+	// the next one. For a polynomial of a higher degree, the interpolated
+	// value is correct only with a negligible probability so checking
+	// a single share is enough. The result only changes from false to true
+	// as k grows so the threshold can be binary searched.
+	determinesNext := func(k int) (bool, error) {
+		interpolated, err := interpolatePublicKeyShares(
+			data.Ks[:k],
+			data.BigXj[:k],
+			data.Ks[k],
+		)
+		if err != nil {
+			return false, err
+		}
+
+		return interpolated.Equals(data.BigXj[k]), nil
+	}
+
+	low, high := 1, len(data.Ks)
+	for low < high {
+		k := (low + high) / 2
+
+		determines, err := determinesNext(k)
+		if err != nil {
+			return 0, err
+		}
+
+		if determines {
+			high = k
+		} else {
+			low = k + 1
+		}
+	}
+
+	return low, nil
+}
+
+// interpolatePublicKeyShares computes the value at the given point of the
+// polynomial whose values at the given share IDs are the given public key
+// shares, using the Lagrange interpolation.
+func interpolatePublicKeyShares(
+	shareIDs []*big.Int,
+	publicKeyShares []*crypto.ECPoint,
+	x *big.Int,
+) (*crypto.ECPoint, error) {
+	var result *crypto.ECPoint
+	for j, shareIDj := range shareIDs {
+		if shareIDj == nil || publicKeyShares[j] == nil {
+			return nil, fmt.Errorf("missing group data of party [%v]", j)
+		}
+
+		order := publicKeyShares[j].Curve().Params().N
+		coefficient := big.NewInt(1)
+
+		for m, shareIDm := range shareIDs {
+			if m == j {
+				continue
+			}
+
+			denominator := new(big.Int).Sub(shareIDj, shareIDm)
+			denominator.Mod(denominator, order)
+			inverse := new(big.Int).ModInverse(denominator, order)
+			if inverse == nil {
+				return nil, fmt.Errorf("duplicated share ID [%v]", shareIDj)
+			}
+
+			numerator := new(big.Int).Sub(x, shareIDm)
+			numerator.Mod(numerator, order)
+
+			coefficient.Mul(coefficient, numerator)
+			coefficient.Mul(coefficient, inverse)
+			coefficient.Mod(coefficient, order)
+		}
+
+		term := publicKeyShares[j].ScalarMult(coefficient)
+
+		if result == nil {
+			result = term
+			continue
+		}
+
+		var err error
+		result, err = result.Add(term)
+		if err != nil {
+			return nil, fmt.Errorf("cannot add public key shares: [%v]", err)
+		}
+	}
+
+	return result, nil
+}
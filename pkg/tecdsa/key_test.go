@@ -0,0 +1,94 @@
+package tecdsa
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/keep-network/keep-core/pkg/internal/tecdsatest"
+)
+
+func TestPrivateKeyShare_RecoverPublicKey(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(1)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	privateKeyShare := NewPrivateKeyShare(testData[0])
+
+	recoveredPublicKey, err := privateKeyShare.RecoverPublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !recoveredPublicKey.Equal(privateKeyShare.PublicKey()) {
+		t.Errorf("recovered public key differs from the group public key")
+	}
+}
+
+func TestPrivateKeyShare_HonestThreshold(t *testing.T) {
+	testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(1)
+	if err != nil {
+		t.Fatalf("failed to load test data: [%v]", err)
+	}
+
+	honestThreshold, err := NewPrivateKeyShare(testData[0]).HonestThreshold()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test fixtures represent a 3-of-5 signing group.
+	if honestThreshold != 3 {
+		t.Errorf(
+			"unexpected honest threshold\nexpected: [%v]\nactual:   [%v]",
+			3,
+			honestThreshold,
+		)
+	}
+}
+
+func TestPrivateKeyShare_RecoverPublicKey_Corrupted(t *testing.T) {
+	var tests = map[string]struct {
+		corruptFn func(share *PrivateKeyShare)
+	}{
+		"corrupted private key share": {
+			corruptFn: func(share *PrivateKeyShare) {
+				share.data.Xi = new(big.Int).Add(share.data.Xi, big.NewInt(1))
+			},
+		},
+		"corrupted public key share": {
+			corruptFn: func(share *PrivateKeyShare) {
+				last := len(share.data.BigXj) - 1
+				if share.data.Ks[last].Cmp(share.data.ShareID) == 0 {
+					last--
+				}
+
+				share.data.BigXj[last] = share.data.BigXj[last].ScalarMult(
+					big.NewInt(2),
+				)
+			},
+		},
+		"missing share ID": {
+			corruptFn: func(share *PrivateKeyShare) {
+				share.data.Ks = share.data.Ks[1:]
+			},
+		},
+	}
+
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			testData, err := tecdsatest.LoadPrivateKeyShareTestFixtures(1)
+			if err != nil {
+				t.Fatalf("failed to load test data: [%v]", err)
+			}
+
+			privateKeyShare := NewPrivateKeyShare(testData[0])
+			test.corruptFn(privateKeyShare)
+
+			recoveredPublicKey, err := privateKeyShare.RecoverPublicKey()
+			if err == nil &&
+				recoveredPublicKey.Equal(privateKeyShare.PublicKey()) {
+				t.Errorf("expected the corruption to be detected")
+			}
+		})
+	}
+}